@@ -1,12 +1,18 @@
 package accounts
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/G7DAO/protocol/signer"
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -27,8 +33,19 @@ func CreateAccountsCommand() *cobra.Command {
 	}
 
 	generateKeyfile := CreateGenerateKeyFileCommand()
+	newCmd := CreateNewCommand()
+	listCmd := CreateListCommand()
+	inspectCmd := CreateInspectCommand()
+	changePasswordCmd := CreateChangePasswordCommand()
+	exportCmd := CreateExportCommand()
+	importCmd := CreateImportCommand()
+	mnemonicCmd := CreateMnemonicCommand()
+	deriveCmd := CreateDeriveCommand()
+	signMessageCmd := CreateSignMessageCommand()
+	signTypedDataCmd := CreateSignTypedDataCommand()
+	verifyCmd := CreateVerifyCommand()
 
-	crossChainCmd.AddCommand(generateKeyfile)
+	crossChainCmd.AddCommand(generateKeyfile, newCmd, listCmd, inspectCmd, changePasswordCmd, exportCmd, importCmd, mnemonicCmd, deriveCmd, signMessageCmd, signTypedDataCmd, verifyCmd)
 
 	return crossChainCmd
 }
@@ -110,3 +127,629 @@ func CreateGenerateKeyFileCommand() *cobra.Command {
 
 	return createCmd
 }
+
+func CreateNewCommand() *cobra.Command {
+	var keystoreDir, password string
+
+	newCmd := &cobra.Command{
+		Use:   "new",
+		Short: "Create an account with a fresh random key",
+		Long:  `Create an account with a fresh random key, stored as an encrypted keyfile in the keystore directory. If no password is given, you are prompted for one.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if keystoreDir == "" {
+				return errors.New("keystore is required")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if password == "" {
+				var passwordErr error
+				password, passwordErr = PromptPassword("Password for the new account: ", true)
+				if passwordErr != nil {
+					return passwordErr
+				}
+			}
+
+			account, accountErr := OpenKeyStore(keystoreDir).NewAccount(password)
+			if accountErr != nil {
+				fmt.Printf("Error creating account: %v", accountErr)
+				return accountErr
+			}
+
+			fmt.Println("Address:", account.Address.Hex())
+			fmt.Println("Keyfile:", account.URL.Path)
+
+			return nil
+		},
+	}
+
+	newCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Keystore directory to create the account in")
+	newCmd.Flags().StringVarP(&password, "password", "p", "", "Password to encrypt the keyfile with")
+
+	return newCmd
+}
+
+func CreateListCommand() *cobra.Command {
+	var keystoreDir string
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the accounts in a keystore directory",
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if keystoreDir == "" {
+				return errors.New("keystore is required")
+			}
+
+			if _, statErr := os.Stat(keystoreDir); statErr != nil {
+				return statErr
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for i, account := range OpenKeyStore(keystoreDir).Accounts() {
+				fmt.Printf("%d\t%s\t%s\n", i, account.Address.Hex(), account.URL.Path)
+			}
+
+			return nil
+		},
+	}
+
+	listCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Keystore directory to list the accounts of")
+
+	return listCmd
+}
+
+func CreateInspectCommand() *cobra.Command {
+	var keystoreDir, keyFile, addressRaw, password string
+
+	inspectCmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Print the address of a keyfile without exposing its key",
+		Long:  `Print the address and location of a keyfile without exposing its key. If a password is given, also check that it decrypts the keyfile.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if keyFile == "" && keystoreDir == "" {
+				return errors.New("keyfile or keystore is required")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ks, account, accountErr := FindAccount(keystoreDir, keyFile, addressRaw)
+			if accountErr != nil {
+				return accountErr
+			}
+
+			fmt.Println("Address:", account.Address.Hex())
+			fmt.Println("Keyfile:", account.URL.Path)
+
+			if password != "" {
+				if unlockErr := ks.Unlock(account, password); unlockErr != nil {
+					return fmt.Errorf("password does not decrypt the keyfile: %w", unlockErr)
+				}
+				ks.Lock(account.Address)
+				fmt.Println("Password: ok")
+			}
+
+			return nil
+		},
+	}
+
+	inspectCmd.Flags().StringVar(&keyFile, "keyfile", "", "Path to the keyfile to inspect")
+	inspectCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Keystore directory holding the account (instead of --keyfile)")
+	inspectCmd.Flags().StringVar(&addressRaw, "address", "", "Address of the account in the keystore directory")
+	inspectCmd.Flags().StringVarP(&password, "password", "p", "", "Password to check against the keyfile")
+
+	return inspectCmd
+}
+
+func CreateChangePasswordCommand() *cobra.Command {
+	var keystoreDir, keyFile, addressRaw, password, newPassword string
+
+	changePasswordCmd := &cobra.Command{
+		Use:   "change-password",
+		Short: "Re-encrypt a keyfile with a new password",
+		Long:  `Re-encrypt a keyfile with a new password. Passwords that are not given are prompted for.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if keyFile == "" && keystoreDir == "" {
+				return errors.New("keyfile or keystore is required")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ks, account, accountErr := FindAccount(keystoreDir, keyFile, addressRaw)
+			if accountErr != nil {
+				return accountErr
+			}
+
+			if password == "" {
+				var passwordErr error
+				password, passwordErr = PromptPassword(fmt.Sprintf("Current password for %s: ", account.Address.Hex()), false)
+				if passwordErr != nil {
+					return passwordErr
+				}
+			}
+			if newPassword == "" {
+				var newPasswordErr error
+				newPassword, newPasswordErr = PromptPassword("New password: ", true)
+				if newPasswordErr != nil {
+					return newPasswordErr
+				}
+			}
+
+			if updateErr := ks.Update(account, password, newPassword); updateErr != nil {
+				return fmt.Errorf("could not change the password of %s: %w", account.Address.Hex(), updateErr)
+			}
+
+			fmt.Println("Address:", account.Address.Hex())
+			fmt.Println("Keyfile:", account.URL.Path)
+
+			return nil
+		},
+	}
+
+	changePasswordCmd.Flags().StringVar(&keyFile, "keyfile", "", "Path to the keyfile to re-encrypt")
+	changePasswordCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Keystore directory holding the account (instead of --keyfile)")
+	changePasswordCmd.Flags().StringVar(&addressRaw, "address", "", "Address of the account in the keystore directory")
+	changePasswordCmd.Flags().StringVarP(&password, "password", "p", "", "Current password of the keyfile")
+	changePasswordCmd.Flags().StringVar(&newPassword, "new-password", "", "New password to encrypt the keyfile with")
+
+	return changePasswordCmd
+}
+
+func CreateExportCommand() *cobra.Command {
+	var keystoreDir, keyFile, addressRaw, password, outfile string
+	var yes bool
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the private key of an account",
+		Long:  `Export the unencrypted private key of an account as hex, to stdout or to a file. Since anyone holding the exported key controls the account, you are asked to confirm the export unless --yes is given.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if keyFile == "" && keystoreDir == "" {
+				return errors.New("keyfile or keystore is required")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ks, account, accountErr := FindAccount(keystoreDir, keyFile, addressRaw)
+			if accountErr != nil {
+				return accountErr
+			}
+
+			if !yes {
+				confirmed, confirmErr := Confirm(cmd.InOrStdin(), cmd.ErrOrStderr(), fmt.Sprintf("Export the unencrypted private key of %s?", account.Address.Hex()))
+				if confirmErr != nil {
+					return confirmErr
+				}
+				if !confirmed {
+					return errors.New("export aborted")
+				}
+			}
+
+			if password == "" {
+				var passwordErr error
+				password, passwordErr = PromptPassword(fmt.Sprintf("Password for %s: ", account.Address.Hex()), false)
+				if passwordErr != nil {
+					return passwordErr
+				}
+			}
+
+			keyJson, exportErr := ks.Export(account, password, password)
+			if exportErr != nil {
+				return fmt.Errorf("could not decrypt the keyfile of %s: %w", account.Address.Hex(), exportErr)
+			}
+			key, keyErr := keystore.DecryptKey(keyJson, password)
+			if keyErr != nil {
+				return keyErr
+			}
+			privateKeyHex := hex.EncodeToString(crypto.FromECDSA(key.PrivateKey))
+
+			if outfile != "" {
+				if writeFileErr := os.WriteFile(outfile, []byte(privateKeyHex+"\n"), 0600); writeFileErr != nil {
+					fmt.Printf("Could not write private key to %s", outfile)
+					return writeFileErr
+				}
+				fmt.Println("Address:", account.Address.Hex())
+				fmt.Println("Private key written to:", outfile)
+				return nil
+			}
+
+			fmt.Println(privateKeyHex)
+
+			return nil
+		},
+	}
+
+	exportCmd.Flags().StringVar(&keyFile, "keyfile", "", "Path to the keyfile to export")
+	exportCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Keystore directory holding the account (instead of --keyfile)")
+	exportCmd.Flags().StringVar(&addressRaw, "address", "", "Address of the account in the keystore directory")
+	exportCmd.Flags().StringVarP(&password, "password", "p", "", "Password of the keyfile")
+	exportCmd.Flags().StringVarP(&outfile, "output", "o", "", "File to write the private key to, with permissions 0600 (defaults to stdout)")
+	exportCmd.Flags().BoolVarP(&yes, "yes", "y", false, "Export without asking for confirmation")
+
+	return exportCmd
+}
+
+func CreateImportCommand() *cobra.Command {
+	var keystoreDir, password, mnemonicFile, passphrase, pathRaw string
+	var path gethaccounts.DerivationPath
+
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import an account from a BIP-39 mnemonic",
+		Long:  `Import the account at a BIP-44 derivation path of a BIP-39 mnemonic into a keystore directory. The mnemonic is read from --mnemonic-file, or prompted for if no file is given.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if keystoreDir == "" {
+				return errors.New("keystore is required")
+			}
+
+			var pathErr error
+			path, pathErr = gethaccounts.ParseDerivationPath(pathRaw)
+			if pathErr != nil {
+				return fmt.Errorf("invalid derivation path %s: %w", pathRaw, pathErr)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mnemonic, mnemonicErr := ReadMnemonic(mnemonicFile)
+			if mnemonicErr != nil {
+				return mnemonicErr
+			}
+
+			privateKey, privateKeyErr := DeriveKeyFromMnemonic(mnemonic, passphrase, path)
+			if privateKeyErr != nil {
+				return privateKeyErr
+			}
+
+			if password == "" {
+				var passwordErr error
+				password, passwordErr = PromptPassword("Password for the imported account: ", true)
+				if passwordErr != nil {
+					return passwordErr
+				}
+			}
+
+			account, importErr := OpenKeyStore(keystoreDir).ImportECDSA(privateKey, password)
+			if importErr != nil {
+				return fmt.Errorf("could not import %s: %w", crypto.PubkeyToAddress(privateKey.PublicKey).Hex(), importErr)
+			}
+
+			fmt.Println("Address:", account.Address.Hex())
+			fmt.Println("Path:", path.String())
+			fmt.Println("Keyfile:", account.URL.Path)
+
+			return nil
+		},
+	}
+
+	importCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Keystore directory to import the account into")
+	importCmd.Flags().StringVarP(&password, "password", "p", "", "Password to encrypt the keyfile with")
+	importCmd.Flags().StringVar(&mnemonicFile, "mnemonic-file", "", "File holding the BIP-39 mnemonic (prompted for if not given)")
+	importCmd.Flags().StringVar(&passphrase, "passphrase", "", "Optional BIP-39 passphrase protecting the mnemonic")
+	importCmd.Flags().StringVar(&pathRaw, "path", DefaultDerivationPath, "BIP-44 derivation path of the account to import")
+
+	return importCmd
+}
+
+func CreateMnemonicCommand() *cobra.Command {
+	var words int
+
+	mnemonicCmd := &cobra.Command{
+		Use:   "mnemonic",
+		Short: "Generate a new BIP-39 mnemonic",
+		Long:  `Generate a new random BIP-39 mnemonic, from which accounts can be derived with "accounts derive" and "accounts import". Anyone holding the mnemonic controls all the accounts derived from it, so store it as carefully as a private key.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if words < 12 || words > 24 || words%3 != 0 {
+				return errors.New("words must be 12, 15, 18, 21 or 24")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mnemonic, mnemonicErr := NewMnemonic(words)
+			if mnemonicErr != nil {
+				return mnemonicErr
+			}
+
+			fmt.Println(mnemonic)
+
+			return nil
+		},
+	}
+
+	mnemonicCmd.Flags().IntVar(&words, "words", 24, "Number of words of the mnemonic (12, 15, 18, 21 or 24)")
+
+	return mnemonicCmd
+}
+
+func CreateDeriveCommand() *cobra.Command {
+	var keystoreDir, password, mnemonicFile, passphrase, pathTemplate, manifestFile string
+	var start, count uint32
+
+	deriveCmd := &cobra.Command{
+		Use:   "derive",
+		Short: "Derive a fleet of accounts from a BIP-39 mnemonic",
+		Long: `Derive count consecutive accounts from a BIP-39 mnemonic and print a JSON manifest of their indices, derivation paths and addresses.
+
+The account with index i is derived at the derivation path obtained by replacing {index} with i in --path. Derivation is deterministic: the same mnemonic, passphrase and path always derive the same accounts, so any of them can be recovered from the mnemonic.
+
+With --keystore, an encrypted keyfile is written for every account, all with the same password. Accounts already present in the keystore are kept, so a fleet can be grown by deriving it again with a larger count.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if count == 0 {
+				return errors.New("count must be positive")
+			}
+
+			if _, pathErr := DerivationPathAt(pathTemplate, start); pathErr != nil {
+				return fmt.Errorf("invalid derivation path %s: %w", pathTemplate, pathErr)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mnemonic, mnemonicErr := ReadMnemonic(mnemonicFile)
+			if mnemonicErr != nil {
+				return mnemonicErr
+			}
+
+			derivedAccounts, deriveErr := DeriveAccounts(mnemonic, passphrase, pathTemplate, start, count)
+			if deriveErr != nil {
+				return deriveErr
+			}
+
+			var ks *keystore.KeyStore
+			if keystoreDir != "" {
+				if password == "" {
+					var passwordErr error
+					password, passwordErr = PromptPassword("Password for the derived accounts: ", true)
+					if passwordErr != nil {
+						return passwordErr
+					}
+				}
+				ks = OpenKeyStore(keystoreDir)
+			}
+
+			manifest, importErr := ImportDerivedAccounts(ks, derivedAccounts, password)
+			if importErr != nil {
+				return importErr
+			}
+
+			manifestJSON, marshalErr := json.MarshalIndent(manifest, "", "  ")
+			if marshalErr != nil {
+				return marshalErr
+			}
+
+			if manifestFile != "" {
+				if writeFileErr := os.WriteFile(manifestFile, append(manifestJSON, '\n'), 0644); writeFileErr != nil {
+					fmt.Printf("Could not write manifest to %s", manifestFile)
+					return writeFileErr
+				}
+			}
+
+			fmt.Println(string(manifestJSON))
+
+			return nil
+		},
+	}
+
+	deriveCmd.Flags().StringVar(&mnemonicFile, "mnemonic-file", "", "File holding the BIP-39 mnemonic (prompted for if not given)")
+	deriveCmd.Flags().StringVar(&passphrase, "passphrase", "", "Optional BIP-39 passphrase protecting the mnemonic")
+	deriveCmd.Flags().StringVar(&pathTemplate, "path", DefaultDerivationPathTemplate, "Derivation path template, in which {index} is replaced by the index of each account")
+	deriveCmd.Flags().Uint32Var(&start, "start", 0, "Index of the first account to derive")
+	deriveCmd.Flags().Uint32VarP(&count, "count", "n", 1, "Number of accounts to derive")
+	deriveCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Keystore directory to write encrypted keyfiles to (if not given, only the manifest is printed)")
+	deriveCmd.Flags().StringVarP(&password, "password", "p", "", "Password to encrypt the keyfiles with")
+	deriveCmd.Flags().StringVar(&manifestFile, "manifest", "", "File to also write the manifest to")
+
+	return deriveCmd
+}
+
+// Adds the flags selecting the account that signs: --keyfile, --keystore and --address, --password and --signer.
+func addSigningAccountFlags(cmd *cobra.Command, keyFile, keystoreDir, addressRaw, password, signerURI *string) {
+	cmd.Flags().StringVar(keyFile, "keyfile", "", "Path to the keyfile of the signing account")
+	cmd.Flags().StringVar(keystoreDir, "keystore", "", "Keystore directory holding the signing account (instead of --keyfile)")
+	cmd.Flags().StringVar(addressRaw, "address", "", "Address of the signing account in the keystore directory")
+	cmd.Flags().StringVarP(password, "password", "p", "", "Password of the keyfile")
+	signer.AddSignerFlag(cmd, signerURI)
+}
+
+func CreateSignMessageCommand() *cobra.Command {
+	var keyFile, keystoreDir, addressRaw, password, signerURI, messageRaw, messageFile string
+	var isHex bool
+
+	signMessageCmd := &cobra.Command{
+		Use:   "sign-message",
+		Short: "Sign a message as personal_sign does (EIP-191)",
+		Long:  `Sign a message with the "\x19Ethereum Signed Message:\n" prefix of EIP-191, as personal_sign and wallets do. The signature is printed as hex, with V set to 27 or 28.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if keyFile == "" && keystoreDir == "" && signerURI == "" {
+				return errors.New("keyfile, keystore or signer is required")
+			}
+
+			if (messageRaw == "") == (messageFile == "") {
+				return errors.New("exactly one of message and message-file is required")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			message, messageErr := ReadMessage(messageRaw, messageFile, isHex)
+			if messageErr != nil {
+				return messageErr
+			}
+
+			sender, senderErr := SignerFromFlags(cmd.Context(), signerURI, keystoreDir, keyFile, addressRaw, password)
+			if senderErr != nil {
+				return senderErr
+			}
+
+			signature, signErr := sender.SignMessage(cmd.Context(), message)
+			if signErr != nil {
+				return fmt.Errorf("could not sign message: %w", signErr)
+			}
+
+			fmt.Println("Address:", sender.Address().Hex())
+			fmt.Println("Signature:", EncodeSignature(signature))
+
+			return nil
+		},
+	}
+
+	addSigningAccountFlags(signMessageCmd, &keyFile, &keystoreDir, &addressRaw, &password, &signerURI)
+	signMessageCmd.Flags().StringVarP(&messageRaw, "message", "m", "", "Message to sign")
+	signMessageCmd.Flags().StringVar(&messageFile, "message-file", "", "File holding the message to sign (instead of --message)")
+	signMessageCmd.Flags().BoolVar(&isHex, "hex", false, "Treat the message as 0x-prefixed hex encoded bytes")
+
+	return signMessageCmd
+}
+
+func CreateSignTypedDataCommand() *cobra.Command {
+	var keyFile, keystoreDir, addressRaw, password, signerURI, typedDataFile string
+
+	signTypedDataCmd := &cobra.Command{
+		Use:   "sign-typed-data",
+		Short: "Sign EIP-712 typed data from a JSON file",
+		Long: `Sign EIP-712 typed data, as eth_signTypedData_v4 does. The typed data is read from a JSON file with types, primaryType, domain and message fields.
+
+The EIP-712 hash of the data is printed along with the signature (V set to 27 or 28). For a SafeTx, the hash is the safeTxHash of the transaction and the signature can be submitted as an owner confirmation.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if keyFile == "" && keystoreDir == "" && signerURI == "" {
+				return errors.New("keyfile, keystore or signer is required")
+			}
+
+			if typedDataFile == "" {
+				return errors.New("typed-data is required")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			typedData, typedDataErr := ReadTypedDataFile(typedDataFile)
+			if typedDataErr != nil {
+				return typedDataErr
+			}
+
+			hash, hashErr := signer.HashTypedData(typedData)
+			if hashErr != nil {
+				return hashErr
+			}
+
+			sender, senderErr := SignerFromFlags(cmd.Context(), signerURI, keystoreDir, keyFile, addressRaw, password)
+			if senderErr != nil {
+				return senderErr
+			}
+
+			signature, signErr := sender.SignTypedData(cmd.Context(), typedData)
+			if signErr != nil {
+				return fmt.Errorf("could not sign typed data: %w", signErr)
+			}
+
+			fmt.Println("Address:", sender.Address().Hex())
+			fmt.Println("Hash:", hash.Hex())
+			fmt.Println("Signature:", EncodeSignature(signature))
+
+			return nil
+		},
+	}
+
+	addSigningAccountFlags(signTypedDataCmd, &keyFile, &keystoreDir, &addressRaw, &password, &signerURI)
+	signTypedDataCmd.Flags().StringVar(&typedDataFile, "typed-data", "", "JSON file holding the EIP-712 typed data to sign")
+
+	return signTypedDataCmd
+}
+
+func CreateVerifyCommand() *cobra.Command {
+	var messageRaw, messageFile, typedDataFile, signatureRaw, expectedRaw string
+	var isHex bool
+	var signature []byte
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Recover the address that signed a message or typed data",
+		Long:  `Recover the address that signed a message (EIP-191) or EIP-712 typed data. If an expected address is given, the command fails unless the signature was made by that address.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			inputs := 0
+			for _, input := range []string{messageRaw, messageFile, typedDataFile} {
+				if input != "" {
+					inputs++
+				}
+			}
+			if inputs != 1 {
+				return errors.New("exactly one of message, message-file and typed-data is required")
+			}
+
+			if signatureRaw == "" {
+				return errors.New("signature is required")
+			}
+			var signatureErr error
+			signature, signatureErr = hexutil.Decode(signatureRaw)
+			if signatureErr != nil {
+				return fmt.Errorf("signature is not valid 0x-prefixed hex: %w", signatureErr)
+			}
+
+			if expectedRaw != "" && !common.IsHexAddress(expectedRaw) {
+				return fmt.Errorf("expected address is not a valid Ethereum address: %s", expectedRaw)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var recovered common.Address
+			if typedDataFile != "" {
+				typedData, typedDataErr := ReadTypedDataFile(typedDataFile)
+				if typedDataErr != nil {
+					return typedDataErr
+				}
+
+				var recoverErr error
+				recovered, recoverErr = signer.RecoverTypedDataSigner(typedData, signature)
+				if recoverErr != nil {
+					return recoverErr
+				}
+			} else {
+				message, messageErr := ReadMessage(messageRaw, messageFile, isHex)
+				if messageErr != nil {
+					return messageErr
+				}
+
+				var recoverErr error
+				recovered, recoverErr = signer.RecoverMessageSigner(message, signature)
+				if recoverErr != nil {
+					return recoverErr
+				}
+			}
+
+			fmt.Println("Address:", recovered.Hex())
+
+			if expectedRaw != "" && recovered != common.HexToAddress(expectedRaw) {
+				return fmt.Errorf("signature was made by %s, not by %s", recovered.Hex(), common.HexToAddress(expectedRaw).Hex())
+			}
+
+			return nil
+		},
+	}
+
+	verifyCmd.Flags().StringVarP(&messageRaw, "message", "m", "", "Signed message")
+	verifyCmd.Flags().StringVar(&messageFile, "message-file", "", "File holding the signed message (instead of --message)")
+	verifyCmd.Flags().BoolVar(&isHex, "hex", false, "Treat the message as 0x-prefixed hex encoded bytes")
+	verifyCmd.Flags().StringVar(&typedDataFile, "typed-data", "", "JSON file holding the signed EIP-712 typed data (instead of a message)")
+	verifyCmd.Flags().StringVarP(&signatureRaw, "signature", "s", "", "Signature to verify, as 0x-prefixed hex")
+	verifyCmd.Flags().StringVar(&expectedRaw, "expect", "", "Address that is expected to have made the signature")
+
+	return verifyCmd
+}
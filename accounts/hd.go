@@ -0,0 +1,166 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Default BIP-44 derivation path for Ethereum accounts, as used by most wallets.
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+// Placeholder for the account index in derivation path templates.
+const IndexPlaceholder = "{index}"
+
+// Default derivation path template for fleets of accounts: the accounts are consecutive children of the default
+// BIP-44 account.
+const DefaultDerivationPathTemplate = "m/44'/60'/0'/0/" + IndexPlaceholder
+
+var ErrInvalidChildKey = errors.New("derived key is invalid for this index, use the next index instead")
+
+// DerivedAccount is an account derived from a mnemonic, together with the index and path it was derived at.
+type DerivedAccount struct {
+	Index      uint32
+	Path       gethaccounts.DerivationPath
+	Address    common.Address
+	PrivateKey *ecdsa.PrivateKey
+}
+
+// Returns the seed of the wallet described by a BIP-39 mnemonic and its optional passphrase.
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	seed, seedErr := bip39.NewSeedWithErrorChecking(NormalizeMnemonic(mnemonic), passphrase)
+	if seedErr != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", seedErr)
+	}
+	return seed, nil
+}
+
+// Returns a new random BIP-39 mnemonic with the given number of words (12, 15, 18, 21 or 24).
+func NewMnemonic(words int) (string, error) {
+	if words < 12 || words > 24 || words%3 != 0 {
+		return "", fmt.Errorf("mnemonics have 12, 15, 18, 21 or 24 words, not %d", words)
+	}
+
+	entropy, entropyErr := bip39.NewEntropy(words * 32 / 3)
+	if entropyErr != nil {
+		return "", entropyErr
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// Returns the derivation path obtained by substituting index for the {index} placeholder of a path template, for
+// example m/44'/60'/0'/0/{index} or m/44'/60'/{index}'/0/0.
+func DerivationPathAt(template string, index uint32) (gethaccounts.DerivationPath, error) {
+	if strings.Count(template, IndexPlaceholder) != 1 {
+		return nil, fmt.Errorf("derivation path template %s must contain %s exactly once", template, IndexPlaceholder)
+	}
+	return gethaccounts.ParseDerivationPath(strings.Replace(template, IndexPlaceholder, strconv.FormatUint(uint64(index), 10), 1))
+}
+
+// Derives count consecutive accounts, starting at index start, along a derivation path template of the wallet
+// described by a BIP-39 mnemonic and its optional passphrase. The same arguments always derive the same accounts.
+func DeriveAccounts(mnemonic string, passphrase string, template string, start uint32, count uint32) ([]DerivedAccount, error) {
+	if uint64(start)+uint64(count) > 0x80000000 {
+		return nil, fmt.Errorf("indices %d to %d exceed the range of derivation path indices", start, uint64(start)+uint64(count)-1)
+	}
+
+	seed, seedErr := MnemonicToSeed(mnemonic, passphrase)
+	if seedErr != nil {
+		return nil, seedErr
+	}
+
+	derivedAccounts := make([]DerivedAccount, 0, count)
+	for index := start; index < start+count; index++ {
+		path, pathErr := DerivationPathAt(template, index)
+		if pathErr != nil {
+			return nil, pathErr
+		}
+
+		privateKey, privateKeyErr := DeriveKey(seed, path)
+		if privateKeyErr != nil {
+			return nil, fmt.Errorf("could not derive the account at %s: %w", path.String(), privateKeyErr)
+		}
+
+		derivedAccounts = append(derivedAccounts, DerivedAccount{
+			Index:      index,
+			Path:       path,
+			Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+			PrivateKey: privateKey,
+		})
+	}
+
+	return derivedAccounts, nil
+}
+
+// Returns the private key at the given derivation path of the wallet described by a BIP-39 mnemonic and its optional
+// passphrase.
+func DeriveKeyFromMnemonic(mnemonic string, passphrase string, path gethaccounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	seed, seedErr := MnemonicToSeed(mnemonic, passphrase)
+	if seedErr != nil {
+		return nil, seedErr
+	}
+	return DeriveKey(seed, path)
+}
+
+// Returns the private key at the given derivation path of the BIP-32 wallet built from seed.
+func DeriveKey(seed []byte, path gethaccounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	curveOrder := crypto.S256().Params().N
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	digest := mac.Sum(nil)
+
+	key := new(big.Int).SetBytes(digest[:32])
+	chainCode := digest[32:]
+	if key.Sign() == 0 || key.Cmp(curveOrder) >= 0 {
+		return nil, errors.New("seed does not produce a valid master key")
+	}
+
+	for _, index := range path {
+		data := make([]byte, 0, 37)
+		if index >= 0x80000000 {
+			data = append(data, 0)
+			data = append(data, math.PaddedBigBytes(key, 32)...)
+		} else {
+			privateKey, privateKeyErr := crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+			if privateKeyErr != nil {
+				return nil, privateKeyErr
+			}
+			data = append(data, crypto.CompressPubkey(&privateKey.PublicKey)...)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		digest := mac.Sum(nil)
+
+		tweak := new(big.Int).SetBytes(digest[:32])
+		if tweak.Cmp(curveOrder) >= 0 {
+			return nil, ErrInvalidChildKey
+		}
+		key.Add(key, tweak).Mod(key, curveOrder)
+		if key.Sign() == 0 {
+			return nil, ErrInvalidChildKey
+		}
+		chainCode = digest[32:]
+	}
+
+	return crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+}
+
+// Collapses the whitespace between the words of a mnemonic, so that mnemonics pasted over several lines are accepted.
+func NormalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(mnemonic), " ")
+}
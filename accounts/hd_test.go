@@ -0,0 +1,90 @@
+package accounts
+
+import (
+	"testing"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Test vector 1 of BIP-32.
+func TestDeriveKey(t *testing.T) {
+	seed := common.FromHex("000102030405060708090a0b0c0d0e0f")
+	vectors := []struct {
+		path       string
+		privateKey string
+	}{
+		{"m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{"m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+
+	for _, vector := range vectors {
+		var path gethaccounts.DerivationPath
+		if vector.path != "m" {
+			var pathErr error
+			path, pathErr = gethaccounts.ParseDerivationPath(vector.path)
+			if pathErr != nil {
+				t.Fatal(pathErr)
+			}
+		}
+
+		privateKey, deriveErr := DeriveKey(seed, path)
+		if deriveErr != nil {
+			t.Fatal(deriveErr)
+		}
+		if derived := common.Bytes2Hex(crypto.FromECDSA(privateKey)); derived != vector.privateKey {
+			t.Errorf("Expected private key %s at %s, got %s", vector.privateKey, vector.path, derived)
+		}
+	}
+}
+
+func TestDeriveKeyFromMnemonic(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon\nabandon abandon abandon abandon abandon about"
+	path, pathErr := gethaccounts.ParseDerivationPath(DefaultDerivationPath)
+	if pathErr != nil {
+		t.Fatal(pathErr)
+	}
+
+	privateKey, deriveErr := DeriveKeyFromMnemonic(mnemonic, "", path)
+	if deriveErr != nil {
+		t.Fatal(deriveErr)
+	}
+	expected := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	if address := crypto.PubkeyToAddress(privateKey.PublicKey); address != expected {
+		t.Errorf("Expected address %s, got %s", expected.Hex(), address.Hex())
+	}
+
+	if _, invalidErr := DeriveKeyFromMnemonic("abandon abandon abandon", "", path); invalidErr == nil {
+		t.Error("Expected an error for an invalid mnemonic")
+	}
+}
+
+// The default Hardhat and Anvil accounts are derived from this mnemonic along the default path template.
+func TestDeriveAccounts(t *testing.T) {
+	mnemonic := "test test test test test test test test test test test junk"
+	expected := []common.Address{
+		common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"),
+	}
+
+	derivedAccounts, deriveErr := DeriveAccounts(mnemonic, "", DefaultDerivationPathTemplate, 1, 2)
+	if deriveErr != nil {
+		t.Fatal(deriveErr)
+	}
+	if len(derivedAccounts) != len(expected) {
+		t.Fatalf("Expected %d accounts, got %d", len(expected), len(derivedAccounts))
+	}
+	for i, derivedAccount := range derivedAccounts {
+		if derivedAccount.Index != uint32(i+1) || derivedAccount.Address != expected[i] {
+			t.Errorf("Expected account %d to be %s, got account %d with address %s", i+1, expected[i].Hex(), derivedAccount.Index, derivedAccount.Address.Hex())
+		}
+	}
+
+	if _, templateErr := DeriveAccounts(mnemonic, "", "m/44'/60'/0'/0/0", 0, 1); templateErr == nil {
+		t.Error("Expected an error for a path template without an index placeholder")
+	}
+}
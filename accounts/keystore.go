@@ -0,0 +1,154 @@
+package accounts
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/term"
+)
+
+// Opens the keystore directory at the given path, creating it if it does not exist.
+func OpenKeyStore(keystoreDir string) *keystore.KeyStore {
+	return keystore.NewKeyStore(keystoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+// Returns the account to operate on together with the keystore holding it. The account is either given by its keyfile,
+// or by its address within a keystore directory. Without an address, the keystore directory must hold a single account.
+func FindAccount(keystoreDir string, keyFile string, addressRaw string) (*keystore.KeyStore, gethaccounts.Account, error) {
+	if keyFile != "" {
+		keyFilePath, absErr := filepath.Abs(keyFile)
+		if absErr != nil {
+			return nil, gethaccounts.Account{}, absErr
+		}
+		if _, statErr := os.Stat(keyFilePath); statErr != nil {
+			return nil, gethaccounts.Account{}, statErr
+		}
+
+		ks := OpenKeyStore(filepath.Dir(keyFilePath))
+		account, findErr := ks.Find(gethaccounts.Account{URL: gethaccounts.URL{Scheme: keystore.KeyStoreScheme, Path: keyFilePath}})
+		if findErr != nil {
+			return nil, gethaccounts.Account{}, fmt.Errorf("%s is not a valid keyfile: %w", keyFile, findErr)
+		}
+		return ks, account, nil
+	}
+
+	if keystoreDir == "" {
+		return nil, gethaccounts.Account{}, errors.New("keyfile or keystore is required")
+	}
+	ks := OpenKeyStore(keystoreDir)
+
+	if addressRaw == "" {
+		accounts := ks.Accounts()
+		if len(accounts) != 1 {
+			return nil, gethaccounts.Account{}, fmt.Errorf("keystore %s holds %d accounts, choose one with --address", keystoreDir, len(accounts))
+		}
+		return ks, accounts[0], nil
+	}
+
+	if !common.IsHexAddress(addressRaw) {
+		return nil, gethaccounts.Account{}, fmt.Errorf("address is not a valid Ethereum address: %s", addressRaw)
+	}
+	account, findErr := ks.Find(gethaccounts.Account{Address: common.HexToAddress(addressRaw)})
+	if findErr != nil {
+		return nil, gethaccounts.Account{}, fmt.Errorf("could not find %s in keystore %s: %w", addressRaw, keystoreDir, findErr)
+	}
+	return ks, account, nil
+}
+
+// Prompts for a password on the terminal without echoing it. With confirm, the password has to be typed twice and may
+// not be empty, as is expected when choosing a new password.
+func PromptPassword(prompt string, confirm bool) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	passwordRaw, inputErr := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprint(os.Stderr, "\n")
+	if inputErr != nil {
+		return "", fmt.Errorf("error reading password: %s", inputErr.Error())
+	}
+
+	if confirm {
+		if len(passwordRaw) == 0 {
+			return "", errors.New("password may not be empty")
+		}
+
+		fmt.Fprint(os.Stderr, "Repeat password: ")
+		repeatedRaw, repeatErr := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprint(os.Stderr, "\n")
+		if repeatErr != nil {
+			return "", fmt.Errorf("error reading password: %s", repeatErr.Error())
+		}
+		if string(repeatedRaw) != string(passwordRaw) {
+			return "", errors.New("passwords do not match")
+		}
+	}
+
+	return string(passwordRaw), nil
+}
+
+// Asks a yes/no question on the given input, defaulting to no.
+func Confirm(input io.Reader, output io.Writer, question string) (bool, error) {
+	fmt.Fprintf(output, "%s [y/N]: ", question)
+	answer, readErr := bufio.NewReader(input).ReadString('\n')
+	if readErr != nil && readErr != io.EOF {
+		return false, readErr
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// ManifestEntry records the index, derivation path and keyfile of an account derived from a mnemonic.
+type ManifestEntry struct {
+	Index   uint32 `json:"index"`
+	Path    string `json:"path"`
+	Address string `json:"address"`
+	Keyfile string `json:"keyfile,omitempty"`
+}
+
+// Writes encrypted keyfiles for derived accounts to a keystore and returns the manifest of the accounts. Accounts that
+// the keystore already holds are left as they are, so that a fleet can be extended by deriving it again with a higher
+// count. If ks is nil, no keyfiles are written and the manifest only maps indices to addresses.
+func ImportDerivedAccounts(ks *keystore.KeyStore, derivedAccounts []DerivedAccount, password string) ([]ManifestEntry, error) {
+	manifest := make([]ManifestEntry, 0, len(derivedAccounts))
+	for _, derivedAccount := range derivedAccounts {
+		entry := ManifestEntry{
+			Index:   derivedAccount.Index,
+			Path:    derivedAccount.Path.String(),
+			Address: derivedAccount.Address.Hex(),
+		}
+
+		if ks != nil {
+			account, importErr := ks.ImportECDSA(derivedAccount.PrivateKey, password)
+			if errors.Is(importErr, keystore.ErrAccountAlreadyExists) {
+				account, importErr = ks.Find(gethaccounts.Account{Address: derivedAccount.Address})
+			}
+			if importErr != nil {
+				return nil, fmt.Errorf("could not write the keyfile of account %d (%s): %w", derivedAccount.Index, entry.Address, importErr)
+			}
+			entry.Keyfile = account.URL.Path
+		}
+
+		manifest = append(manifest, entry)
+	}
+
+	return manifest, nil
+}
+
+// Reads a BIP-39 mnemonic from the given file, or prompts for it without echoing it if no file is given.
+func ReadMnemonic(mnemonicFile string) (string, error) {
+	if mnemonicFile == "" {
+		return PromptPassword("Mnemonic: ", false)
+	}
+
+	mnemonicRaw, readErr := os.ReadFile(mnemonicFile)
+	if readErr != nil {
+		return "", readErr
+	}
+	return string(mnemonicRaw), nil
+}
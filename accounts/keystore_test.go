@@ -0,0 +1,128 @@
+package accounts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// Opens a keystore with light scrypt parameters, so that the tests do not spend seconds encrypting each key.
+func openTestKeyStore(keystoreDir string) *keystore.KeyStore {
+	return keystore.NewKeyStore(keystoreDir, keystore.LightScryptN, keystore.LightScryptP)
+}
+
+func deriveTestAccounts(t *testing.T, count uint32) []DerivedAccount {
+	derivedAccounts, deriveErr := DeriveAccounts(testMnemonic, "", DefaultDerivationPathTemplate, 0, count)
+	if deriveErr != nil {
+		t.Fatal(deriveErr)
+	}
+	return derivedAccounts
+}
+
+func TestImportDerivedAccounts(t *testing.T) {
+	keystoreDir := t.TempDir()
+	ks := openTestKeyStore(keystoreDir)
+
+	manifest, importErr := ImportDerivedAccounts(ks, deriveTestAccounts(t, 2), "password")
+	if importErr != nil {
+		t.Fatal(importErr)
+	}
+	if len(manifest) != 2 {
+		t.Fatalf("Expected 2 manifest entries, got %d", len(manifest))
+	}
+	for _, entry := range manifest {
+		if _, statErr := os.Stat(entry.Keyfile); statErr != nil {
+			t.Errorf("Keyfile of account %d was not written: %v", entry.Index, statErr)
+		}
+	}
+
+	// Deriving the fleet again with a higher count adds the new accounts and keeps the keyfiles of the existing ones.
+	grown, importErr := ImportDerivedAccounts(ks, deriveTestAccounts(t, 3), "password")
+	if importErr != nil {
+		t.Fatal(importErr)
+	}
+	if len(grown) != 3 {
+		t.Fatalf("Expected 3 manifest entries, got %d", len(grown))
+	}
+	for i, entry := range manifest {
+		if grown[i] != entry {
+			t.Errorf("Expected account %d to be left as it is, got %+v instead of %+v", i, grown[i], entry)
+		}
+	}
+	keyfiles, readErr := os.ReadDir(keystoreDir)
+	if readErr != nil {
+		t.Fatal(readErr)
+	}
+	if len(keyfiles) != 3 || len(ks.Accounts()) != 3 {
+		t.Errorf("Expected 3 keyfiles, got %d files and %d accounts", len(keyfiles), len(ks.Accounts()))
+	}
+
+	unkeyed, importErr := ImportDerivedAccounts(nil, deriveTestAccounts(t, 1), "password")
+	if importErr != nil {
+		t.Fatal(importErr)
+	}
+	if len(unkeyed) != 1 || unkeyed[0].Keyfile != "" || unkeyed[0].Address != manifest[0].Address {
+		t.Errorf("Expected a manifest without keyfiles, got %+v", unkeyed)
+	}
+}
+
+func TestFindAccount(t *testing.T) {
+	keystoreDir := t.TempDir()
+	manifest, importErr := ImportDerivedAccounts(openTestKeyStore(keystoreDir), deriveTestAccounts(t, 1), "password")
+	if importErr != nil {
+		t.Fatal(importErr)
+	}
+
+	_, account, findErr := FindAccount("", manifest[0].Keyfile, "")
+	if findErr != nil {
+		t.Fatal(findErr)
+	}
+	if account.Address.Hex() != manifest[0].Address {
+		t.Errorf("Expected account %s from the keyfile, got %s", manifest[0].Address, account.Address.Hex())
+	}
+
+	// A keystore holding a single account does not need an address.
+	_, account, findErr = FindAccount(keystoreDir, "", "")
+	if findErr != nil {
+		t.Fatal(findErr)
+	}
+	if account.Address.Hex() != manifest[0].Address {
+		t.Errorf("Expected the only account %s of the keystore, got %s", manifest[0].Address, account.Address.Hex())
+	}
+
+	manifest, importErr = ImportDerivedAccounts(openTestKeyStore(keystoreDir), deriveTestAccounts(t, 2), "password")
+	if importErr != nil {
+		t.Fatal(importErr)
+	}
+	if _, _, findErr := FindAccount(keystoreDir, "", ""); findErr == nil {
+		t.Error("Expected an error without an address for a keystore holding several accounts")
+	}
+	_, account, findErr = FindAccount(keystoreDir, "", manifest[1].Address)
+	if findErr != nil {
+		t.Fatal(findErr)
+	}
+	if account.Address.Hex() != manifest[1].Address || account.URL.Path != manifest[1].Keyfile {
+		t.Errorf("Expected account %s in %s, got %s in %s", manifest[1].Address, manifest[1].Keyfile, account.Address.Hex(), account.URL.Path)
+	}
+
+	invalid := []struct {
+		name        string
+		keystoreDir string
+		keyFile     string
+		address     string
+	}{
+		{name: "no keyfile or keystore"},
+		{name: "missing keyfile", keyFile: filepath.Join(keystoreDir, "missing.json")},
+		{name: "invalid address", keystoreDir: keystoreDir, address: "0x123"},
+		{name: "address not in the keystore", keystoreDir: keystoreDir, address: "0x00000000000000000000000000000000000000aa"},
+	}
+	for _, c := range invalid {
+		if _, _, findErr := FindAccount(c.keystoreDir, c.keyFile, c.address); findErr == nil {
+			t.Errorf("Expected an error for %s", c.name)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Returns the signer for the account selected by --signer, --keyfile, or --keystore and --address, in that order of
+// precedence.
+func SignerFromFlags(ctx context.Context, signerURI string, keystoreDir string, keyFile string, addressRaw string, password string) (signer.Signer, error) {
+	if signerURI == "" && keyFile == "" && keystoreDir != "" {
+		_, account, accountErr := FindAccount(keystoreDir, "", addressRaw)
+		if accountErr != nil {
+			return nil, accountErr
+		}
+		keyFile = account.URL.Path
+	}
+
+	return signer.FromFlags(ctx, signerURI, keyFile, password)
+}
+
+// Returns the message given either literally or as the contents of a file. If isHex is set, the message is hex
+// encoded, which allows signing arbitrary bytes such as hashes.
+func ReadMessage(messageRaw string, messageFile string, isHex bool) ([]byte, error) {
+	if (messageRaw == "") == (messageFile == "") {
+		return nil, errors.New("exactly one of message and message file is required")
+	}
+
+	message := []byte(messageRaw)
+	if messageFile != "" {
+		var readErr error
+		message, readErr = os.ReadFile(messageFile)
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+
+	if isHex {
+		decoded, decodeErr := hexutil.Decode(string(message))
+		if decodeErr != nil {
+			return nil, fmt.Errorf("message is not valid 0x-prefixed hex: %w", decodeErr)
+		}
+		return decoded, nil
+	}
+
+	return message, nil
+}
+
+// Reads EIP-712 typed data from a JSON file in the format taken by eth_signTypedData_v4, with types, primaryType,
+// domain and message fields.
+func ReadTypedDataFile(typedDataFile string) (apitypes.TypedData, error) {
+	var typedData apitypes.TypedData
+
+	typedDataRaw, readErr := os.ReadFile(typedDataFile)
+	if readErr != nil {
+		return typedData, readErr
+	}
+
+	if unmarshalErr := json.Unmarshal(typedDataRaw, &typedData); unmarshalErr != nil {
+		return typedData, fmt.Errorf("could not parse typed data from %s: %w", typedDataFile, unmarshalErr)
+	}
+	if typedData.PrimaryType == "" {
+		return typedData, fmt.Errorf("typed data in %s has no primaryType", typedDataFile)
+	}
+
+	return typedData, nil
+}
+
+// Encodes a signature with V set to 27 or 28, as expected by ecrecover and most tools verifying signatures.
+func EncodeSignature(signature []byte) string {
+	encoded := make([]byte, len(signature))
+	copy(encoded, signature)
+	if len(encoded) == 65 && encoded[64] < 27 {
+		encoded[64] += 27
+	}
+	return hexutil.Encode(encoded)
+}
@@ -0,0 +1,1114 @@
+// This file was generated by seer: https://github.com/G7DAO/seer.
+// seer version: 0.3.15
+// seer command: seer evm generate --package ArbRetryableTx --abi abis/ArbRetryableTx.json --struct ArbRetryableTx --output bindings/ArbRetryableTx/ArbRetryableTx.go
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package ArbRetryableTx
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ArbRetryableTxMetaData contains all meta data concerning the ArbRetryableTx contract.
+var ArbRetryableTxMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"NoTicketWithID\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotCallable\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"ticketId\",\"type\":\"bytes32\"}],\"name\":\"Canceled\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"ticketId\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newTimeout\",\"type\":\"uint256\"}],\"name\":\"LifetimeExtended\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"ticketId\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"retryTxHash\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"uint64\",\"name\":\"sequenceNum\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"donatedGas\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"gasDonor\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"maxRefund\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"submissionFeeRefund\",\"type\":\"uint256\"}],\"name\":\"RedeemScheduled\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"userTxHash\",\"type\":\"bytes32\"}],\"name\":\"Redeemed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"ticketId\",\"type\":\"bytes32\"}],\"name\":\"TicketCreated\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"ticketId\",\"type\":\"bytes32\"}],\"name\":\"cancel\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"ticketId\",\"type\":\"bytes32\"}],\"name\":\"getBeneficiary\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getCurrentRedeemer\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getLifetime\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"ticketId\",\"type\":\"bytes32\"}],\"name\":\"getTimeout\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"ticketId\",\"type\":\"bytes32\"}],\"name\":\"keepalive\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"ticketId\",\"type\":\"bytes32\"}],\"name\":\"redeem\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// ArbRetryableTxABI is the input ABI used to generate the binding from.
+// Deprecated: Use ArbRetryableTxMetaData.ABI instead.
+var ArbRetryableTxABI = ArbRetryableTxMetaData.ABI
+
+// ArbRetryableTx is an auto generated Go binding around an Ethereum contract.
+type ArbRetryableTx struct {
+	ArbRetryableTxCaller     // Read-only binding to the contract
+	ArbRetryableTxTransactor // Write-only binding to the contract
+	ArbRetryableTxFilterer   // Log filterer for contract events
+}
+
+// ArbRetryableTxCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ArbRetryableTxCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ArbRetryableTxTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ArbRetryableTxTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ArbRetryableTxFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ArbRetryableTxFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ArbRetryableTxSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ArbRetryableTxSession struct {
+	Contract     *ArbRetryableTx   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ArbRetryableTxCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ArbRetryableTxCallerSession struct {
+	Contract *ArbRetryableTxCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// ArbRetryableTxTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ArbRetryableTxTransactorSession struct {
+	Contract     *ArbRetryableTxTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// ArbRetryableTxRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ArbRetryableTxRaw struct {
+	Contract *ArbRetryableTx // Generic contract binding to access the raw methods on
+}
+
+// ArbRetryableTxCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ArbRetryableTxCallerRaw struct {
+	Contract *ArbRetryableTxCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ArbRetryableTxTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ArbRetryableTxTransactorRaw struct {
+	Contract *ArbRetryableTxTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewArbRetryableTx creates a new instance of ArbRetryableTx, bound to a specific deployed contract.
+func NewArbRetryableTx(address common.Address, backend bind.ContractBackend) (*ArbRetryableTx, error) {
+	contract, err := bindArbRetryableTx(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ArbRetryableTx{ArbRetryableTxCaller: ArbRetryableTxCaller{contract: contract}, ArbRetryableTxTransactor: ArbRetryableTxTransactor{contract: contract}, ArbRetryableTxFilterer: ArbRetryableTxFilterer{contract: contract}}, nil
+}
+
+// NewArbRetryableTxCaller creates a new read-only instance of ArbRetryableTx, bound to a specific deployed contract.
+func NewArbRetryableTxCaller(address common.Address, caller bind.ContractCaller) (*ArbRetryableTxCaller, error) {
+	contract, err := bindArbRetryableTx(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ArbRetryableTxCaller{contract: contract}, nil
+}
+
+// NewArbRetryableTxTransactor creates a new write-only instance of ArbRetryableTx, bound to a specific deployed contract.
+func NewArbRetryableTxTransactor(address common.Address, transactor bind.ContractTransactor) (*ArbRetryableTxTransactor, error) {
+	contract, err := bindArbRetryableTx(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ArbRetryableTxTransactor{contract: contract}, nil
+}
+
+// NewArbRetryableTxFilterer creates a new log filterer instance of ArbRetryableTx, bound to a specific deployed contract.
+func NewArbRetryableTxFilterer(address common.Address, filterer bind.ContractFilterer) (*ArbRetryableTxFilterer, error) {
+	contract, err := bindArbRetryableTx(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ArbRetryableTxFilterer{contract: contract}, nil
+}
+
+// bindArbRetryableTx binds a generic wrapper to an already deployed contract.
+func bindArbRetryableTx(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ArbRetryableTxMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ArbRetryableTx *ArbRetryableTxRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ArbRetryableTx.Contract.ArbRetryableTxCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ArbRetryableTx *ArbRetryableTxRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ArbRetryableTx.Contract.ArbRetryableTxTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ArbRetryableTx *ArbRetryableTxRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ArbRetryableTx.Contract.ArbRetryableTxTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ArbRetryableTx *ArbRetryableTxCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ArbRetryableTx.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ArbRetryableTx *ArbRetryableTxTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ArbRetryableTx.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ArbRetryableTx *ArbRetryableTxTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ArbRetryableTx.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetBeneficiary is a free data retrieval call binding the contract method 0xba20dda4.
+//
+// Solidity: function getBeneficiary(bytes32 ticketId) view returns(address)
+func (_ArbRetryableTx *ArbRetryableTxCaller) GetBeneficiary(opts *bind.CallOpts, ticketId [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _ArbRetryableTx.contract.Call(opts, &out, "getBeneficiary", ticketId)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetBeneficiary is a free data retrieval call binding the contract method 0xba20dda4.
+//
+// Solidity: function getBeneficiary(bytes32 ticketId) view returns(address)
+func (_ArbRetryableTx *ArbRetryableTxSession) GetBeneficiary(ticketId [32]byte) (common.Address, error) {
+	return _ArbRetryableTx.Contract.GetBeneficiary(&_ArbRetryableTx.CallOpts, ticketId)
+}
+
+// GetBeneficiary is a free data retrieval call binding the contract method 0xba20dda4.
+//
+// Solidity: function getBeneficiary(bytes32 ticketId) view returns(address)
+func (_ArbRetryableTx *ArbRetryableTxCallerSession) GetBeneficiary(ticketId [32]byte) (common.Address, error) {
+	return _ArbRetryableTx.Contract.GetBeneficiary(&_ArbRetryableTx.CallOpts, ticketId)
+}
+
+// GetCurrentRedeemer is a free data retrieval call binding the contract method 0xde4ba2b3.
+//
+// Solidity: function getCurrentRedeemer() view returns(address)
+func (_ArbRetryableTx *ArbRetryableTxCaller) GetCurrentRedeemer(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ArbRetryableTx.contract.Call(opts, &out, "getCurrentRedeemer")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetCurrentRedeemer is a free data retrieval call binding the contract method 0xde4ba2b3.
+//
+// Solidity: function getCurrentRedeemer() view returns(address)
+func (_ArbRetryableTx *ArbRetryableTxSession) GetCurrentRedeemer() (common.Address, error) {
+	return _ArbRetryableTx.Contract.GetCurrentRedeemer(&_ArbRetryableTx.CallOpts)
+}
+
+// GetCurrentRedeemer is a free data retrieval call binding the contract method 0xde4ba2b3.
+//
+// Solidity: function getCurrentRedeemer() view returns(address)
+func (_ArbRetryableTx *ArbRetryableTxCallerSession) GetCurrentRedeemer() (common.Address, error) {
+	return _ArbRetryableTx.Contract.GetCurrentRedeemer(&_ArbRetryableTx.CallOpts)
+}
+
+// GetLifetime is a free data retrieval call binding the contract method 0x81e6e083.
+//
+// Solidity: function getLifetime() view returns(uint256)
+func (_ArbRetryableTx *ArbRetryableTxCaller) GetLifetime(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ArbRetryableTx.contract.Call(opts, &out, "getLifetime")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetLifetime is a free data retrieval call binding the contract method 0x81e6e083.
+//
+// Solidity: function getLifetime() view returns(uint256)
+func (_ArbRetryableTx *ArbRetryableTxSession) GetLifetime() (*big.Int, error) {
+	return _ArbRetryableTx.Contract.GetLifetime(&_ArbRetryableTx.CallOpts)
+}
+
+// GetLifetime is a free data retrieval call binding the contract method 0x81e6e083.
+//
+// Solidity: function getLifetime() view returns(uint256)
+func (_ArbRetryableTx *ArbRetryableTxCallerSession) GetLifetime() (*big.Int, error) {
+	return _ArbRetryableTx.Contract.GetLifetime(&_ArbRetryableTx.CallOpts)
+}
+
+// GetTimeout is a free data retrieval call binding the contract method 0x9f1025c6.
+//
+// Solidity: function getTimeout(bytes32 ticketId) view returns(uint256)
+func (_ArbRetryableTx *ArbRetryableTxCaller) GetTimeout(opts *bind.CallOpts, ticketId [32]byte) (*big.Int, error) {
+	var out []interface{}
+	err := _ArbRetryableTx.contract.Call(opts, &out, "getTimeout", ticketId)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetTimeout is a free data retrieval call binding the contract method 0x9f1025c6.
+//
+// Solidity: function getTimeout(bytes32 ticketId) view returns(uint256)
+func (_ArbRetryableTx *ArbRetryableTxSession) GetTimeout(ticketId [32]byte) (*big.Int, error) {
+	return _ArbRetryableTx.Contract.GetTimeout(&_ArbRetryableTx.CallOpts, ticketId)
+}
+
+// GetTimeout is a free data retrieval call binding the contract method 0x9f1025c6.
+//
+// Solidity: function getTimeout(bytes32 ticketId) view returns(uint256)
+func (_ArbRetryableTx *ArbRetryableTxCallerSession) GetTimeout(ticketId [32]byte) (*big.Int, error) {
+	return _ArbRetryableTx.Contract.GetTimeout(&_ArbRetryableTx.CallOpts, ticketId)
+}
+
+// Cancel is a paid mutator transaction binding the contract method 0xc4d252f5.
+//
+// Solidity: function cancel(bytes32 ticketId) returns()
+func (_ArbRetryableTx *ArbRetryableTxTransactor) Cancel(opts *bind.TransactOpts, ticketId [32]byte) (*types.Transaction, error) {
+	return _ArbRetryableTx.contract.Transact(opts, "cancel", ticketId)
+}
+
+// Cancel is a paid mutator transaction binding the contract method 0xc4d252f5.
+//
+// Solidity: function cancel(bytes32 ticketId) returns()
+func (_ArbRetryableTx *ArbRetryableTxSession) Cancel(ticketId [32]byte) (*types.Transaction, error) {
+	return _ArbRetryableTx.Contract.Cancel(&_ArbRetryableTx.TransactOpts, ticketId)
+}
+
+// Cancel is a paid mutator transaction binding the contract method 0xc4d252f5.
+//
+// Solidity: function cancel(bytes32 ticketId) returns()
+func (_ArbRetryableTx *ArbRetryableTxTransactorSession) Cancel(ticketId [32]byte) (*types.Transaction, error) {
+	return _ArbRetryableTx.Contract.Cancel(&_ArbRetryableTx.TransactOpts, ticketId)
+}
+
+// Keepalive is a paid mutator transaction binding the contract method 0xf0b21a41.
+//
+// Solidity: function keepalive(bytes32 ticketId) returns(uint256)
+func (_ArbRetryableTx *ArbRetryableTxTransactor) Keepalive(opts *bind.TransactOpts, ticketId [32]byte) (*types.Transaction, error) {
+	return _ArbRetryableTx.contract.Transact(opts, "keepalive", ticketId)
+}
+
+// Keepalive is a paid mutator transaction binding the contract method 0xf0b21a41.
+//
+// Solidity: function keepalive(bytes32 ticketId) returns(uint256)
+func (_ArbRetryableTx *ArbRetryableTxSession) Keepalive(ticketId [32]byte) (*types.Transaction, error) {
+	return _ArbRetryableTx.Contract.Keepalive(&_ArbRetryableTx.TransactOpts, ticketId)
+}
+
+// Keepalive is a paid mutator transaction binding the contract method 0xf0b21a41.
+//
+// Solidity: function keepalive(bytes32 ticketId) returns(uint256)
+func (_ArbRetryableTx *ArbRetryableTxTransactorSession) Keepalive(ticketId [32]byte) (*types.Transaction, error) {
+	return _ArbRetryableTx.Contract.Keepalive(&_ArbRetryableTx.TransactOpts, ticketId)
+}
+
+// Redeem is a paid mutator transaction binding the contract method 0xeda1122c.
+//
+// Solidity: function redeem(bytes32 ticketId) returns(bytes32)
+func (_ArbRetryableTx *ArbRetryableTxTransactor) Redeem(opts *bind.TransactOpts, ticketId [32]byte) (*types.Transaction, error) {
+	return _ArbRetryableTx.contract.Transact(opts, "redeem", ticketId)
+}
+
+// Redeem is a paid mutator transaction binding the contract method 0xeda1122c.
+//
+// Solidity: function redeem(bytes32 ticketId) returns(bytes32)
+func (_ArbRetryableTx *ArbRetryableTxSession) Redeem(ticketId [32]byte) (*types.Transaction, error) {
+	return _ArbRetryableTx.Contract.Redeem(&_ArbRetryableTx.TransactOpts, ticketId)
+}
+
+// Redeem is a paid mutator transaction binding the contract method 0xeda1122c.
+//
+// Solidity: function redeem(bytes32 ticketId) returns(bytes32)
+func (_ArbRetryableTx *ArbRetryableTxTransactorSession) Redeem(ticketId [32]byte) (*types.Transaction, error) {
+	return _ArbRetryableTx.Contract.Redeem(&_ArbRetryableTx.TransactOpts, ticketId)
+}
+
+// ArbRetryableTxCanceledIterator is returned from FilterCanceled and is used to iterate over the raw logs and unpacked data for Canceled events raised by the ArbRetryableTx contract.
+type ArbRetryableTxCanceledIterator struct {
+	Event *ArbRetryableTxCanceled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ArbRetryableTxCanceledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ArbRetryableTxCanceled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ArbRetryableTxCanceled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ArbRetryableTxCanceledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ArbRetryableTxCanceledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ArbRetryableTxCanceled represents a Canceled event raised by the ArbRetryableTx contract.
+type ArbRetryableTxCanceled struct {
+	TicketId [32]byte
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterCanceled is a free log retrieval operation binding the contract event 0x134fdd648feeaf30251f0157f9624ef8608ff9a042aad6d13e73f35d21d3f88d.
+//
+// Solidity: event Canceled(bytes32 indexed ticketId)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) FilterCanceled(opts *bind.FilterOpts, ticketId [][32]byte) (*ArbRetryableTxCanceledIterator, error) {
+
+	var ticketIdRule []interface{}
+	for _, ticketIdItem := range ticketId {
+		ticketIdRule = append(ticketIdRule, ticketIdItem)
+	}
+
+	logs, sub, err := _ArbRetryableTx.contract.FilterLogs(opts, "Canceled", ticketIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ArbRetryableTxCanceledIterator{contract: _ArbRetryableTx.contract, event: "Canceled", logs: logs, sub: sub}, nil
+}
+
+// WatchCanceled is a free log subscription operation binding the contract event 0x134fdd648feeaf30251f0157f9624ef8608ff9a042aad6d13e73f35d21d3f88d.
+//
+// Solidity: event Canceled(bytes32 indexed ticketId)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) WatchCanceled(opts *bind.WatchOpts, sink chan<- *ArbRetryableTxCanceled, ticketId [][32]byte) (event.Subscription, error) {
+
+	var ticketIdRule []interface{}
+	for _, ticketIdItem := range ticketId {
+		ticketIdRule = append(ticketIdRule, ticketIdItem)
+	}
+
+	logs, sub, err := _ArbRetryableTx.contract.WatchLogs(opts, "Canceled", ticketIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ArbRetryableTxCanceled)
+				if err := _ArbRetryableTx.contract.UnpackLog(event, "Canceled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCanceled is a log parse operation binding the contract event 0x134fdd648feeaf30251f0157f9624ef8608ff9a042aad6d13e73f35d21d3f88d.
+//
+// Solidity: event Canceled(bytes32 indexed ticketId)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) ParseCanceled(log types.Log) (*ArbRetryableTxCanceled, error) {
+	event := new(ArbRetryableTxCanceled)
+	if err := _ArbRetryableTx.contract.UnpackLog(event, "Canceled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ArbRetryableTxLifetimeExtendedIterator is returned from FilterLifetimeExtended and is used to iterate over the raw logs and unpacked data for LifetimeExtended events raised by the ArbRetryableTx contract.
+type ArbRetryableTxLifetimeExtendedIterator struct {
+	Event *ArbRetryableTxLifetimeExtended // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ArbRetryableTxLifetimeExtendedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ArbRetryableTxLifetimeExtended)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ArbRetryableTxLifetimeExtended)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ArbRetryableTxLifetimeExtendedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ArbRetryableTxLifetimeExtendedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ArbRetryableTxLifetimeExtended represents a LifetimeExtended event raised by the ArbRetryableTx contract.
+type ArbRetryableTxLifetimeExtended struct {
+	TicketId   [32]byte
+	NewTimeout *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterLifetimeExtended is a free log retrieval operation binding the contract event 0xf4c40a5f930e1469fcc053bf25f045253a7bad2fcc9b88c05ec1fca8e2066b83.
+//
+// Solidity: event LifetimeExtended(bytes32 indexed ticketId, uint256 newTimeout)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) FilterLifetimeExtended(opts *bind.FilterOpts, ticketId [][32]byte) (*ArbRetryableTxLifetimeExtendedIterator, error) {
+
+	var ticketIdRule []interface{}
+	for _, ticketIdItem := range ticketId {
+		ticketIdRule = append(ticketIdRule, ticketIdItem)
+	}
+
+	logs, sub, err := _ArbRetryableTx.contract.FilterLogs(opts, "LifetimeExtended", ticketIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ArbRetryableTxLifetimeExtendedIterator{contract: _ArbRetryableTx.contract, event: "LifetimeExtended", logs: logs, sub: sub}, nil
+}
+
+// WatchLifetimeExtended is a free log subscription operation binding the contract event 0xf4c40a5f930e1469fcc053bf25f045253a7bad2fcc9b88c05ec1fca8e2066b83.
+//
+// Solidity: event LifetimeExtended(bytes32 indexed ticketId, uint256 newTimeout)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) WatchLifetimeExtended(opts *bind.WatchOpts, sink chan<- *ArbRetryableTxLifetimeExtended, ticketId [][32]byte) (event.Subscription, error) {
+
+	var ticketIdRule []interface{}
+	for _, ticketIdItem := range ticketId {
+		ticketIdRule = append(ticketIdRule, ticketIdItem)
+	}
+
+	logs, sub, err := _ArbRetryableTx.contract.WatchLogs(opts, "LifetimeExtended", ticketIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ArbRetryableTxLifetimeExtended)
+				if err := _ArbRetryableTx.contract.UnpackLog(event, "LifetimeExtended", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseLifetimeExtended is a log parse operation binding the contract event 0xf4c40a5f930e1469fcc053bf25f045253a7bad2fcc9b88c05ec1fca8e2066b83.
+//
+// Solidity: event LifetimeExtended(bytes32 indexed ticketId, uint256 newTimeout)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) ParseLifetimeExtended(log types.Log) (*ArbRetryableTxLifetimeExtended, error) {
+	event := new(ArbRetryableTxLifetimeExtended)
+	if err := _ArbRetryableTx.contract.UnpackLog(event, "LifetimeExtended", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ArbRetryableTxRedeemScheduledIterator is returned from FilterRedeemScheduled and is used to iterate over the raw logs and unpacked data for RedeemScheduled events raised by the ArbRetryableTx contract.
+type ArbRetryableTxRedeemScheduledIterator struct {
+	Event *ArbRetryableTxRedeemScheduled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ArbRetryableTxRedeemScheduledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ArbRetryableTxRedeemScheduled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ArbRetryableTxRedeemScheduled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ArbRetryableTxRedeemScheduledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ArbRetryableTxRedeemScheduledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ArbRetryableTxRedeemScheduled represents a RedeemScheduled event raised by the ArbRetryableTx contract.
+type ArbRetryableTxRedeemScheduled struct {
+	TicketId            [32]byte
+	RetryTxHash         [32]byte
+	SequenceNum         uint64
+	DonatedGas          uint64
+	GasDonor            common.Address
+	MaxRefund           *big.Int
+	SubmissionFeeRefund *big.Int
+	Raw                 types.Log // Blockchain specific contextual infos
+}
+
+// FilterRedeemScheduled is a free log retrieval operation binding the contract event 0x5ccd009502509cf28762c67858994d85b163bb6e451f5e9df7c5e18c9c2e123e.
+//
+// Solidity: event RedeemScheduled(bytes32 indexed ticketId, bytes32 indexed retryTxHash, uint64 indexed sequenceNum, uint64 donatedGas, address gasDonor, uint256 maxRefund, uint256 submissionFeeRefund)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) FilterRedeemScheduled(opts *bind.FilterOpts, ticketId [][32]byte, retryTxHash [][32]byte, sequenceNum []uint64) (*ArbRetryableTxRedeemScheduledIterator, error) {
+
+	var ticketIdRule []interface{}
+	for _, ticketIdItem := range ticketId {
+		ticketIdRule = append(ticketIdRule, ticketIdItem)
+	}
+	var retryTxHashRule []interface{}
+	for _, retryTxHashItem := range retryTxHash {
+		retryTxHashRule = append(retryTxHashRule, retryTxHashItem)
+	}
+	var sequenceNumRule []interface{}
+	for _, sequenceNumItem := range sequenceNum {
+		sequenceNumRule = append(sequenceNumRule, sequenceNumItem)
+	}
+
+	logs, sub, err := _ArbRetryableTx.contract.FilterLogs(opts, "RedeemScheduled", ticketIdRule, retryTxHashRule, sequenceNumRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ArbRetryableTxRedeemScheduledIterator{contract: _ArbRetryableTx.contract, event: "RedeemScheduled", logs: logs, sub: sub}, nil
+}
+
+// WatchRedeemScheduled is a free log subscription operation binding the contract event 0x5ccd009502509cf28762c67858994d85b163bb6e451f5e9df7c5e18c9c2e123e.
+//
+// Solidity: event RedeemScheduled(bytes32 indexed ticketId, bytes32 indexed retryTxHash, uint64 indexed sequenceNum, uint64 donatedGas, address gasDonor, uint256 maxRefund, uint256 submissionFeeRefund)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) WatchRedeemScheduled(opts *bind.WatchOpts, sink chan<- *ArbRetryableTxRedeemScheduled, ticketId [][32]byte, retryTxHash [][32]byte, sequenceNum []uint64) (event.Subscription, error) {
+
+	var ticketIdRule []interface{}
+	for _, ticketIdItem := range ticketId {
+		ticketIdRule = append(ticketIdRule, ticketIdItem)
+	}
+	var retryTxHashRule []interface{}
+	for _, retryTxHashItem := range retryTxHash {
+		retryTxHashRule = append(retryTxHashRule, retryTxHashItem)
+	}
+	var sequenceNumRule []interface{}
+	for _, sequenceNumItem := range sequenceNum {
+		sequenceNumRule = append(sequenceNumRule, sequenceNumItem)
+	}
+
+	logs, sub, err := _ArbRetryableTx.contract.WatchLogs(opts, "RedeemScheduled", ticketIdRule, retryTxHashRule, sequenceNumRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ArbRetryableTxRedeemScheduled)
+				if err := _ArbRetryableTx.contract.UnpackLog(event, "RedeemScheduled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRedeemScheduled is a log parse operation binding the contract event 0x5ccd009502509cf28762c67858994d85b163bb6e451f5e9df7c5e18c9c2e123e.
+//
+// Solidity: event RedeemScheduled(bytes32 indexed ticketId, bytes32 indexed retryTxHash, uint64 indexed sequenceNum, uint64 donatedGas, address gasDonor, uint256 maxRefund, uint256 submissionFeeRefund)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) ParseRedeemScheduled(log types.Log) (*ArbRetryableTxRedeemScheduled, error) {
+	event := new(ArbRetryableTxRedeemScheduled)
+	if err := _ArbRetryableTx.contract.UnpackLog(event, "RedeemScheduled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ArbRetryableTxRedeemedIterator is returned from FilterRedeemed and is used to iterate over the raw logs and unpacked data for Redeemed events raised by the ArbRetryableTx contract.
+type ArbRetryableTxRedeemedIterator struct {
+	Event *ArbRetryableTxRedeemed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ArbRetryableTxRedeemedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ArbRetryableTxRedeemed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ArbRetryableTxRedeemed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ArbRetryableTxRedeemedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ArbRetryableTxRedeemedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ArbRetryableTxRedeemed represents a Redeemed event raised by the ArbRetryableTx contract.
+type ArbRetryableTxRedeemed struct {
+	UserTxHash [32]byte
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterRedeemed is a free log retrieval operation binding the contract event 0x27fc6cca2a0e9eb6f4876c01fc7779b00cdeb7277a770ac2b844db5932449578.
+//
+// Solidity: event Redeemed(bytes32 indexed userTxHash)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) FilterRedeemed(opts *bind.FilterOpts, userTxHash [][32]byte) (*ArbRetryableTxRedeemedIterator, error) {
+
+	var userTxHashRule []interface{}
+	for _, userTxHashItem := range userTxHash {
+		userTxHashRule = append(userTxHashRule, userTxHashItem)
+	}
+
+	logs, sub, err := _ArbRetryableTx.contract.FilterLogs(opts, "Redeemed", userTxHashRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ArbRetryableTxRedeemedIterator{contract: _ArbRetryableTx.contract, event: "Redeemed", logs: logs, sub: sub}, nil
+}
+
+// WatchRedeemed is a free log subscription operation binding the contract event 0x27fc6cca2a0e9eb6f4876c01fc7779b00cdeb7277a770ac2b844db5932449578.
+//
+// Solidity: event Redeemed(bytes32 indexed userTxHash)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) WatchRedeemed(opts *bind.WatchOpts, sink chan<- *ArbRetryableTxRedeemed, userTxHash [][32]byte) (event.Subscription, error) {
+
+	var userTxHashRule []interface{}
+	for _, userTxHashItem := range userTxHash {
+		userTxHashRule = append(userTxHashRule, userTxHashItem)
+	}
+
+	logs, sub, err := _ArbRetryableTx.contract.WatchLogs(opts, "Redeemed", userTxHashRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ArbRetryableTxRedeemed)
+				if err := _ArbRetryableTx.contract.UnpackLog(event, "Redeemed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRedeemed is a log parse operation binding the contract event 0x27fc6cca2a0e9eb6f4876c01fc7779b00cdeb7277a770ac2b844db5932449578.
+//
+// Solidity: event Redeemed(bytes32 indexed userTxHash)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) ParseRedeemed(log types.Log) (*ArbRetryableTxRedeemed, error) {
+	event := new(ArbRetryableTxRedeemed)
+	if err := _ArbRetryableTx.contract.UnpackLog(event, "Redeemed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ArbRetryableTxTicketCreatedIterator is returned from FilterTicketCreated and is used to iterate over the raw logs and unpacked data for TicketCreated events raised by the ArbRetryableTx contract.
+type ArbRetryableTxTicketCreatedIterator struct {
+	Event *ArbRetryableTxTicketCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ArbRetryableTxTicketCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ArbRetryableTxTicketCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ArbRetryableTxTicketCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ArbRetryableTxTicketCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ArbRetryableTxTicketCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ArbRetryableTxTicketCreated represents a TicketCreated event raised by the ArbRetryableTx contract.
+type ArbRetryableTxTicketCreated struct {
+	TicketId [32]byte
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterTicketCreated is a free log retrieval operation binding the contract event 0x7c793cced5743dc5f531bbe2bfb5a9fa3f40adef29231e6ab165c08a29e3dd89.
+//
+// Solidity: event TicketCreated(bytes32 indexed ticketId)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) FilterTicketCreated(opts *bind.FilterOpts, ticketId [][32]byte) (*ArbRetryableTxTicketCreatedIterator, error) {
+
+	var ticketIdRule []interface{}
+	for _, ticketIdItem := range ticketId {
+		ticketIdRule = append(ticketIdRule, ticketIdItem)
+	}
+
+	logs, sub, err := _ArbRetryableTx.contract.FilterLogs(opts, "TicketCreated", ticketIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ArbRetryableTxTicketCreatedIterator{contract: _ArbRetryableTx.contract, event: "TicketCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchTicketCreated is a free log subscription operation binding the contract event 0x7c793cced5743dc5f531bbe2bfb5a9fa3f40adef29231e6ab165c08a29e3dd89.
+//
+// Solidity: event TicketCreated(bytes32 indexed ticketId)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) WatchTicketCreated(opts *bind.WatchOpts, sink chan<- *ArbRetryableTxTicketCreated, ticketId [][32]byte) (event.Subscription, error) {
+
+	var ticketIdRule []interface{}
+	for _, ticketIdItem := range ticketId {
+		ticketIdRule = append(ticketIdRule, ticketIdItem)
+	}
+
+	logs, sub, err := _ArbRetryableTx.contract.WatchLogs(opts, "TicketCreated", ticketIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ArbRetryableTxTicketCreated)
+				if err := _ArbRetryableTx.contract.UnpackLog(event, "TicketCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTicketCreated is a log parse operation binding the contract event 0x7c793cced5743dc5f531bbe2bfb5a9fa3f40adef29231e6ab165c08a29e3dd89.
+//
+// Solidity: event TicketCreated(bytes32 indexed ticketId)
+func (_ArbRetryableTx *ArbRetryableTxFilterer) ParseTicketCreated(log types.Log) (*ArbRetryableTxTicketCreated, error) {
+	event := new(ArbRetryableTxTicketCreated)
+	if err := _ArbRetryableTx.contract.UnpackLog(event, "TicketCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
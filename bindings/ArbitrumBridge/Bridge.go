@@ -0,0 +1,529 @@
+// This file was generated by seer: https://github.com/G7DAO/seer.
+// seer version: 0.3.15
+// seer command: seer evm generate --package ArbitrumBridge --abi abis/Bridge.json --struct Bridge --output bindings/ArbitrumBridge/Bridge.go
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package ArbitrumBridge
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// BridgeMetaData contains all meta data concerning the Bridge contract.
+var BridgeMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"messageIndex\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"beforeInboxAcc\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"inbox\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint8\",\"name\":\"kind\",\"type\":\"uint8\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"messageDataHash\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"baseFeeL1\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"}],\"name\":\"MessageDelivered\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"activeOutbox\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"inbox\",\"type\":\"address\"}],\"name\":\"allowedDelayedInboxes\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"outbox\",\"type\":\"address\"}],\"name\":\"allowedOutboxes\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"delayedMessageCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"nativeToken\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"rollup\",\"outputs\":[{\"internalType\":\"contractIOwnable\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// BridgeABI is the input ABI used to generate the binding from.
+// Deprecated: Use BridgeMetaData.ABI instead.
+var BridgeABI = BridgeMetaData.ABI
+
+// Bridge is an auto generated Go binding around an Ethereum contract.
+type Bridge struct {
+	BridgeCaller     // Read-only binding to the contract
+	BridgeTransactor // Write-only binding to the contract
+	BridgeFilterer   // Log filterer for contract events
+}
+
+// BridgeCaller is an auto generated read-only Go binding around an Ethereum contract.
+type BridgeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BridgeTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type BridgeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BridgeFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BridgeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BridgeSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BridgeSession struct {
+	Contract     *Bridge           // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BridgeCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BridgeCallerSession struct {
+	Contract *BridgeCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// BridgeTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BridgeTransactorSession struct {
+	Contract     *BridgeTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BridgeRaw is an auto generated low-level Go binding around an Ethereum contract.
+type BridgeRaw struct {
+	Contract *Bridge // Generic contract binding to access the raw methods on
+}
+
+// BridgeCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BridgeCallerRaw struct {
+	Contract *BridgeCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// BridgeTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BridgeTransactorRaw struct {
+	Contract *BridgeTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBridge creates a new instance of Bridge, bound to a specific deployed contract.
+func NewBridge(address common.Address, backend bind.ContractBackend) (*Bridge, error) {
+	contract, err := bindBridge(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Bridge{BridgeCaller: BridgeCaller{contract: contract}, BridgeTransactor: BridgeTransactor{contract: contract}, BridgeFilterer: BridgeFilterer{contract: contract}}, nil
+}
+
+// NewBridgeCaller creates a new read-only instance of Bridge, bound to a specific deployed contract.
+func NewBridgeCaller(address common.Address, caller bind.ContractCaller) (*BridgeCaller, error) {
+	contract, err := bindBridge(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeCaller{contract: contract}, nil
+}
+
+// NewBridgeTransactor creates a new write-only instance of Bridge, bound to a specific deployed contract.
+func NewBridgeTransactor(address common.Address, transactor bind.ContractTransactor) (*BridgeTransactor, error) {
+	contract, err := bindBridge(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeTransactor{contract: contract}, nil
+}
+
+// NewBridgeFilterer creates a new log filterer instance of Bridge, bound to a specific deployed contract.
+func NewBridgeFilterer(address common.Address, filterer bind.ContractFilterer) (*BridgeFilterer, error) {
+	contract, err := bindBridge(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeFilterer{contract: contract}, nil
+}
+
+// bindBridge binds a generic wrapper to an already deployed contract.
+func bindBridge(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := BridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bridge *BridgeRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bridge.Contract.BridgeCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bridge *BridgeRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bridge.Contract.BridgeTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bridge *BridgeRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bridge.Contract.BridgeTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bridge *BridgeCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bridge.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bridge *BridgeTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bridge.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bridge *BridgeTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bridge.Contract.contract.Transact(opts, method, params...)
+}
+
+// ActiveOutbox is a free data retrieval call binding the contract method 0xab5d8943.
+//
+// Solidity: function activeOutbox() view returns(address)
+func (_Bridge *BridgeCaller) ActiveOutbox(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bridge.contract.Call(opts, &out, "activeOutbox")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// ActiveOutbox is a free data retrieval call binding the contract method 0xab5d8943.
+//
+// Solidity: function activeOutbox() view returns(address)
+func (_Bridge *BridgeSession) ActiveOutbox() (common.Address, error) {
+	return _Bridge.Contract.ActiveOutbox(&_Bridge.CallOpts)
+}
+
+// ActiveOutbox is a free data retrieval call binding the contract method 0xab5d8943.
+//
+// Solidity: function activeOutbox() view returns(address)
+func (_Bridge *BridgeCallerSession) ActiveOutbox() (common.Address, error) {
+	return _Bridge.Contract.ActiveOutbox(&_Bridge.CallOpts)
+}
+
+// AllowedDelayedInboxes is a free data retrieval call binding the contract method 0xae60bd13.
+//
+// Solidity: function allowedDelayedInboxes(address inbox) view returns(bool)
+func (_Bridge *BridgeCaller) AllowedDelayedInboxes(opts *bind.CallOpts, inbox common.Address) (bool, error) {
+	var out []interface{}
+	err := _Bridge.contract.Call(opts, &out, "allowedDelayedInboxes", inbox)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// AllowedDelayedInboxes is a free data retrieval call binding the contract method 0xae60bd13.
+//
+// Solidity: function allowedDelayedInboxes(address inbox) view returns(bool)
+func (_Bridge *BridgeSession) AllowedDelayedInboxes(inbox common.Address) (bool, error) {
+	return _Bridge.Contract.AllowedDelayedInboxes(&_Bridge.CallOpts, inbox)
+}
+
+// AllowedDelayedInboxes is a free data retrieval call binding the contract method 0xae60bd13.
+//
+// Solidity: function allowedDelayedInboxes(address inbox) view returns(bool)
+func (_Bridge *BridgeCallerSession) AllowedDelayedInboxes(inbox common.Address) (bool, error) {
+	return _Bridge.Contract.AllowedDelayedInboxes(&_Bridge.CallOpts, inbox)
+}
+
+// AllowedOutboxes is a free data retrieval call binding the contract method 0x413b35bd.
+//
+// Solidity: function allowedOutboxes(address outbox) view returns(bool)
+func (_Bridge *BridgeCaller) AllowedOutboxes(opts *bind.CallOpts, outbox common.Address) (bool, error) {
+	var out []interface{}
+	err := _Bridge.contract.Call(opts, &out, "allowedOutboxes", outbox)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// AllowedOutboxes is a free data retrieval call binding the contract method 0x413b35bd.
+//
+// Solidity: function allowedOutboxes(address outbox) view returns(bool)
+func (_Bridge *BridgeSession) AllowedOutboxes(outbox common.Address) (bool, error) {
+	return _Bridge.Contract.AllowedOutboxes(&_Bridge.CallOpts, outbox)
+}
+
+// AllowedOutboxes is a free data retrieval call binding the contract method 0x413b35bd.
+//
+// Solidity: function allowedOutboxes(address outbox) view returns(bool)
+func (_Bridge *BridgeCallerSession) AllowedOutboxes(outbox common.Address) (bool, error) {
+	return _Bridge.Contract.AllowedOutboxes(&_Bridge.CallOpts, outbox)
+}
+
+// DelayedMessageCount is a free data retrieval call binding the contract method 0xeca067ad.
+//
+// Solidity: function delayedMessageCount() view returns(uint256)
+func (_Bridge *BridgeCaller) DelayedMessageCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Bridge.contract.Call(opts, &out, "delayedMessageCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// DelayedMessageCount is a free data retrieval call binding the contract method 0xeca067ad.
+//
+// Solidity: function delayedMessageCount() view returns(uint256)
+func (_Bridge *BridgeSession) DelayedMessageCount() (*big.Int, error) {
+	return _Bridge.Contract.DelayedMessageCount(&_Bridge.CallOpts)
+}
+
+// DelayedMessageCount is a free data retrieval call binding the contract method 0xeca067ad.
+//
+// Solidity: function delayedMessageCount() view returns(uint256)
+func (_Bridge *BridgeCallerSession) DelayedMessageCount() (*big.Int, error) {
+	return _Bridge.Contract.DelayedMessageCount(&_Bridge.CallOpts)
+}
+
+// NativeToken is a free data retrieval call binding the contract method 0xe1758bd8.
+//
+// Solidity: function nativeToken() view returns(address)
+func (_Bridge *BridgeCaller) NativeToken(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bridge.contract.Call(opts, &out, "nativeToken")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// NativeToken is a free data retrieval call binding the contract method 0xe1758bd8.
+//
+// Solidity: function nativeToken() view returns(address)
+func (_Bridge *BridgeSession) NativeToken() (common.Address, error) {
+	return _Bridge.Contract.NativeToken(&_Bridge.CallOpts)
+}
+
+// NativeToken is a free data retrieval call binding the contract method 0xe1758bd8.
+//
+// Solidity: function nativeToken() view returns(address)
+func (_Bridge *BridgeCallerSession) NativeToken() (common.Address, error) {
+	return _Bridge.Contract.NativeToken(&_Bridge.CallOpts)
+}
+
+// Rollup is a free data retrieval call binding the contract method 0xcb23bcb5.
+//
+// Solidity: function rollup() view returns(address)
+func (_Bridge *BridgeCaller) Rollup(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bridge.contract.Call(opts, &out, "rollup")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Rollup is a free data retrieval call binding the contract method 0xcb23bcb5.
+//
+// Solidity: function rollup() view returns(address)
+func (_Bridge *BridgeSession) Rollup() (common.Address, error) {
+	return _Bridge.Contract.Rollup(&_Bridge.CallOpts)
+}
+
+// Rollup is a free data retrieval call binding the contract method 0xcb23bcb5.
+//
+// Solidity: function rollup() view returns(address)
+func (_Bridge *BridgeCallerSession) Rollup() (common.Address, error) {
+	return _Bridge.Contract.Rollup(&_Bridge.CallOpts)
+}
+
+// BridgeMessageDeliveredIterator is returned from FilterMessageDelivered and is used to iterate over the raw logs and unpacked data for MessageDelivered events raised by the Bridge contract.
+type BridgeMessageDeliveredIterator struct {
+	Event *BridgeMessageDelivered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BridgeMessageDeliveredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BridgeMessageDelivered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BridgeMessageDelivered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BridgeMessageDeliveredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BridgeMessageDeliveredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BridgeMessageDelivered represents a MessageDelivered event raised by the Bridge contract.
+type BridgeMessageDelivered struct {
+	MessageIndex    *big.Int
+	BeforeInboxAcc  [32]byte
+	Inbox           common.Address
+	Kind            uint8
+	Sender          common.Address
+	MessageDataHash [32]byte
+	BaseFeeL1       *big.Int
+	Timestamp       uint64
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterMessageDelivered is a free log retrieval operation binding the contract event 0x5e3c1311ea442664e8b1611bfabef659120ea7a0a2cfc0667700bebc69cbffe1.
+//
+// Solidity: event MessageDelivered(uint256 indexed messageIndex, bytes32 indexed beforeInboxAcc, address inbox, uint8 kind, address sender, bytes32 messageDataHash, uint256 baseFeeL1, uint64 timestamp)
+func (_Bridge *BridgeFilterer) FilterMessageDelivered(opts *bind.FilterOpts, messageIndex []*big.Int, beforeInboxAcc [][32]byte) (*BridgeMessageDeliveredIterator, error) {
+
+	var messageIndexRule []interface{}
+	for _, messageIndexItem := range messageIndex {
+		messageIndexRule = append(messageIndexRule, messageIndexItem)
+	}
+	var beforeInboxAccRule []interface{}
+	for _, beforeInboxAccItem := range beforeInboxAcc {
+		beforeInboxAccRule = append(beforeInboxAccRule, beforeInboxAccItem)
+	}
+
+	logs, sub, err := _Bridge.contract.FilterLogs(opts, "MessageDelivered", messageIndexRule, beforeInboxAccRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeMessageDeliveredIterator{contract: _Bridge.contract, event: "MessageDelivered", logs: logs, sub: sub}, nil
+}
+
+// WatchMessageDelivered is a free log subscription operation binding the contract event 0x5e3c1311ea442664e8b1611bfabef659120ea7a0a2cfc0667700bebc69cbffe1.
+//
+// Solidity: event MessageDelivered(uint256 indexed messageIndex, bytes32 indexed beforeInboxAcc, address inbox, uint8 kind, address sender, bytes32 messageDataHash, uint256 baseFeeL1, uint64 timestamp)
+func (_Bridge *BridgeFilterer) WatchMessageDelivered(opts *bind.WatchOpts, sink chan<- *BridgeMessageDelivered, messageIndex []*big.Int, beforeInboxAcc [][32]byte) (event.Subscription, error) {
+
+	var messageIndexRule []interface{}
+	for _, messageIndexItem := range messageIndex {
+		messageIndexRule = append(messageIndexRule, messageIndexItem)
+	}
+	var beforeInboxAccRule []interface{}
+	for _, beforeInboxAccItem := range beforeInboxAcc {
+		beforeInboxAccRule = append(beforeInboxAccRule, beforeInboxAccItem)
+	}
+
+	logs, sub, err := _Bridge.contract.WatchLogs(opts, "MessageDelivered", messageIndexRule, beforeInboxAccRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BridgeMessageDelivered)
+				if err := _Bridge.contract.UnpackLog(event, "MessageDelivered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMessageDelivered is a log parse operation binding the contract event 0x5e3c1311ea442664e8b1611bfabef659120ea7a0a2cfc0667700bebc69cbffe1.
+//
+// Solidity: event MessageDelivered(uint256 indexed messageIndex, bytes32 indexed beforeInboxAcc, address inbox, uint8 kind, address sender, bytes32 messageDataHash, uint256 baseFeeL1, uint64 timestamp)
+func (_Bridge *BridgeFilterer) ParseMessageDelivered(log types.Log) (*BridgeMessageDelivered, error) {
+	event := new(BridgeMessageDelivered)
+	if err := _Bridge.contract.UnpackLog(event, "MessageDelivered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
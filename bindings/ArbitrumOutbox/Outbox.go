@@ -0,0 +1,924 @@
+// This file was generated by seer: https://github.com/G7DAO/seer.
+// seer version: 0.3.15
+// seer command: seer evm generate --package ArbitrumOutbox --abi abis/Outbox.json --struct Outbox --output bindings/ArbitrumOutbox/Outbox.go
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package ArbitrumOutbox
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// OutboxMetaData contains all meta data concerning the Outbox contract.
+var OutboxMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"}],\"name\":\"AlreadySpent\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"actualLength\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxProofLength\",\"type\":\"uint256\"}],\"name\":\"MerkleProofTooLong\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxIndex\",\"type\":\"uint256\"}],\"name\":\"PathNotMinimal\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"proofLength\",\"type\":\"uint256\"}],\"name\":\"ProofTooLong\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"root\",\"type\":\"bytes32\"}],\"name\":\"UnknownRoot\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"l2Sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"zero\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"transactionIndex\",\"type\":\"uint256\"}],\"name\":\"OutBoxTransactionExecuted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"outputRoot\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"l2BlockHash\",\"type\":\"bytes32\"}],\"name\":\"SendRootUpdated\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"OUTBOX_VERSION\",\"outputs\":[{\"internalType\":\"uint128\",\"name\":\"\",\"type\":\"uint128\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"bridge\",\"outputs\":[{\"internalType\":\"contractIBridge\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"l2Sender\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"l2Block\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"l1Block\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"l2Timestamp\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"calculateItemHash\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32[]\",\"name\":\"proof\",\"type\":\"bytes32[]\"},{\"internalType\":\"uint256\",\"name\":\"path\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"item\",\"type\":\"bytes32\"}],\"name\":\"calculateMerkleRoot\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32[]\",\"name\":\"proof\",\"type\":\"bytes32[]\"},{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"l2Sender\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"l2Block\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"l1Block\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"l2Timestamp\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"executeTransaction\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"}],\"name\":\"isSpent\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"l2ToL1Block\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"l2ToL1EthBlock\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"l2ToL1OutputId\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"l2ToL1Sender\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"l2ToL1Timestamp\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"rollup\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"roots\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"spent\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// OutboxABI is the input ABI used to generate the binding from.
+// Deprecated: Use OutboxMetaData.ABI instead.
+var OutboxABI = OutboxMetaData.ABI
+
+// Outbox is an auto generated Go binding around an Ethereum contract.
+type Outbox struct {
+	OutboxCaller     // Read-only binding to the contract
+	OutboxTransactor // Write-only binding to the contract
+	OutboxFilterer   // Log filterer for contract events
+}
+
+// OutboxCaller is an auto generated read-only Go binding around an Ethereum contract.
+type OutboxCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// OutboxTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type OutboxTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// OutboxFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type OutboxFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// OutboxSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type OutboxSession struct {
+	Contract     *Outbox           // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// OutboxCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type OutboxCallerSession struct {
+	Contract *OutboxCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// OutboxTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type OutboxTransactorSession struct {
+	Contract     *OutboxTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// OutboxRaw is an auto generated low-level Go binding around an Ethereum contract.
+type OutboxRaw struct {
+	Contract *Outbox // Generic contract binding to access the raw methods on
+}
+
+// OutboxCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type OutboxCallerRaw struct {
+	Contract *OutboxCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// OutboxTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type OutboxTransactorRaw struct {
+	Contract *OutboxTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewOutbox creates a new instance of Outbox, bound to a specific deployed contract.
+func NewOutbox(address common.Address, backend bind.ContractBackend) (*Outbox, error) {
+	contract, err := bindOutbox(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Outbox{OutboxCaller: OutboxCaller{contract: contract}, OutboxTransactor: OutboxTransactor{contract: contract}, OutboxFilterer: OutboxFilterer{contract: contract}}, nil
+}
+
+// NewOutboxCaller creates a new read-only instance of Outbox, bound to a specific deployed contract.
+func NewOutboxCaller(address common.Address, caller bind.ContractCaller) (*OutboxCaller, error) {
+	contract, err := bindOutbox(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxCaller{contract: contract}, nil
+}
+
+// NewOutboxTransactor creates a new write-only instance of Outbox, bound to a specific deployed contract.
+func NewOutboxTransactor(address common.Address, transactor bind.ContractTransactor) (*OutboxTransactor, error) {
+	contract, err := bindOutbox(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxTransactor{contract: contract}, nil
+}
+
+// NewOutboxFilterer creates a new log filterer instance of Outbox, bound to a specific deployed contract.
+func NewOutboxFilterer(address common.Address, filterer bind.ContractFilterer) (*OutboxFilterer, error) {
+	contract, err := bindOutbox(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxFilterer{contract: contract}, nil
+}
+
+// bindOutbox binds a generic wrapper to an already deployed contract.
+func bindOutbox(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := OutboxMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Outbox *OutboxRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Outbox.Contract.OutboxCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Outbox *OutboxRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Outbox.Contract.OutboxTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Outbox *OutboxRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Outbox.Contract.OutboxTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Outbox *OutboxCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Outbox.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Outbox *OutboxTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Outbox.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Outbox *OutboxTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Outbox.Contract.contract.Transact(opts, method, params...)
+}
+
+// OUTBOXVERSION is a free data retrieval call binding the contract method 0xc75184df.
+//
+// Solidity: function OUTBOX_VERSION() view returns(uint128)
+func (_Outbox *OutboxCaller) OUTBOXVERSION(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "OUTBOX_VERSION")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// OUTBOXVERSION is a free data retrieval call binding the contract method 0xc75184df.
+//
+// Solidity: function OUTBOX_VERSION() view returns(uint128)
+func (_Outbox *OutboxSession) OUTBOXVERSION() (*big.Int, error) {
+	return _Outbox.Contract.OUTBOXVERSION(&_Outbox.CallOpts)
+}
+
+// OUTBOXVERSION is a free data retrieval call binding the contract method 0xc75184df.
+//
+// Solidity: function OUTBOX_VERSION() view returns(uint128)
+func (_Outbox *OutboxCallerSession) OUTBOXVERSION() (*big.Int, error) {
+	return _Outbox.Contract.OUTBOXVERSION(&_Outbox.CallOpts)
+}
+
+// Bridge is a free data retrieval call binding the contract method 0xe78cea92.
+//
+// Solidity: function bridge() view returns(address)
+func (_Outbox *OutboxCaller) Bridge(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "bridge")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Bridge is a free data retrieval call binding the contract method 0xe78cea92.
+//
+// Solidity: function bridge() view returns(address)
+func (_Outbox *OutboxSession) Bridge() (common.Address, error) {
+	return _Outbox.Contract.Bridge(&_Outbox.CallOpts)
+}
+
+// Bridge is a free data retrieval call binding the contract method 0xe78cea92.
+//
+// Solidity: function bridge() view returns(address)
+func (_Outbox *OutboxCallerSession) Bridge() (common.Address, error) {
+	return _Outbox.Contract.Bridge(&_Outbox.CallOpts)
+}
+
+// CalculateItemHash is a free data retrieval call binding the contract method 0x9f0c04bf.
+//
+// Solidity: function calculateItemHash(address l2Sender, address to, uint256 l2Block, uint256 l1Block, uint256 l2Timestamp, uint256 value, bytes data) pure returns(bytes32)
+func (_Outbox *OutboxCaller) CalculateItemHash(opts *bind.CallOpts, l2Sender common.Address, to common.Address, l2Block *big.Int, l1Block *big.Int, l2Timestamp *big.Int, value *big.Int, data []byte) ([32]byte, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "calculateItemHash", l2Sender, to, l2Block, l1Block, l2Timestamp, value, data)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// CalculateItemHash is a free data retrieval call binding the contract method 0x9f0c04bf.
+//
+// Solidity: function calculateItemHash(address l2Sender, address to, uint256 l2Block, uint256 l1Block, uint256 l2Timestamp, uint256 value, bytes data) pure returns(bytes32)
+func (_Outbox *OutboxSession) CalculateItemHash(l2Sender common.Address, to common.Address, l2Block *big.Int, l1Block *big.Int, l2Timestamp *big.Int, value *big.Int, data []byte) ([32]byte, error) {
+	return _Outbox.Contract.CalculateItemHash(&_Outbox.CallOpts, l2Sender, to, l2Block, l1Block, l2Timestamp, value, data)
+}
+
+// CalculateItemHash is a free data retrieval call binding the contract method 0x9f0c04bf.
+//
+// Solidity: function calculateItemHash(address l2Sender, address to, uint256 l2Block, uint256 l1Block, uint256 l2Timestamp, uint256 value, bytes data) pure returns(bytes32)
+func (_Outbox *OutboxCallerSession) CalculateItemHash(l2Sender common.Address, to common.Address, l2Block *big.Int, l1Block *big.Int, l2Timestamp *big.Int, value *big.Int, data []byte) ([32]byte, error) {
+	return _Outbox.Contract.CalculateItemHash(&_Outbox.CallOpts, l2Sender, to, l2Block, l1Block, l2Timestamp, value, data)
+}
+
+// CalculateMerkleRoot is a free data retrieval call binding the contract method 0x007436d3.
+//
+// Solidity: function calculateMerkleRoot(bytes32[] proof, uint256 path, bytes32 item) pure returns(bytes32)
+func (_Outbox *OutboxCaller) CalculateMerkleRoot(opts *bind.CallOpts, proof [][32]byte, path *big.Int, item [32]byte) ([32]byte, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "calculateMerkleRoot", proof, path, item)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// CalculateMerkleRoot is a free data retrieval call binding the contract method 0x007436d3.
+//
+// Solidity: function calculateMerkleRoot(bytes32[] proof, uint256 path, bytes32 item) pure returns(bytes32)
+func (_Outbox *OutboxSession) CalculateMerkleRoot(proof [][32]byte, path *big.Int, item [32]byte) ([32]byte, error) {
+	return _Outbox.Contract.CalculateMerkleRoot(&_Outbox.CallOpts, proof, path, item)
+}
+
+// CalculateMerkleRoot is a free data retrieval call binding the contract method 0x007436d3.
+//
+// Solidity: function calculateMerkleRoot(bytes32[] proof, uint256 path, bytes32 item) pure returns(bytes32)
+func (_Outbox *OutboxCallerSession) CalculateMerkleRoot(proof [][32]byte, path *big.Int, item [32]byte) ([32]byte, error) {
+	return _Outbox.Contract.CalculateMerkleRoot(&_Outbox.CallOpts, proof, path, item)
+}
+
+// IsSpent is a free data retrieval call binding the contract method 0x5a129efe.
+//
+// Solidity: function isSpent(uint256 index) view returns(bool)
+func (_Outbox *OutboxCaller) IsSpent(opts *bind.CallOpts, index *big.Int) (bool, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "isSpent", index)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsSpent is a free data retrieval call binding the contract method 0x5a129efe.
+//
+// Solidity: function isSpent(uint256 index) view returns(bool)
+func (_Outbox *OutboxSession) IsSpent(index *big.Int) (bool, error) {
+	return _Outbox.Contract.IsSpent(&_Outbox.CallOpts, index)
+}
+
+// IsSpent is a free data retrieval call binding the contract method 0x5a129efe.
+//
+// Solidity: function isSpent(uint256 index) view returns(bool)
+func (_Outbox *OutboxCallerSession) IsSpent(index *big.Int) (bool, error) {
+	return _Outbox.Contract.IsSpent(&_Outbox.CallOpts, index)
+}
+
+// L2ToL1Block is a free data retrieval call binding the contract method 0x46547790.
+//
+// Solidity: function l2ToL1Block() view returns(uint256)
+func (_Outbox *OutboxCaller) L2ToL1Block(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "l2ToL1Block")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// L2ToL1Block is a free data retrieval call binding the contract method 0x46547790.
+//
+// Solidity: function l2ToL1Block() view returns(uint256)
+func (_Outbox *OutboxSession) L2ToL1Block() (*big.Int, error) {
+	return _Outbox.Contract.L2ToL1Block(&_Outbox.CallOpts)
+}
+
+// L2ToL1Block is a free data retrieval call binding the contract method 0x46547790.
+//
+// Solidity: function l2ToL1Block() view returns(uint256)
+func (_Outbox *OutboxCallerSession) L2ToL1Block() (*big.Int, error) {
+	return _Outbox.Contract.L2ToL1Block(&_Outbox.CallOpts)
+}
+
+// L2ToL1EthBlock is a free data retrieval call binding the contract method 0x8515bc6a.
+//
+// Solidity: function l2ToL1EthBlock() view returns(uint256)
+func (_Outbox *OutboxCaller) L2ToL1EthBlock(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "l2ToL1EthBlock")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// L2ToL1EthBlock is a free data retrieval call binding the contract method 0x8515bc6a.
+//
+// Solidity: function l2ToL1EthBlock() view returns(uint256)
+func (_Outbox *OutboxSession) L2ToL1EthBlock() (*big.Int, error) {
+	return _Outbox.Contract.L2ToL1EthBlock(&_Outbox.CallOpts)
+}
+
+// L2ToL1EthBlock is a free data retrieval call binding the contract method 0x8515bc6a.
+//
+// Solidity: function l2ToL1EthBlock() view returns(uint256)
+func (_Outbox *OutboxCallerSession) L2ToL1EthBlock() (*big.Int, error) {
+	return _Outbox.Contract.L2ToL1EthBlock(&_Outbox.CallOpts)
+}
+
+// L2ToL1OutputId is a free data retrieval call binding the contract method 0x72f2a8c7.
+//
+// Solidity: function l2ToL1OutputId() view returns(bytes32)
+func (_Outbox *OutboxCaller) L2ToL1OutputId(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "l2ToL1OutputId")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// L2ToL1OutputId is a free data retrieval call binding the contract method 0x72f2a8c7.
+//
+// Solidity: function l2ToL1OutputId() view returns(bytes32)
+func (_Outbox *OutboxSession) L2ToL1OutputId() ([32]byte, error) {
+	return _Outbox.Contract.L2ToL1OutputId(&_Outbox.CallOpts)
+}
+
+// L2ToL1OutputId is a free data retrieval call binding the contract method 0x72f2a8c7.
+//
+// Solidity: function l2ToL1OutputId() view returns(bytes32)
+func (_Outbox *OutboxCallerSession) L2ToL1OutputId() ([32]byte, error) {
+	return _Outbox.Contract.L2ToL1OutputId(&_Outbox.CallOpts)
+}
+
+// L2ToL1Sender is a free data retrieval call binding the contract method 0x80648b02.
+//
+// Solidity: function l2ToL1Sender() view returns(address)
+func (_Outbox *OutboxCaller) L2ToL1Sender(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "l2ToL1Sender")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// L2ToL1Sender is a free data retrieval call binding the contract method 0x80648b02.
+//
+// Solidity: function l2ToL1Sender() view returns(address)
+func (_Outbox *OutboxSession) L2ToL1Sender() (common.Address, error) {
+	return _Outbox.Contract.L2ToL1Sender(&_Outbox.CallOpts)
+}
+
+// L2ToL1Sender is a free data retrieval call binding the contract method 0x80648b02.
+//
+// Solidity: function l2ToL1Sender() view returns(address)
+func (_Outbox *OutboxCallerSession) L2ToL1Sender() (common.Address, error) {
+	return _Outbox.Contract.L2ToL1Sender(&_Outbox.CallOpts)
+}
+
+// L2ToL1Timestamp is a free data retrieval call binding the contract method 0xb0f30537.
+//
+// Solidity: function l2ToL1Timestamp() view returns(uint256)
+func (_Outbox *OutboxCaller) L2ToL1Timestamp(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "l2ToL1Timestamp")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// L2ToL1Timestamp is a free data retrieval call binding the contract method 0xb0f30537.
+//
+// Solidity: function l2ToL1Timestamp() view returns(uint256)
+func (_Outbox *OutboxSession) L2ToL1Timestamp() (*big.Int, error) {
+	return _Outbox.Contract.L2ToL1Timestamp(&_Outbox.CallOpts)
+}
+
+// L2ToL1Timestamp is a free data retrieval call binding the contract method 0xb0f30537.
+//
+// Solidity: function l2ToL1Timestamp() view returns(uint256)
+func (_Outbox *OutboxCallerSession) L2ToL1Timestamp() (*big.Int, error) {
+	return _Outbox.Contract.L2ToL1Timestamp(&_Outbox.CallOpts)
+}
+
+// Rollup is a free data retrieval call binding the contract method 0xcb23bcb5.
+//
+// Solidity: function rollup() view returns(address)
+func (_Outbox *OutboxCaller) Rollup(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "rollup")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Rollup is a free data retrieval call binding the contract method 0xcb23bcb5.
+//
+// Solidity: function rollup() view returns(address)
+func (_Outbox *OutboxSession) Rollup() (common.Address, error) {
+	return _Outbox.Contract.Rollup(&_Outbox.CallOpts)
+}
+
+// Rollup is a free data retrieval call binding the contract method 0xcb23bcb5.
+//
+// Solidity: function rollup() view returns(address)
+func (_Outbox *OutboxCallerSession) Rollup() (common.Address, error) {
+	return _Outbox.Contract.Rollup(&_Outbox.CallOpts)
+}
+
+// Roots is a free data retrieval call binding the contract method 0xae6dead7.
+//
+// Solidity: function roots(bytes32 ) view returns(bytes32)
+func (_Outbox *OutboxCaller) Roots(opts *bind.CallOpts, arg0 [32]byte) ([32]byte, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "roots", arg0)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// Roots is a free data retrieval call binding the contract method 0xae6dead7.
+//
+// Solidity: function roots(bytes32 ) view returns(bytes32)
+func (_Outbox *OutboxSession) Roots(arg0 [32]byte) ([32]byte, error) {
+	return _Outbox.Contract.Roots(&_Outbox.CallOpts, arg0)
+}
+
+// Roots is a free data retrieval call binding the contract method 0xae6dead7.
+//
+// Solidity: function roots(bytes32 ) view returns(bytes32)
+func (_Outbox *OutboxCallerSession) Roots(arg0 [32]byte) ([32]byte, error) {
+	return _Outbox.Contract.Roots(&_Outbox.CallOpts, arg0)
+}
+
+// Spent is a free data retrieval call binding the contract method 0xd5b5cc23.
+//
+// Solidity: function spent(uint256 ) view returns(bytes32)
+func (_Outbox *OutboxCaller) Spent(opts *bind.CallOpts, arg0 *big.Int) ([32]byte, error) {
+	var out []interface{}
+	err := _Outbox.contract.Call(opts, &out, "spent", arg0)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// Spent is a free data retrieval call binding the contract method 0xd5b5cc23.
+//
+// Solidity: function spent(uint256 ) view returns(bytes32)
+func (_Outbox *OutboxSession) Spent(arg0 *big.Int) ([32]byte, error) {
+	return _Outbox.Contract.Spent(&_Outbox.CallOpts, arg0)
+}
+
+// Spent is a free data retrieval call binding the contract method 0xd5b5cc23.
+//
+// Solidity: function spent(uint256 ) view returns(bytes32)
+func (_Outbox *OutboxCallerSession) Spent(arg0 *big.Int) ([32]byte, error) {
+	return _Outbox.Contract.Spent(&_Outbox.CallOpts, arg0)
+}
+
+// ExecuteTransaction is a paid mutator transaction binding the contract method 0x08635a95.
+//
+// Solidity: function executeTransaction(bytes32[] proof, uint256 index, address l2Sender, address to, uint256 l2Block, uint256 l1Block, uint256 l2Timestamp, uint256 value, bytes data) returns()
+func (_Outbox *OutboxTransactor) ExecuteTransaction(opts *bind.TransactOpts, proof [][32]byte, index *big.Int, l2Sender common.Address, to common.Address, l2Block *big.Int, l1Block *big.Int, l2Timestamp *big.Int, value *big.Int, data []byte) (*types.Transaction, error) {
+	return _Outbox.contract.Transact(opts, "executeTransaction", proof, index, l2Sender, to, l2Block, l1Block, l2Timestamp, value, data)
+}
+
+// ExecuteTransaction is a paid mutator transaction binding the contract method 0x08635a95.
+//
+// Solidity: function executeTransaction(bytes32[] proof, uint256 index, address l2Sender, address to, uint256 l2Block, uint256 l1Block, uint256 l2Timestamp, uint256 value, bytes data) returns()
+func (_Outbox *OutboxSession) ExecuteTransaction(proof [][32]byte, index *big.Int, l2Sender common.Address, to common.Address, l2Block *big.Int, l1Block *big.Int, l2Timestamp *big.Int, value *big.Int, data []byte) (*types.Transaction, error) {
+	return _Outbox.Contract.ExecuteTransaction(&_Outbox.TransactOpts, proof, index, l2Sender, to, l2Block, l1Block, l2Timestamp, value, data)
+}
+
+// ExecuteTransaction is a paid mutator transaction binding the contract method 0x08635a95.
+//
+// Solidity: function executeTransaction(bytes32[] proof, uint256 index, address l2Sender, address to, uint256 l2Block, uint256 l1Block, uint256 l2Timestamp, uint256 value, bytes data) returns()
+func (_Outbox *OutboxTransactorSession) ExecuteTransaction(proof [][32]byte, index *big.Int, l2Sender common.Address, to common.Address, l2Block *big.Int, l1Block *big.Int, l2Timestamp *big.Int, value *big.Int, data []byte) (*types.Transaction, error) {
+	return _Outbox.Contract.ExecuteTransaction(&_Outbox.TransactOpts, proof, index, l2Sender, to, l2Block, l1Block, l2Timestamp, value, data)
+}
+
+// OutboxOutBoxTransactionExecutedIterator is returned from FilterOutBoxTransactionExecuted and is used to iterate over the raw logs and unpacked data for OutBoxTransactionExecuted events raised by the Outbox contract.
+type OutboxOutBoxTransactionExecutedIterator struct {
+	Event *OutboxOutBoxTransactionExecuted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *OutboxOutBoxTransactionExecutedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(OutboxOutBoxTransactionExecuted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(OutboxOutBoxTransactionExecuted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *OutboxOutBoxTransactionExecutedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *OutboxOutBoxTransactionExecutedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// OutboxOutBoxTransactionExecuted represents a OutBoxTransactionExecuted event raised by the Outbox contract.
+type OutboxOutBoxTransactionExecuted struct {
+	To               common.Address
+	L2Sender         common.Address
+	Zero             *big.Int
+	TransactionIndex *big.Int
+	Raw              types.Log // Blockchain specific contextual infos
+}
+
+// FilterOutBoxTransactionExecuted is a free log retrieval operation binding the contract event 0x20af7f3bbfe38132b8900ae295cd9c8d1914be7052d061a511f3f728dab18964.
+//
+// Solidity: event OutBoxTransactionExecuted(address indexed to, address indexed l2Sender, uint256 indexed zero, uint256 transactionIndex)
+func (_Outbox *OutboxFilterer) FilterOutBoxTransactionExecuted(opts *bind.FilterOpts, to []common.Address, l2Sender []common.Address, zero []*big.Int) (*OutboxOutBoxTransactionExecutedIterator, error) {
+
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+	var l2SenderRule []interface{}
+	for _, l2SenderItem := range l2Sender {
+		l2SenderRule = append(l2SenderRule, l2SenderItem)
+	}
+	var zeroRule []interface{}
+	for _, zeroItem := range zero {
+		zeroRule = append(zeroRule, zeroItem)
+	}
+
+	logs, sub, err := _Outbox.contract.FilterLogs(opts, "OutBoxTransactionExecuted", toRule, l2SenderRule, zeroRule)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxOutBoxTransactionExecutedIterator{contract: _Outbox.contract, event: "OutBoxTransactionExecuted", logs: logs, sub: sub}, nil
+}
+
+// WatchOutBoxTransactionExecuted is a free log subscription operation binding the contract event 0x20af7f3bbfe38132b8900ae295cd9c8d1914be7052d061a511f3f728dab18964.
+//
+// Solidity: event OutBoxTransactionExecuted(address indexed to, address indexed l2Sender, uint256 indexed zero, uint256 transactionIndex)
+func (_Outbox *OutboxFilterer) WatchOutBoxTransactionExecuted(opts *bind.WatchOpts, sink chan<- *OutboxOutBoxTransactionExecuted, to []common.Address, l2Sender []common.Address, zero []*big.Int) (event.Subscription, error) {
+
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+	var l2SenderRule []interface{}
+	for _, l2SenderItem := range l2Sender {
+		l2SenderRule = append(l2SenderRule, l2SenderItem)
+	}
+	var zeroRule []interface{}
+	for _, zeroItem := range zero {
+		zeroRule = append(zeroRule, zeroItem)
+	}
+
+	logs, sub, err := _Outbox.contract.WatchLogs(opts, "OutBoxTransactionExecuted", toRule, l2SenderRule, zeroRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(OutboxOutBoxTransactionExecuted)
+				if err := _Outbox.contract.UnpackLog(event, "OutBoxTransactionExecuted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOutBoxTransactionExecuted is a log parse operation binding the contract event 0x20af7f3bbfe38132b8900ae295cd9c8d1914be7052d061a511f3f728dab18964.
+//
+// Solidity: event OutBoxTransactionExecuted(address indexed to, address indexed l2Sender, uint256 indexed zero, uint256 transactionIndex)
+func (_Outbox *OutboxFilterer) ParseOutBoxTransactionExecuted(log types.Log) (*OutboxOutBoxTransactionExecuted, error) {
+	event := new(OutboxOutBoxTransactionExecuted)
+	if err := _Outbox.contract.UnpackLog(event, "OutBoxTransactionExecuted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// OutboxSendRootUpdatedIterator is returned from FilterSendRootUpdated and is used to iterate over the raw logs and unpacked data for SendRootUpdated events raised by the Outbox contract.
+type OutboxSendRootUpdatedIterator struct {
+	Event *OutboxSendRootUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *OutboxSendRootUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(OutboxSendRootUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(OutboxSendRootUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *OutboxSendRootUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *OutboxSendRootUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// OutboxSendRootUpdated represents a SendRootUpdated event raised by the Outbox contract.
+type OutboxSendRootUpdated struct {
+	OutputRoot  [32]byte
+	L2BlockHash [32]byte
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterSendRootUpdated is a free log retrieval operation binding the contract event 0xb4df3847300f076a369cd76d2314b470a1194d9e8a6bb97f1860aee88a5f6748.
+//
+// Solidity: event SendRootUpdated(bytes32 indexed outputRoot, bytes32 indexed l2BlockHash)
+func (_Outbox *OutboxFilterer) FilterSendRootUpdated(opts *bind.FilterOpts, outputRoot [][32]byte, l2BlockHash [][32]byte) (*OutboxSendRootUpdatedIterator, error) {
+
+	var outputRootRule []interface{}
+	for _, outputRootItem := range outputRoot {
+		outputRootRule = append(outputRootRule, outputRootItem)
+	}
+	var l2BlockHashRule []interface{}
+	for _, l2BlockHashItem := range l2BlockHash {
+		l2BlockHashRule = append(l2BlockHashRule, l2BlockHashItem)
+	}
+
+	logs, sub, err := _Outbox.contract.FilterLogs(opts, "SendRootUpdated", outputRootRule, l2BlockHashRule)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxSendRootUpdatedIterator{contract: _Outbox.contract, event: "SendRootUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchSendRootUpdated is a free log subscription operation binding the contract event 0xb4df3847300f076a369cd76d2314b470a1194d9e8a6bb97f1860aee88a5f6748.
+//
+// Solidity: event SendRootUpdated(bytes32 indexed outputRoot, bytes32 indexed l2BlockHash)
+func (_Outbox *OutboxFilterer) WatchSendRootUpdated(opts *bind.WatchOpts, sink chan<- *OutboxSendRootUpdated, outputRoot [][32]byte, l2BlockHash [][32]byte) (event.Subscription, error) {
+
+	var outputRootRule []interface{}
+	for _, outputRootItem := range outputRoot {
+		outputRootRule = append(outputRootRule, outputRootItem)
+	}
+	var l2BlockHashRule []interface{}
+	for _, l2BlockHashItem := range l2BlockHash {
+		l2BlockHashRule = append(l2BlockHashRule, l2BlockHashItem)
+	}
+
+	logs, sub, err := _Outbox.contract.WatchLogs(opts, "SendRootUpdated", outputRootRule, l2BlockHashRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(OutboxSendRootUpdated)
+				if err := _Outbox.contract.UnpackLog(event, "SendRootUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSendRootUpdated is a log parse operation binding the contract event 0xb4df3847300f076a369cd76d2314b470a1194d9e8a6bb97f1860aee88a5f6748.
+//
+// Solidity: event SendRootUpdated(bytes32 indexed outputRoot, bytes32 indexed l2BlockHash)
+func (_Outbox *OutboxFilterer) ParseSendRootUpdated(log types.Log) (*OutboxSendRootUpdated, error) {
+	event := new(OutboxSendRootUpdated)
+	if err := _Outbox.contract.UnpackLog(event, "SendRootUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -7,11 +7,9 @@
 package Metronome
 
 import (
-	"bytes"
 	"crypto/rand"
 	"errors"
 	"math/big"
-	"net/http"
 	"strings"
 
 	"context"
@@ -22,7 +20,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
-	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 
 	// Reference imports to suppress errors if they are not otherwise used.
 	"encoding/hex"
@@ -31,15 +28,15 @@ import (
 	"os"
 	"time"
 
+	"github.com/G7DAO/protocol/safe"
+	"github.com/G7DAO/protocol/signer"
 	"github.com/G7DAO/seer/bindings/CreateCall"
-	"github.com/G7DAO/seer/bindings/GnosisSafe"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 
 	// MetronomeMetaData contains all meta data concerning the Metronome contract.
-	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -917,11 +914,12 @@ func (_Metronome *MetronomeFilterer) ParseScheduleCreated(log types.Log) (*Metro
 }
 
 func CreateMetronomeDeploymentCommand() *cobra.Command {
-	var keyfile, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc string
+	var keyfile, signerRaw, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc string
 	var gasLimit uint64
 	var simulate bool
 	var timeout uint
-	var safeAddress, safeApi, safeCreateCall, safeSaltRaw, safeNonceRaw string
+	var safeAddress, safeApi, safeApiKey, safeOut, safeCreateCall, safeSaltRaw, safeNonceRaw string
+	var safeForce bool
 	var safeOperationType uint8
 	var salt [32]byte
 	var predictAddress bool
@@ -934,8 +932,8 @@ func CreateMetronomeDeploymentCommand() *cobra.Command {
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 
 			if !calldata {
-				if keyfile == "" {
-					return fmt.Errorf("--keystore not specified (this should be a path to an Ethereum account keystore file)")
+				if keyfile == "" && signerRaw == "" {
+					return fmt.Errorf("--keyfile or --signer not specified (this should be a path to an Ethereum account keystore file, or a signer URI)")
 				}
 
 				if rpc == "" {
@@ -1023,9 +1021,9 @@ func CreateMetronomeDeploymentCommand() *cobra.Command {
 				return clientErr
 			}
 
-			key, keyErr := KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
+			sender, senderErr := signer.FromFlags(cmd.Context(), signerRaw, keyfile, password)
+			if senderErr != nil {
+				return senderErr
 			}
 
 			chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
@@ -1035,10 +1033,9 @@ func CreateMetronomeDeploymentCommand() *cobra.Command {
 				return chainIDErr
 			}
 
-			transactionOpts, transactionOptsErr := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
-			if transactionOptsErr != nil {
-				return transactionOptsErr
-			}
+			ctx, cancel := NewChainContext(timeout)
+			defer cancel()
+			transactionOpts := signer.NewTransactOpts(ctx, sender, chainID)
 
 			SetTransactionParametersFromArgs(transactionOpts, nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, gasLimit, simulate)
 
@@ -1063,7 +1060,7 @@ func CreateMetronomeDeploymentCommand() *cobra.Command {
 					return nil
 				} else {
 					fmt.Println("Creating Safe proposal...")
-					err = DeployWithSafe(client, key, common.HexToAddress(safeAddress), common.HexToAddress(safeCreateCall), value, safeApi, deployCalldata, SafeOperationType(safeOperationType), salt, safeNonce)
+					err = DeployWithSafe(ctx, client, sender, common.HexToAddress(safeAddress), common.HexToAddress(safeCreateCall), value, NewSafeClient(safeApi, safeApiKey), safeOut, safeForce, deployCalldata, SafeOperationType(safeOperationType), salt, safeNonce)
 					if err != nil {
 						return fmt.Errorf("failed to create Safe proposal: %v", err)
 					}
@@ -1112,6 +1109,7 @@ func CreateMetronomeDeploymentCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
 	cmd.Flags().StringVar(&keyfile, "keyfile", "", "Path to the keystore file to use for the transaction")
+	signer.AddSignerFlag(cmd, &signerRaw)
 	cmd.Flags().StringVar(&password, "password", "", "Password to use to unlock the keystore (if not specified, you will be prompted for the password when the command executes)")
 	cmd.Flags().StringVar(&nonce, "nonce", "", "Nonce to use for the transaction")
 	cmd.Flags().StringVar(&value, "value", "", "Value to send with the transaction")
@@ -1123,6 +1121,9 @@ func CreateMetronomeDeploymentCommand() *cobra.Command {
 	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
 	cmd.Flags().StringVar(&safeAddress, "safe", "", "Address of the Safe contract")
 	cmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
+	cmd.Flags().StringVar(&safeApiKey, "safe-api-key", "", "API key sent to the Safe API, required by https://api.safe.global (optional)")
+	cmd.Flags().StringVar(&safeOut, "safe-out", "", "Write the signed Safe transaction to this bundle file instead of proposing it to the Safe Transaction Service")
+	cmd.Flags().BoolVar(&safeForce, "force", false, "Propose the Safe transaction even if it reverts in simulation")
 	cmd.Flags().StringVar(&safeCreateCall, "safe-create-call", "", "Address of the CreateCall contract (optional)")
 	cmd.Flags().Uint8Var(&safeOperationType, "safe-operation", 1, "Safe operation type: 0 (Call) or 1 (DelegateCall) - default is 1")
 	cmd.Flags().StringVar(&safeSaltRaw, "safe-salt", "", "Salt to use for the Safe transaction")
@@ -1455,12 +1456,13 @@ func CreateSchedulesCommand() *cobra.Command {
 }
 
 func CreateClaimCommand() *cobra.Command {
-	var keyfile, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc, contractAddressRaw, safeFunction, safeNonceRaw string
+	var keyfile, signerRaw, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc, contractAddressRaw, safeFunction, safeNonceRaw string
 	var gasLimit uint64
 	var simulate bool
 	var timeout uint
 	var contractAddress common.Address
-	var safeAddress, safeApi string
+	var safeAddress, safeApi, safeApiKey, safeOut string
+	var safeForce bool
 	var safeOperationType uint8
 	var safeNonce *big.Int
 	var calldata bool
@@ -1482,8 +1484,8 @@ func CreateClaimCommand() *cobra.Command {
 				}
 				contractAddress = common.HexToAddress(contractAddressRaw)
 
-				if keyfile == "" {
-					return fmt.Errorf("--keystore not specified (this should be a path to an Ethereum account keystore file)")
+				if keyfile == "" && signerRaw == "" {
+					return fmt.Errorf("--keyfile or --signer not specified (this should be a path to an Ethereum account keystore file, or a signer URI)")
 				}
 
 				if rpc == "" {
@@ -1573,9 +1575,9 @@ func CreateClaimCommand() *cobra.Command {
 				return clientErr
 			}
 
-			key, keyErr := KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
+			sender, senderErr := signer.FromFlags(cmd.Context(), signerRaw, keyfile, password)
+			if senderErr != nil {
+				return senderErr
 			}
 
 			chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
@@ -1585,10 +1587,9 @@ func CreateClaimCommand() *cobra.Command {
 				return chainIDErr
 			}
 
-			transactionOpts, transactionOptsErr := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
-			if transactionOptsErr != nil {
-				return transactionOptsErr
-			}
+			ctx, cancel := NewChainContext(timeout)
+			defer cancel()
+			transactionOpts := signer.NewTransactOpts(ctx, sender, chainID)
 
 			SetTransactionParametersFromArgs(transactionOpts, nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, gasLimit, simulate)
 
@@ -1609,7 +1610,7 @@ func CreateClaimCommand() *cobra.Command {
 					value = big.NewInt(0)
 				}
 
-				err = CreateSafeProposal(client, key, common.HexToAddress(safeAddress), contractAddress, txCalldata, value, safeApi, SafeOperationType(safeOperationType), safeNonce)
+				err = CreateSafeProposal(ctx, client, sender, common.HexToAddress(safeAddress), contractAddress, txCalldata, value, NewSafeClient(safeApi, safeApiKey), safeOut, safeForce, SafeOperationType(safeOperationType), safeNonce)
 				if err != nil {
 					return fmt.Errorf("failed to create Safe proposal: %v", err)
 				}
@@ -1659,6 +1660,7 @@ func CreateClaimCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
 	cmd.Flags().StringVar(&keyfile, "keyfile", "", "Path to the keystore file to use for the transaction")
+	signer.AddSignerFlag(cmd, &signerRaw)
 	cmd.Flags().StringVar(&password, "password", "", "Password to use to unlock the keystore (if not specified, you will be prompted for the password when the command executes)")
 	cmd.Flags().StringVar(&nonce, "nonce", "", "Nonce to use for the transaction")
 	cmd.Flags().StringVar(&value, "value", "", "Value to send with the transaction")
@@ -1671,6 +1673,9 @@ func CreateClaimCommand() *cobra.Command {
 	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
 	cmd.Flags().StringVar(&safeAddress, "safe", "", "Address of the Safe contract")
 	cmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
+	cmd.Flags().StringVar(&safeApiKey, "safe-api-key", "", "API key sent to the Safe API, required by https://api.safe.global (optional)")
+	cmd.Flags().StringVar(&safeOut, "safe-out", "", "Write the signed Safe transaction to this bundle file instead of proposing it to the Safe Transaction Service")
+	cmd.Flags().BoolVar(&safeForce, "force", false, "Propose the Safe transaction even if it reverts in simulation")
 	cmd.Flags().Uint8Var(&safeOperationType, "safe-operation", 0, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
 	cmd.Flags().StringVar(&safeFunction, "safe-function", "", "Safe function overrider to use for the transaction (optional)")
 	cmd.Flags().StringVar(&safeNonceRaw, "safe-nonce", "", "Safe nonce overrider for the transaction (optional)")
@@ -1682,12 +1687,13 @@ func CreateClaimCommand() *cobra.Command {
 	return cmd
 }
 func CreateClaimBatchCommand() *cobra.Command {
-	var keyfile, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc, contractAddressRaw, safeFunction, safeNonceRaw string
+	var keyfile, signerRaw, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc, contractAddressRaw, safeFunction, safeNonceRaw string
 	var gasLimit uint64
 	var simulate bool
 	var timeout uint
 	var contractAddress common.Address
-	var safeAddress, safeApi string
+	var safeAddress, safeApi, safeApiKey, safeOut string
+	var safeForce bool
 	var safeOperationType uint8
 	var safeNonce *big.Int
 	var calldata bool
@@ -1709,8 +1715,8 @@ func CreateClaimBatchCommand() *cobra.Command {
 				}
 				contractAddress = common.HexToAddress(contractAddressRaw)
 
-				if keyfile == "" {
-					return fmt.Errorf("--keystore not specified (this should be a path to an Ethereum account keystore file)")
+				if keyfile == "" && signerRaw == "" {
+					return fmt.Errorf("--keyfile or --signer not specified (this should be a path to an Ethereum account keystore file, or a signer URI)")
 				}
 
 				if rpc == "" {
@@ -1813,9 +1819,9 @@ func CreateClaimBatchCommand() *cobra.Command {
 				return clientErr
 			}
 
-			key, keyErr := KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
+			sender, senderErr := signer.FromFlags(cmd.Context(), signerRaw, keyfile, password)
+			if senderErr != nil {
+				return senderErr
 			}
 
 			chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
@@ -1825,10 +1831,9 @@ func CreateClaimBatchCommand() *cobra.Command {
 				return chainIDErr
 			}
 
-			transactionOpts, transactionOptsErr := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
-			if transactionOptsErr != nil {
-				return transactionOptsErr
-			}
+			ctx, cancel := NewChainContext(timeout)
+			defer cancel()
+			transactionOpts := signer.NewTransactOpts(ctx, sender, chainID)
 
 			SetTransactionParametersFromArgs(transactionOpts, nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, gasLimit, simulate)
 
@@ -1849,7 +1854,7 @@ func CreateClaimBatchCommand() *cobra.Command {
 					value = big.NewInt(0)
 				}
 
-				err = CreateSafeProposal(client, key, common.HexToAddress(safeAddress), contractAddress, txCalldata, value, safeApi, SafeOperationType(safeOperationType), safeNonce)
+				err = CreateSafeProposal(ctx, client, sender, common.HexToAddress(safeAddress), contractAddress, txCalldata, value, NewSafeClient(safeApi, safeApiKey), safeOut, safeForce, SafeOperationType(safeOperationType), safeNonce)
 				if err != nil {
 					return fmt.Errorf("failed to create Safe proposal: %v", err)
 				}
@@ -1899,6 +1904,7 @@ func CreateClaimBatchCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
 	cmd.Flags().StringVar(&keyfile, "keyfile", "", "Path to the keystore file to use for the transaction")
+	signer.AddSignerFlag(cmd, &signerRaw)
 	cmd.Flags().StringVar(&password, "password", "", "Password to use to unlock the keystore (if not specified, you will be prompted for the password when the command executes)")
 	cmd.Flags().StringVar(&nonce, "nonce", "", "Nonce to use for the transaction")
 	cmd.Flags().StringVar(&value, "value", "", "Value to send with the transaction")
@@ -1911,6 +1917,9 @@ func CreateClaimBatchCommand() *cobra.Command {
 	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
 	cmd.Flags().StringVar(&safeAddress, "safe", "", "Address of the Safe contract")
 	cmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
+	cmd.Flags().StringVar(&safeApiKey, "safe-api-key", "", "API key sent to the Safe API, required by https://api.safe.global (optional)")
+	cmd.Flags().StringVar(&safeOut, "safe-out", "", "Write the signed Safe transaction to this bundle file instead of proposing it to the Safe Transaction Service")
+	cmd.Flags().BoolVar(&safeForce, "force", false, "Propose the Safe transaction even if it reverts in simulation")
 	cmd.Flags().Uint8Var(&safeOperationType, "safe-operation", 0, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
 	cmd.Flags().StringVar(&safeFunction, "safe-function", "", "Safe function overrider to use for the transaction (optional)")
 	cmd.Flags().StringVar(&safeNonceRaw, "safe-nonce", "", "Safe nonce overrider for the transaction (optional)")
@@ -1922,12 +1931,13 @@ func CreateClaimBatchCommand() *cobra.Command {
 	return cmd
 }
 func CreateCreateScheduleCommand() *cobra.Command {
-	var keyfile, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc, contractAddressRaw, safeFunction, safeNonceRaw string
+	var keyfile, signerRaw, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc, contractAddressRaw, safeFunction, safeNonceRaw string
 	var gasLimit uint64
 	var simulate bool
 	var timeout uint
 	var contractAddress common.Address
-	var safeAddress, safeApi string
+	var safeAddress, safeApi, safeApiKey, safeOut string
+	var safeForce bool
 	var safeOperationType uint8
 	var safeNonce *big.Int
 	var calldata bool
@@ -1951,8 +1961,8 @@ func CreateCreateScheduleCommand() *cobra.Command {
 				}
 				contractAddress = common.HexToAddress(contractAddressRaw)
 
-				if keyfile == "" {
-					return fmt.Errorf("--keystore not specified (this should be a path to an Ethereum account keystore file)")
+				if keyfile == "" && signerRaw == "" {
+					return fmt.Errorf("--keyfile or --signer not specified (this should be a path to an Ethereum account keystore file, or a signer URI)")
 				}
 
 				if rpc == "" {
@@ -2048,9 +2058,9 @@ func CreateCreateScheduleCommand() *cobra.Command {
 				return clientErr
 			}
 
-			key, keyErr := KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
+			sender, senderErr := signer.FromFlags(cmd.Context(), signerRaw, keyfile, password)
+			if senderErr != nil {
+				return senderErr
 			}
 
 			chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
@@ -2060,10 +2070,9 @@ func CreateCreateScheduleCommand() *cobra.Command {
 				return chainIDErr
 			}
 
-			transactionOpts, transactionOptsErr := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
-			if transactionOptsErr != nil {
-				return transactionOptsErr
-			}
+			ctx, cancel := NewChainContext(timeout)
+			defer cancel()
+			transactionOpts := signer.NewTransactOpts(ctx, sender, chainID)
 
 			SetTransactionParametersFromArgs(transactionOpts, nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, gasLimit, simulate)
 
@@ -2084,7 +2093,7 @@ func CreateCreateScheduleCommand() *cobra.Command {
 					value = big.NewInt(0)
 				}
 
-				err = CreateSafeProposal(client, key, common.HexToAddress(safeAddress), contractAddress, txCalldata, value, safeApi, SafeOperationType(safeOperationType), safeNonce)
+				err = CreateSafeProposal(ctx, client, sender, common.HexToAddress(safeAddress), contractAddress, txCalldata, value, NewSafeClient(safeApi, safeApiKey), safeOut, safeForce, SafeOperationType(safeOperationType), safeNonce)
 				if err != nil {
 					return fmt.Errorf("failed to create Safe proposal: %v", err)
 				}
@@ -2135,6 +2144,7 @@ func CreateCreateScheduleCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
 	cmd.Flags().StringVar(&keyfile, "keyfile", "", "Path to the keystore file to use for the transaction")
+	signer.AddSignerFlag(cmd, &signerRaw)
 	cmd.Flags().StringVar(&password, "password", "", "Password to use to unlock the keystore (if not specified, you will be prompted for the password when the command executes)")
 	cmd.Flags().StringVar(&nonce, "nonce", "", "Nonce to use for the transaction")
 	cmd.Flags().StringVar(&value, "value", "", "Value to send with the transaction")
@@ -2147,6 +2157,9 @@ func CreateCreateScheduleCommand() *cobra.Command {
 	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
 	cmd.Flags().StringVar(&safeAddress, "safe", "", "Address of the Safe contract")
 	cmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
+	cmd.Flags().StringVar(&safeApiKey, "safe-api-key", "", "API key sent to the Safe API, required by https://api.safe.global (optional)")
+	cmd.Flags().StringVar(&safeOut, "safe-out", "", "Write the signed Safe transaction to this bundle file instead of proposing it to the Safe Transaction Service")
+	cmd.Flags().BoolVar(&safeForce, "force", false, "Propose the Safe transaction even if it reverts in simulation")
 	cmd.Flags().Uint8Var(&safeOperationType, "safe-operation", 0, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
 	cmd.Flags().StringVar(&safeFunction, "safe-function", "", "Safe function overrider to use for the transaction (optional)")
 	cmd.Flags().StringVar(&safeNonceRaw, "safe-nonce", "", "Safe nonce overrider for the transaction (optional)")
@@ -2159,12 +2172,13 @@ func CreateCreateScheduleCommand() *cobra.Command {
 	return cmd
 }
 func CreateIncreaseBalanceCommand() *cobra.Command {
-	var keyfile, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc, contractAddressRaw, safeFunction, safeNonceRaw string
+	var keyfile, signerRaw, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc, contractAddressRaw, safeFunction, safeNonceRaw string
 	var gasLimit uint64
 	var simulate bool
 	var timeout uint
 	var contractAddress common.Address
-	var safeAddress, safeApi string
+	var safeAddress, safeApi, safeApiKey, safeOut string
+	var safeForce bool
 	var safeOperationType uint8
 	var safeNonce *big.Int
 	var calldata bool
@@ -2184,8 +2198,8 @@ func CreateIncreaseBalanceCommand() *cobra.Command {
 				}
 				contractAddress = common.HexToAddress(contractAddressRaw)
 
-				if keyfile == "" {
-					return fmt.Errorf("--keystore not specified (this should be a path to an Ethereum account keystore file)")
+				if keyfile == "" && signerRaw == "" {
+					return fmt.Errorf("--keyfile or --signer not specified (this should be a path to an Ethereum account keystore file, or a signer URI)")
 				}
 
 				if rpc == "" {
@@ -2267,9 +2281,9 @@ func CreateIncreaseBalanceCommand() *cobra.Command {
 				return clientErr
 			}
 
-			key, keyErr := KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
+			sender, senderErr := signer.FromFlags(cmd.Context(), signerRaw, keyfile, password)
+			if senderErr != nil {
+				return senderErr
 			}
 
 			chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
@@ -2279,10 +2293,9 @@ func CreateIncreaseBalanceCommand() *cobra.Command {
 				return chainIDErr
 			}
 
-			transactionOpts, transactionOptsErr := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
-			if transactionOptsErr != nil {
-				return transactionOptsErr
-			}
+			ctx, cancel := NewChainContext(timeout)
+			defer cancel()
+			transactionOpts := signer.NewTransactOpts(ctx, sender, chainID)
 
 			SetTransactionParametersFromArgs(transactionOpts, nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, gasLimit, simulate)
 
@@ -2303,7 +2316,7 @@ func CreateIncreaseBalanceCommand() *cobra.Command {
 					value = big.NewInt(0)
 				}
 
-				err = CreateSafeProposal(client, key, common.HexToAddress(safeAddress), contractAddress, txCalldata, value, safeApi, SafeOperationType(safeOperationType), safeNonce)
+				err = CreateSafeProposal(ctx, client, sender, common.HexToAddress(safeAddress), contractAddress, txCalldata, value, NewSafeClient(safeApi, safeApiKey), safeOut, safeForce, SafeOperationType(safeOperationType), safeNonce)
 				if err != nil {
 					return fmt.Errorf("failed to create Safe proposal: %v", err)
 				}
@@ -2352,6 +2365,7 @@ func CreateIncreaseBalanceCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
 	cmd.Flags().StringVar(&keyfile, "keyfile", "", "Path to the keystore file to use for the transaction")
+	signer.AddSignerFlag(cmd, &signerRaw)
 	cmd.Flags().StringVar(&password, "password", "", "Password to use to unlock the keystore (if not specified, you will be prompted for the password when the command executes)")
 	cmd.Flags().StringVar(&nonce, "nonce", "", "Nonce to use for the transaction")
 	cmd.Flags().StringVar(&value, "value", "", "Value to send with the transaction")
@@ -2364,6 +2378,9 @@ func CreateIncreaseBalanceCommand() *cobra.Command {
 	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
 	cmd.Flags().StringVar(&safeAddress, "safe", "", "Address of the Safe contract")
 	cmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
+	cmd.Flags().StringVar(&safeApiKey, "safe-api-key", "", "API key sent to the Safe API, required by https://api.safe.global (optional)")
+	cmd.Flags().StringVar(&safeOut, "safe-out", "", "Write the signed Safe transaction to this bundle file instead of proposing it to the Safe Transaction Service")
+	cmd.Flags().BoolVar(&safeForce, "force", false, "Propose the Safe transaction even if it reverts in simulation")
 	cmd.Flags().Uint8Var(&safeOperationType, "safe-operation", 0, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
 	cmd.Flags().StringVar(&safeFunction, "safe-function", "", "Safe function overrider to use for the transaction (optional)")
 	cmd.Flags().StringVar(&safeNonceRaw, "safe-nonce", "", "Safe nonce overrider for the transaction (optional)")
@@ -2557,29 +2574,11 @@ func (o SafeOperationType) String() string {
 	}
 }
 
-// SafeTransactionData represents the data for a Safe transaction
-type SafeTransactionData struct {
-	To             string            `json:"to"`
-	Value          string            `json:"value"`
-	Data           string            `json:"data"`
-	Operation      SafeOperationType `json:"operation"`
-	SafeTxGas      uint64            `json:"safeTxGas"`
-	BaseGas        uint64            `json:"baseGas"`
-	GasPrice       string            `json:"gasPrice"`
-	GasToken       string            `json:"gasToken"`
-	RefundReceiver string            `json:"refundReceiver"`
-	Nonce          *big.Int          `json:"nonce"`
-	SafeTxHash     string            `json:"safeTxHash"`
-	Sender         string            `json:"sender"`
-	Signature      string            `json:"signature"`
-	Origin         string            `json:"origin"`
-}
-
 const (
 	NativeTokenAddress = "0x0000000000000000000000000000000000000000"
 )
 
-func DeployWithSafe(client *ethclient.Client, key *keystore.Key, safeAddress common.Address, factoryAddress common.Address, value *big.Int, safeApi string, deployBytecode []byte, safeOperationType SafeOperationType, salt [32]byte, safeNonce *big.Int) error {
+func DeployWithSafe(ctx context.Context, client *ethclient.Client, sender signer.Signer, safeAddress common.Address, factoryAddress common.Address, value *big.Int, safeClient *safe.Client, safeOut string, safeForce bool, deployBytecode []byte, safeOperationType SafeOperationType, salt [32]byte, safeNonce *big.Int) error {
 	abi, err := CreateCall.CreateCallMetaData.GetAbi()
 	if err != nil {
 		return fmt.Errorf("failed to get ABI: %v", err)
@@ -2590,7 +2589,7 @@ func DeployWithSafe(client *ethclient.Client, key *keystore.Key, safeAddress com
 		return fmt.Errorf("failed to pack performCreate2 transaction: %v", err)
 	}
 
-	return CreateSafeProposal(client, key, safeAddress, factoryAddress, safeCreateCallTxData, value, safeApi, SafeOperationType(safeOperationType), safeNonce)
+	return CreateSafeProposal(ctx, client, sender, safeAddress, factoryAddress, safeCreateCallTxData, value, safeClient, safeOut, safeForce, SafeOperationType(safeOperationType), safeNonce)
 }
 
 func PredictDeploymentAddressSafe(from common.Address, salt [32]byte, deployBytecode []byte) (common.Address, error) {
@@ -2603,153 +2602,24 @@ func PredictDeploymentAddressSafe(from common.Address, salt [32]byte, deployByte
 	return deployedAddress, nil
 }
 
-func CreateSafeProposal(client *ethclient.Client, key *keystore.Key, safeAddress common.Address, to common.Address, data []byte, value *big.Int, safeApi string, safeOperationType SafeOperationType, safeNonce *big.Int) error {
-	chainID, err := client.ChainID(context.Background())
-	if err != nil {
-		return fmt.Errorf("failed to get chain ID: %v", err)
-	}
-
-	// Create a new instance of the GnosisSafe contract
-	safeInstance, err := GnosisSafe.NewGnosisSafe(safeAddress, client)
-	if err != nil {
-		return fmt.Errorf("failed to create GnosisSafe instance: %v", err)
-	}
-
-	nonce := safeNonce
-	if safeNonce == nil {
-		// Fetch the current nonce from the Safe contract
-		fetchedNonce, err := safeInstance.Nonce(&bind.CallOpts{})
-		if err != nil {
-			return fmt.Errorf("failed to fetch nonce from Safe contract: %v", err)
-		}
-		nonce = fetchedNonce
-	} else {
-		nonce = safeNonce
-	}
-
-	safeTransactionData := SafeTransactionData{
-		To:             to.Hex(),
-		Value:          value.String(),
-		Data:           common.Bytes2Hex(data),
-		Operation:      safeOperationType,
-		SafeTxGas:      0,
-		BaseGas:        0,
-		GasPrice:       "0",
-		GasToken:       NativeTokenAddress,
-		RefundReceiver: NativeTokenAddress,
-		Nonce:          nonce,
-	}
-
-	// Calculate SafeTxHash
-	safeTxHash, err := CalculateSafeTxHash(safeAddress, safeTransactionData, chainID)
-	if err != nil {
-		return fmt.Errorf("failed to calculate SafeTxHash: %v", err)
-	}
-
-	// Sign the SafeTxHash
-	signature, err := crypto.Sign(safeTxHash.Bytes(), key.PrivateKey)
-	if err != nil {
-		return fmt.Errorf("failed to sign SafeTxHash: %v", err)
-	}
-
-	// Adjust V value for Ethereum's replay protection
-	signature[64] += 27
-
-	// Convert signature to hex
-	senderSignature := "0x" + common.Bytes2Hex(signature)
-
-	// Prepare the request body
-	requestBody := map[string]interface{}{
-		"to":             safeTransactionData.To,
-		"value":          safeTransactionData.Value,
-		"data":           "0x" + safeTransactionData.Data,
-		"operation":      int(safeTransactionData.Operation),
-		"safeTxGas":      fmt.Sprintf("%d", safeTransactionData.SafeTxGas),
-		"baseGas":        fmt.Sprintf("%d", safeTransactionData.BaseGas),
-		"gasPrice":       safeTransactionData.GasPrice,
-		"gasToken":       safeTransactionData.GasToken,
-		"refundReceiver": safeTransactionData.RefundReceiver,
-		"nonce":          fmt.Sprintf("%d", safeTransactionData.Nonce),
-		"safeTxHash":     safeTxHash.Hex(),
-		"sender":         key.Address.Hex(),
-		"signature":      senderSignature,
-		"origin":         fmt.Sprintf("{\"url\":\"%s\",\"name\":\"TokenSender Deployment\"}", safeApi),
-	}
-
-	// Marshal the request body to JSON
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %v", err)
-	}
-
-	// Send the request to the Safe Transaction Service
-	req, err := http.NewRequest("POST", safeApi, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	fmt.Println("Safe proposal created successfully")
-	return nil
+func init() {
+	// Decode the custom errors of the contract in the revert reasons of Safe proposals that fail in simulation.
+	safe.RegisterRevertErrorABI(MetronomeABI)
 }
 
-func CalculateSafeTxHash(safeAddress common.Address, txData SafeTransactionData, chainID *big.Int) (common.Hash, error) {
-	domainSeparator := apitypes.TypedDataDomain{
-		ChainId:           (*math.HexOrDecimal256)(chainID),
-		VerifyingContract: safeAddress.Hex(),
-	}
-
-	typedData := apitypes.TypedData{
-		Types: apitypes.Types{
-			"EIP712Domain": []apitypes.Type{
-				{Name: "chainId", Type: "uint256"},
-				{Name: "verifyingContract", Type: "address"},
-			},
-			"SafeTx": []apitypes.Type{
-				{Name: "to", Type: "address"},
-				{Name: "value", Type: "uint256"},
-				{Name: "data", Type: "bytes"},
-				{Name: "operation", Type: "uint8"},
-				{Name: "safeTxGas", Type: "uint256"},
-				{Name: "baseGas", Type: "uint256"},
-				{Name: "gasPrice", Type: "uint256"},
-				{Name: "gasToken", Type: "address"},
-				{Name: "refundReceiver", Type: "address"},
-				{Name: "nonce", Type: "uint256"},
-			},
-		},
-		Domain:      domainSeparator,
-		PrimaryType: "SafeTx",
-		Message: apitypes.TypedDataMessage{
-			"to":             txData.To,
-			"value":          txData.Value,
-			"data":           "0x" + txData.Data,
-			"operation":      fmt.Sprintf("%d", txData.Operation),
-			"safeTxGas":      fmt.Sprintf("%d", txData.SafeTxGas),
-			"baseGas":        fmt.Sprintf("%d", txData.BaseGas),
-			"gasPrice":       txData.GasPrice,
-			"gasToken":       txData.GasToken,
-			"refundReceiver": txData.RefundReceiver,
-			"nonce":          fmt.Sprintf("%d", txData.Nonce),
-		},
-	}
-
-	typedDataHash, _, err := apitypes.TypedDataAndHash(typedData)
-	if err != nil {
-		return common.Hash{}, fmt.Errorf("failed to hash typed data: %v", err)
-	}
+// Returns the client that the --safe mode of the commands proposes through. safeApi is the endpoint that proposals are
+// posted to: the value of --safe-api, or the Safe client gateway of the chain by default.
+func NewSafeClient(safeApi string, safeApiKey string) *safe.Client {
+	client := safe.NewClient("")
+	client.ProposeURL = safeApi
+	client.APIKey = safeApiKey
+	client.Origin.Name = "game7 Metronome"
+	return client
+}
 
-	return common.BytesToHash(typedDataHash), nil
+// Proposes a Safe transaction making the given call through safeClient or, if safeOut is set, writes it to a bundle
+// file for the other owners to sign offline. Unless safeForce is set, the transaction is simulated first and not
+// proposed if it reverts. The commands pass the context of their --timeout.
+func CreateSafeProposal(ctx context.Context, client *ethclient.Client, sender signer.Signer, safeAddress common.Address, to common.Address, data []byte, value *big.Int, safeClient *safe.Client, safeOut string, safeForce bool, safeOperationType SafeOperationType, safeNonce *big.Int) error {
+	return safe.CreateProposal(ctx, client, sender, safeClient, safeOut, safeForce, safeAddress, to, data, value, safe.OperationType(safeOperationType), safeNonce)
 }
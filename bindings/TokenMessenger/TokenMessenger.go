@@ -0,0 +1,675 @@
+// This file was generated by seer: https://github.com/G7DAO/seer.
+// seer version: 0.3.15
+// seer command: seer evm generate --package TokenMessenger --abi abis/TokenMessenger.json --struct TokenMessenger --output bindings/TokenMessenger/TokenMessenger.go
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package TokenMessenger
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// TokenMessengerMetaData contains all meta data concerning the TokenMessenger contract.
+var TokenMessengerMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint64\",\"name\":\"nonce\",\"type\":\"uint64\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"burnToken\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"depositor\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"mintRecipient\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint32\",\"name\":\"destinationDomain\",\"type\":\"uint32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"destinationTokenMessenger\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"destinationCaller\",\"type\":\"bytes32\"}],\"name\":\"DepositForBurn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"mintRecipient\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"mintToken\",\"type\":\"address\"}],\"name\":\"MintAndWithdraw\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint32\",\"name\":\"destinationDomain\",\"type\":\"uint32\"},{\"internalType\":\"bytes32\",\"name\":\"mintRecipient\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"burnToken\",\"type\":\"address\"}],\"name\":\"depositForBurn\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"_nonce\",\"type\":\"uint64\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint32\",\"name\":\"destinationDomain\",\"type\":\"uint32\"},{\"internalType\":\"bytes32\",\"name\":\"mintRecipient\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"burnToken\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"destinationCaller\",\"type\":\"bytes32\"}],\"name\":\"depositForBurnWithCaller\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"nonce\",\"type\":\"uint64\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"localMessageTransmitter\",\"outputs\":[{\"internalType\":\"contractIMessageTransmitter\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"localMinter\",\"outputs\":[{\"internalType\":\"contractITokenMinter\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"messageBodyVersion\",\"outputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"}],\"name\":\"remoteTokenMessengers\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// TokenMessengerABI is the input ABI used to generate the binding from.
+// Deprecated: Use TokenMessengerMetaData.ABI instead.
+var TokenMessengerABI = TokenMessengerMetaData.ABI
+
+// TokenMessenger is an auto generated Go binding around an Ethereum contract.
+type TokenMessenger struct {
+	TokenMessengerCaller     // Read-only binding to the contract
+	TokenMessengerTransactor // Write-only binding to the contract
+	TokenMessengerFilterer   // Log filterer for contract events
+}
+
+// TokenMessengerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type TokenMessengerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenMessengerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type TokenMessengerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenMessengerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type TokenMessengerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenMessengerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type TokenMessengerSession struct {
+	Contract     *TokenMessenger   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// TokenMessengerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type TokenMessengerCallerSession struct {
+	Contract *TokenMessengerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// TokenMessengerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type TokenMessengerTransactorSession struct {
+	Contract     *TokenMessengerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// TokenMessengerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type TokenMessengerRaw struct {
+	Contract *TokenMessenger // Generic contract binding to access the raw methods on
+}
+
+// TokenMessengerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type TokenMessengerCallerRaw struct {
+	Contract *TokenMessengerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// TokenMessengerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type TokenMessengerTransactorRaw struct {
+	Contract *TokenMessengerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewTokenMessenger creates a new instance of TokenMessenger, bound to a specific deployed contract.
+func NewTokenMessenger(address common.Address, backend bind.ContractBackend) (*TokenMessenger, error) {
+	contract, err := bindTokenMessenger(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessenger{TokenMessengerCaller: TokenMessengerCaller{contract: contract}, TokenMessengerTransactor: TokenMessengerTransactor{contract: contract}, TokenMessengerFilterer: TokenMessengerFilterer{contract: contract}}, nil
+}
+
+// NewTokenMessengerCaller creates a new read-only instance of TokenMessenger, bound to a specific deployed contract.
+func NewTokenMessengerCaller(address common.Address, caller bind.ContractCaller) (*TokenMessengerCaller, error) {
+	contract, err := bindTokenMessenger(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessengerCaller{contract: contract}, nil
+}
+
+// NewTokenMessengerTransactor creates a new write-only instance of TokenMessenger, bound to a specific deployed contract.
+func NewTokenMessengerTransactor(address common.Address, transactor bind.ContractTransactor) (*TokenMessengerTransactor, error) {
+	contract, err := bindTokenMessenger(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessengerTransactor{contract: contract}, nil
+}
+
+// NewTokenMessengerFilterer creates a new log filterer instance of TokenMessenger, bound to a specific deployed contract.
+func NewTokenMessengerFilterer(address common.Address, filterer bind.ContractFilterer) (*TokenMessengerFilterer, error) {
+	contract, err := bindTokenMessenger(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessengerFilterer{contract: contract}, nil
+}
+
+// bindTokenMessenger binds a generic wrapper to an already deployed contract.
+func bindTokenMessenger(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := TokenMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TokenMessenger *TokenMessengerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TokenMessenger.Contract.TokenMessengerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TokenMessenger *TokenMessengerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TokenMessenger.Contract.TokenMessengerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TokenMessenger *TokenMessengerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TokenMessenger.Contract.TokenMessengerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TokenMessenger *TokenMessengerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TokenMessenger.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TokenMessenger *TokenMessengerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TokenMessenger.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TokenMessenger *TokenMessengerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TokenMessenger.Contract.contract.Transact(opts, method, params...)
+}
+
+// LocalMessageTransmitter is a free data retrieval call binding the contract method 0x2c121921.
+//
+// Solidity: function localMessageTransmitter() view returns(address)
+func (_TokenMessenger *TokenMessengerCaller) LocalMessageTransmitter(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _TokenMessenger.contract.Call(opts, &out, "localMessageTransmitter")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// LocalMessageTransmitter is a free data retrieval call binding the contract method 0x2c121921.
+//
+// Solidity: function localMessageTransmitter() view returns(address)
+func (_TokenMessenger *TokenMessengerSession) LocalMessageTransmitter() (common.Address, error) {
+	return _TokenMessenger.Contract.LocalMessageTransmitter(&_TokenMessenger.CallOpts)
+}
+
+// LocalMessageTransmitter is a free data retrieval call binding the contract method 0x2c121921.
+//
+// Solidity: function localMessageTransmitter() view returns(address)
+func (_TokenMessenger *TokenMessengerCallerSession) LocalMessageTransmitter() (common.Address, error) {
+	return _TokenMessenger.Contract.LocalMessageTransmitter(&_TokenMessenger.CallOpts)
+}
+
+// LocalMinter is a free data retrieval call binding the contract method 0xcb75c11c.
+//
+// Solidity: function localMinter() view returns(address)
+func (_TokenMessenger *TokenMessengerCaller) LocalMinter(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _TokenMessenger.contract.Call(opts, &out, "localMinter")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// LocalMinter is a free data retrieval call binding the contract method 0xcb75c11c.
+//
+// Solidity: function localMinter() view returns(address)
+func (_TokenMessenger *TokenMessengerSession) LocalMinter() (common.Address, error) {
+	return _TokenMessenger.Contract.LocalMinter(&_TokenMessenger.CallOpts)
+}
+
+// LocalMinter is a free data retrieval call binding the contract method 0xcb75c11c.
+//
+// Solidity: function localMinter() view returns(address)
+func (_TokenMessenger *TokenMessengerCallerSession) LocalMinter() (common.Address, error) {
+	return _TokenMessenger.Contract.LocalMinter(&_TokenMessenger.CallOpts)
+}
+
+// MessageBodyVersion is a free data retrieval call binding the contract method 0x9cdbb181.
+//
+// Solidity: function messageBodyVersion() view returns(uint32)
+func (_TokenMessenger *TokenMessengerCaller) MessageBodyVersion(opts *bind.CallOpts) (uint32, error) {
+	var out []interface{}
+	err := _TokenMessenger.contract.Call(opts, &out, "messageBodyVersion")
+
+	if err != nil {
+		return *new(uint32), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint32)).(*uint32)
+
+	return out0, err
+
+}
+
+// MessageBodyVersion is a free data retrieval call binding the contract method 0x9cdbb181.
+//
+// Solidity: function messageBodyVersion() view returns(uint32)
+func (_TokenMessenger *TokenMessengerSession) MessageBodyVersion() (uint32, error) {
+	return _TokenMessenger.Contract.MessageBodyVersion(&_TokenMessenger.CallOpts)
+}
+
+// MessageBodyVersion is a free data retrieval call binding the contract method 0x9cdbb181.
+//
+// Solidity: function messageBodyVersion() view returns(uint32)
+func (_TokenMessenger *TokenMessengerCallerSession) MessageBodyVersion() (uint32, error) {
+	return _TokenMessenger.Contract.MessageBodyVersion(&_TokenMessenger.CallOpts)
+}
+
+// RemoteTokenMessengers is a free data retrieval call binding the contract method 0x82a5e665.
+//
+// Solidity: function remoteTokenMessengers(uint32 ) view returns(bytes32)
+func (_TokenMessenger *TokenMessengerCaller) RemoteTokenMessengers(opts *bind.CallOpts, arg0 uint32) ([32]byte, error) {
+	var out []interface{}
+	err := _TokenMessenger.contract.Call(opts, &out, "remoteTokenMessengers", arg0)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// RemoteTokenMessengers is a free data retrieval call binding the contract method 0x82a5e665.
+//
+// Solidity: function remoteTokenMessengers(uint32 ) view returns(bytes32)
+func (_TokenMessenger *TokenMessengerSession) RemoteTokenMessengers(arg0 uint32) ([32]byte, error) {
+	return _TokenMessenger.Contract.RemoteTokenMessengers(&_TokenMessenger.CallOpts, arg0)
+}
+
+// RemoteTokenMessengers is a free data retrieval call binding the contract method 0x82a5e665.
+//
+// Solidity: function remoteTokenMessengers(uint32 ) view returns(bytes32)
+func (_TokenMessenger *TokenMessengerCallerSession) RemoteTokenMessengers(arg0 uint32) ([32]byte, error) {
+	return _TokenMessenger.Contract.RemoteTokenMessengers(&_TokenMessenger.CallOpts, arg0)
+}
+
+// DepositForBurn is a paid mutator transaction binding the contract method 0x6fd3504e.
+//
+// Solidity: function depositForBurn(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken) returns(uint64 _nonce)
+func (_TokenMessenger *TokenMessengerTransactor) DepositForBurn(opts *bind.TransactOpts, amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address) (*types.Transaction, error) {
+	return _TokenMessenger.contract.Transact(opts, "depositForBurn", amount, destinationDomain, mintRecipient, burnToken)
+}
+
+// DepositForBurn is a paid mutator transaction binding the contract method 0x6fd3504e.
+//
+// Solidity: function depositForBurn(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken) returns(uint64 _nonce)
+func (_TokenMessenger *TokenMessengerSession) DepositForBurn(amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address) (*types.Transaction, error) {
+	return _TokenMessenger.Contract.DepositForBurn(&_TokenMessenger.TransactOpts, amount, destinationDomain, mintRecipient, burnToken)
+}
+
+// DepositForBurn is a paid mutator transaction binding the contract method 0x6fd3504e.
+//
+// Solidity: function depositForBurn(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken) returns(uint64 _nonce)
+func (_TokenMessenger *TokenMessengerTransactorSession) DepositForBurn(amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address) (*types.Transaction, error) {
+	return _TokenMessenger.Contract.DepositForBurn(&_TokenMessenger.TransactOpts, amount, destinationDomain, mintRecipient, burnToken)
+}
+
+// DepositForBurnWithCaller is a paid mutator transaction binding the contract method 0xf856ddb6.
+//
+// Solidity: function depositForBurnWithCaller(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken, bytes32 destinationCaller) returns(uint64 nonce)
+func (_TokenMessenger *TokenMessengerTransactor) DepositForBurnWithCaller(opts *bind.TransactOpts, amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address, destinationCaller [32]byte) (*types.Transaction, error) {
+	return _TokenMessenger.contract.Transact(opts, "depositForBurnWithCaller", amount, destinationDomain, mintRecipient, burnToken, destinationCaller)
+}
+
+// DepositForBurnWithCaller is a paid mutator transaction binding the contract method 0xf856ddb6.
+//
+// Solidity: function depositForBurnWithCaller(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken, bytes32 destinationCaller) returns(uint64 nonce)
+func (_TokenMessenger *TokenMessengerSession) DepositForBurnWithCaller(amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address, destinationCaller [32]byte) (*types.Transaction, error) {
+	return _TokenMessenger.Contract.DepositForBurnWithCaller(&_TokenMessenger.TransactOpts, amount, destinationDomain, mintRecipient, burnToken, destinationCaller)
+}
+
+// DepositForBurnWithCaller is a paid mutator transaction binding the contract method 0xf856ddb6.
+//
+// Solidity: function depositForBurnWithCaller(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken, bytes32 destinationCaller) returns(uint64 nonce)
+func (_TokenMessenger *TokenMessengerTransactorSession) DepositForBurnWithCaller(amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address, destinationCaller [32]byte) (*types.Transaction, error) {
+	return _TokenMessenger.Contract.DepositForBurnWithCaller(&_TokenMessenger.TransactOpts, amount, destinationDomain, mintRecipient, burnToken, destinationCaller)
+}
+
+// TokenMessengerDepositForBurnIterator is returned from FilterDepositForBurn and is used to iterate over the raw logs and unpacked data for DepositForBurn events raised by the TokenMessenger contract.
+type TokenMessengerDepositForBurnIterator struct {
+	Event *TokenMessengerDepositForBurn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenMessengerDepositForBurnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenMessengerDepositForBurn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenMessengerDepositForBurn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenMessengerDepositForBurnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenMessengerDepositForBurnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenMessengerDepositForBurn represents a DepositForBurn event raised by the TokenMessenger contract.
+type TokenMessengerDepositForBurn struct {
+	Nonce                     uint64
+	BurnToken                 common.Address
+	Amount                    *big.Int
+	Depositor                 common.Address
+	MintRecipient             [32]byte
+	DestinationDomain         uint32
+	DestinationTokenMessenger [32]byte
+	DestinationCaller         [32]byte
+	Raw                       types.Log // Blockchain specific contextual infos
+}
+
+// FilterDepositForBurn is a free log retrieval operation binding the contract event 0x2fa9ca894982930190727e75500a97d8dc500233a5065e0f3126c48fbe0343c0.
+//
+// Solidity: event DepositForBurn(uint64 indexed nonce, address indexed burnToken, uint256 amount, address indexed depositor, bytes32 mintRecipient, uint32 destinationDomain, bytes32 destinationTokenMessenger, bytes32 destinationCaller)
+func (_TokenMessenger *TokenMessengerFilterer) FilterDepositForBurn(opts *bind.FilterOpts, nonce []uint64, burnToken []common.Address, depositor []common.Address) (*TokenMessengerDepositForBurnIterator, error) {
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+	var burnTokenRule []interface{}
+	for _, burnTokenItem := range burnToken {
+		burnTokenRule = append(burnTokenRule, burnTokenItem)
+	}
+
+	var depositorRule []interface{}
+	for _, depositorItem := range depositor {
+		depositorRule = append(depositorRule, depositorItem)
+	}
+
+	logs, sub, err := _TokenMessenger.contract.FilterLogs(opts, "DepositForBurn", nonceRule, burnTokenRule, depositorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessengerDepositForBurnIterator{contract: _TokenMessenger.contract, event: "DepositForBurn", logs: logs, sub: sub}, nil
+}
+
+// WatchDepositForBurn is a free log subscription operation binding the contract event 0x2fa9ca894982930190727e75500a97d8dc500233a5065e0f3126c48fbe0343c0.
+//
+// Solidity: event DepositForBurn(uint64 indexed nonce, address indexed burnToken, uint256 amount, address indexed depositor, bytes32 mintRecipient, uint32 destinationDomain, bytes32 destinationTokenMessenger, bytes32 destinationCaller)
+func (_TokenMessenger *TokenMessengerFilterer) WatchDepositForBurn(opts *bind.WatchOpts, sink chan<- *TokenMessengerDepositForBurn, nonce []uint64, burnToken []common.Address, depositor []common.Address) (event.Subscription, error) {
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+	var burnTokenRule []interface{}
+	for _, burnTokenItem := range burnToken {
+		burnTokenRule = append(burnTokenRule, burnTokenItem)
+	}
+
+	var depositorRule []interface{}
+	for _, depositorItem := range depositor {
+		depositorRule = append(depositorRule, depositorItem)
+	}
+
+	logs, sub, err := _TokenMessenger.contract.WatchLogs(opts, "DepositForBurn", nonceRule, burnTokenRule, depositorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenMessengerDepositForBurn)
+				if err := _TokenMessenger.contract.UnpackLog(event, "DepositForBurn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDepositForBurn is a log parse operation binding the contract event 0x2fa9ca894982930190727e75500a97d8dc500233a5065e0f3126c48fbe0343c0.
+//
+// Solidity: event DepositForBurn(uint64 indexed nonce, address indexed burnToken, uint256 amount, address indexed depositor, bytes32 mintRecipient, uint32 destinationDomain, bytes32 destinationTokenMessenger, bytes32 destinationCaller)
+func (_TokenMessenger *TokenMessengerFilterer) ParseDepositForBurn(log types.Log) (*TokenMessengerDepositForBurn, error) {
+	event := new(TokenMessengerDepositForBurn)
+	if err := _TokenMessenger.contract.UnpackLog(event, "DepositForBurn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenMessengerMintAndWithdrawIterator is returned from FilterMintAndWithdraw and is used to iterate over the raw logs and unpacked data for MintAndWithdraw events raised by the TokenMessenger contract.
+type TokenMessengerMintAndWithdrawIterator struct {
+	Event *TokenMessengerMintAndWithdraw // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenMessengerMintAndWithdrawIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenMessengerMintAndWithdraw)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenMessengerMintAndWithdraw)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenMessengerMintAndWithdrawIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenMessengerMintAndWithdrawIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenMessengerMintAndWithdraw represents a MintAndWithdraw event raised by the TokenMessenger contract.
+type TokenMessengerMintAndWithdraw struct {
+	MintRecipient common.Address
+	Amount        *big.Int
+	MintToken     common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterMintAndWithdraw is a free log retrieval operation binding the contract event 0x1b2a7ff080b8cb6ff436ce0372e399692bbfb6d4ae5766fd8d58a7b8cc6142e6.
+//
+// Solidity: event MintAndWithdraw(address indexed mintRecipient, uint256 amount, address indexed mintToken)
+func (_TokenMessenger *TokenMessengerFilterer) FilterMintAndWithdraw(opts *bind.FilterOpts, mintRecipient []common.Address, mintToken []common.Address) (*TokenMessengerMintAndWithdrawIterator, error) {
+
+	var mintRecipientRule []interface{}
+	for _, mintRecipientItem := range mintRecipient {
+		mintRecipientRule = append(mintRecipientRule, mintRecipientItem)
+	}
+
+	var mintTokenRule []interface{}
+	for _, mintTokenItem := range mintToken {
+		mintTokenRule = append(mintTokenRule, mintTokenItem)
+	}
+
+	logs, sub, err := _TokenMessenger.contract.FilterLogs(opts, "MintAndWithdraw", mintRecipientRule, mintTokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMessengerMintAndWithdrawIterator{contract: _TokenMessenger.contract, event: "MintAndWithdraw", logs: logs, sub: sub}, nil
+}
+
+// WatchMintAndWithdraw is a free log subscription operation binding the contract event 0x1b2a7ff080b8cb6ff436ce0372e399692bbfb6d4ae5766fd8d58a7b8cc6142e6.
+//
+// Solidity: event MintAndWithdraw(address indexed mintRecipient, uint256 amount, address indexed mintToken)
+func (_TokenMessenger *TokenMessengerFilterer) WatchMintAndWithdraw(opts *bind.WatchOpts, sink chan<- *TokenMessengerMintAndWithdraw, mintRecipient []common.Address, mintToken []common.Address) (event.Subscription, error) {
+
+	var mintRecipientRule []interface{}
+	for _, mintRecipientItem := range mintRecipient {
+		mintRecipientRule = append(mintRecipientRule, mintRecipientItem)
+	}
+
+	var mintTokenRule []interface{}
+	for _, mintTokenItem := range mintToken {
+		mintTokenRule = append(mintTokenRule, mintTokenItem)
+	}
+
+	logs, sub, err := _TokenMessenger.contract.WatchLogs(opts, "MintAndWithdraw", mintRecipientRule, mintTokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenMessengerMintAndWithdraw)
+				if err := _TokenMessenger.contract.UnpackLog(event, "MintAndWithdraw", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMintAndWithdraw is a log parse operation binding the contract event 0x1b2a7ff080b8cb6ff436ce0372e399692bbfb6d4ae5766fd8d58a7b8cc6142e6.
+//
+// Solidity: event MintAndWithdraw(address indexed mintRecipient, uint256 amount, address indexed mintToken)
+func (_TokenMessenger *TokenMessengerFilterer) ParseMintAndWithdraw(log types.Log) (*TokenMessengerMintAndWithdraw, error) {
+	event := new(TokenMessengerMintAndWithdraw)
+	if err := _TokenMessenger.contract.UnpackLog(event, "MintAndWithdraw", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
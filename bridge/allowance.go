@@ -0,0 +1,271 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/G7DAO/protocol/bindings/ArbitrumBridge"
+	"github.com/G7DAO/protocol/bindings/ERC20"
+	"github.com/G7DAO/protocol/bindings/ERC20Inbox"
+	"github.com/G7DAO/protocol/bindings/L1GatewayRouter"
+	"github.com/G7DAO/protocol/safe"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/spf13/cobra"
+)
+
+// ApprovalMode selects how the bridge flows approve the tokens that a transfer spends
+type ApprovalMode string
+
+const (
+	ExactApproval     ApprovalMode = "exact"     // Approve exactly the amount that the transfer spends
+	UnlimitedApproval ApprovalMode = "unlimited" // Approve the maximum amount, so that later transfers need no approval
+	SkipApproval      ApprovalMode = "none"      // Do not check allowances or send approvals
+)
+
+func ParseApprovalMode(raw string) (ApprovalMode, error) {
+	switch mode := ApprovalMode(strings.ToLower(raw)); mode {
+	case ExactApproval, UnlimitedApproval, SkipApproval:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid approval mode %q (expected exact, unlimited or none)", raw)
+	}
+}
+
+// Returns the amount to approve for a transfer that spends the given amount.
+func (m ApprovalMode) Amount(required *big.Int) *big.Int {
+	if m == UnlimitedApproval {
+		return new(big.Int).Set(math.MaxBig256)
+	}
+	return new(big.Int).Set(required)
+}
+
+// TokenApproval is an allowance that a transfer needs: Spender must be allowed to spend Amount of Token on behalf of
+// the sender.
+type TokenApproval struct {
+	Token   common.Address
+	Spender common.Address
+	Amount  *big.Int
+}
+
+// Returns the approvals that the owner's current allowances do not cover. Approvals of the same token to the same
+// spender are added up, and approvals of a zero amount are dropped.
+func GetMissingApprovals(ctx context.Context, client Backend, owner common.Address, approvals []TokenApproval) ([]TokenApproval, error) {
+	var merged []TokenApproval
+	for _, approval := range approvals {
+		if approval.Amount == nil || approval.Amount.Sign() == 0 {
+			continue
+		}
+
+		found := false
+		for i := range merged {
+			if merged[i].Token == approval.Token && merged[i].Spender == approval.Spender {
+				merged[i].Amount.Add(merged[i].Amount, approval.Amount)
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, TokenApproval{Token: approval.Token, Spender: approval.Spender, Amount: new(big.Int).Set(approval.Amount)})
+		}
+	}
+
+	var missing []TokenApproval
+	for _, approval := range merged {
+		token, tokenErr := ERC20.NewERC20(approval.Token, client)
+		if tokenErr != nil {
+			return nil, tokenErr
+		}
+
+		allowance, allowanceErr := token.Allowance(&bind.CallOpts{Context: ctx}, owner, approval.Spender)
+		if allowanceErr != nil {
+			return nil, fmt.Errorf("could not read allowance of %s for %s on token %s: %w", owner.Hex(), approval.Spender.Hex(), approval.Token.Hex(), allowanceErr)
+		}
+
+		if allowance.Cmp(approval.Amount) < 0 {
+			missing = append(missing, approval)
+		}
+	}
+
+	return missing, nil
+}
+
+func GetApproveCalldata(spender common.Address, amount *big.Int) ([]byte, error) {
+	erc20Abi, erc20AbiErr := abi.JSON(strings.NewReader(ERC20.ERC20ABI))
+	if erc20AbiErr != nil {
+		return nil, erc20AbiErr
+	}
+
+	// function approve(address spender, uint256 amount) external returns (bool)
+	return erc20Abi.Pack("approve", spender, amount)
+}
+
+// Returns the approve calls for the given approvals, as MultiSend calls.
+func GetApprovalCalls(approvals []TokenApproval, mode ApprovalMode) ([]safe.MultiSendCall, error) {
+	calls := make([]safe.MultiSendCall, len(approvals))
+	for i, approval := range approvals {
+		data, dataErr := GetApproveCalldata(approval.Spender, mode.Amount(approval.Amount))
+		if dataErr != nil {
+			return nil, dataErr
+		}
+		calls[i] = safe.MultiSendCall{To: approval.Token, Value: big.NewInt(0), Data: data}
+	}
+	return calls, nil
+}
+
+// Sends the approvals that the sender's allowances do not cover yet, one transaction each, and waits for them to be
+// mined so that the transfer can be estimated afterwards.
+func EnsureApprovals(ctx context.Context, client Backend, sender signer.Signer, approvals []TokenApproval, mode ApprovalMode) error {
+	if mode == SkipApproval {
+		return nil
+	}
+
+	missing, missingErr := GetMissingApprovals(ctx, client, sender.Address(), approvals)
+	if missingErr != nil {
+		return missingErr
+	}
+
+	calls, callsErr := GetApprovalCalls(missing, mode)
+	if callsErr != nil {
+		return callsErr
+	}
+
+	for i, call := range calls {
+		fmt.Println("Approving", missing[i].Spender.Hex(), "to spend", mode.Amount(missing[i].Amount).String(), "of", missing[i].Token.Hex())
+		transaction, transactionErr := SendTransaction(ctx, client, sender, call.Data, call.To.Hex(), call.Value)
+		if transactionErr != nil {
+			return transactionErr
+		}
+		fmt.Println("Approval sent! Transaction hash:", transaction.Hash().Hex())
+
+		receipt, receiptErr := WaitForTransaction(ctx, client, transaction, "approval")
+		if receiptErr != nil {
+			return receiptErr
+		}
+		if receipt.Status != 1 {
+			return fmt.Errorf("approval transaction %s reverted", transaction.Hash().Hex())
+		}
+	}
+
+	return nil
+}
+
+// Creates a Safe proposal for the given call. If the Safe's allowances do not cover the approvals, the approve calls
+// and the call are batched into a single proposal that delegate calls MultiSendCallOnly.
+func CreateSafeProposalWithApprovals(ctx context.Context, client Backend, sender signer.Signer, safeAddress common.Address, approvals []TokenApproval, mode ApprovalMode, multiSendAddress common.Address, to common.Address, data []byte, value *big.Int, safeClient *safe.Client, safeOut string, safeForce bool, safeOperation safe.OperationType, safeNonce *big.Int) error {
+	if mode == SkipApproval {
+		return safe.CreateProposal(ctx, client, sender, safeClient, safeOut, safeForce, safeAddress, to, data, value, safeOperation, safeNonce)
+	}
+
+	missing, missingErr := GetMissingApprovals(ctx, client, safeAddress, approvals)
+	if missingErr != nil {
+		return missingErr
+	}
+	if len(missing) == 0 {
+		return safe.CreateProposal(ctx, client, sender, safeClient, safeOut, safeForce, safeAddress, to, data, value, safeOperation, safeNonce)
+	}
+
+	if safeOperation != safe.Call {
+		return fmt.Errorf("cannot batch approvals with a %s operation", safeOperation.String())
+	}
+
+	calls, callsErr := GetApprovalCalls(missing, mode)
+	if callsErr != nil {
+		return callsErr
+	}
+	calls = append(calls, safe.MultiSendCall{To: to, Value: value, Data: data})
+
+	fmt.Println("Batching", len(missing), "approvals with the transfer through MultiSendCallOnly at", multiSendAddress.Hex())
+	return safe.CreateBatchProposal(ctx, client, sender, safeClient, safeOut, safeForce, safeAddress, multiSendAddress, calls, safeNonce)
+}
+
+// Returns the L1 address of the native token of the chain that the given ERC20Inbox delivers messages to.
+func GetInboxNativeToken(ctx context.Context, client Backend, inboxAddress common.Address) (common.Address, error) {
+	inbox, inboxErr := ERC20Inbox.NewERC20Inbox(inboxAddress, client)
+	if inboxErr != nil {
+		return common.Address{}, inboxErr
+	}
+
+	bridgeAddress, bridgeAddressErr := inbox.Bridge(&bind.CallOpts{Context: ctx})
+	if bridgeAddressErr != nil {
+		return common.Address{}, bridgeAddressErr
+	}
+
+	bridge, bridgeErr := ArbitrumBridge.NewBridge(bridgeAddress, client)
+	if bridgeErr != nil {
+		return common.Address{}, bridgeErr
+	}
+
+	return bridge.NativeToken(&bind.CallOpts{Context: ctx})
+}
+
+// Returns the allowance that a native token deposit through an ERC20Inbox needs: the inbox pulls the fee and call
+// value from the sender in the chain's native token.
+func GetNativeTokenBridgeApprovals(ctx context.Context, client Backend, inboxAddress common.Address, tokenTotalFeeAmount *big.Int) ([]TokenApproval, error) {
+	nativeToken, nativeTokenErr := GetInboxNativeToken(ctx, client, inboxAddress)
+	if nativeTokenErr != nil {
+		return nil, nativeTokenErr
+	}
+
+	return []TokenApproval{{Token: nativeToken, Spender: inboxAddress, Amount: tokenTotalFeeAmount}}, nil
+}
+
+// Returns the allowances that an ERC20 deposit through the gateway router needs: the token's gateway pulls the token
+// and, on chains with a custom native token, the retryable ticket fees in the native token.
+func GetERC20BridgeApprovals(ctx context.Context, client Backend, routerAddress common.Address, tokenAddress common.Address, amount *big.Int, tokenTotalFeeAmount *big.Int, customNativeToken bool) ([]TokenApproval, error) {
+	router, routerErr := L1GatewayRouter.NewL1GatewayRouter(routerAddress, client)
+	if routerErr != nil {
+		return nil, routerErr
+	}
+
+	gatewayAddress, gatewayAddressErr := router.GetGateway(&bind.CallOpts{Context: ctx}, tokenAddress)
+	if gatewayAddressErr != nil {
+		return nil, gatewayAddressErr
+	}
+
+	approvals := []TokenApproval{{Token: tokenAddress, Spender: gatewayAddress, Amount: amount}}
+
+	if customNativeToken {
+		inboxAddress, inboxAddressErr := router.Inbox(&bind.CallOpts{Context: ctx})
+		if inboxAddressErr != nil {
+			return nil, inboxAddressErr
+		}
+
+		nativeToken, nativeTokenErr := GetInboxNativeToken(ctx, client, inboxAddress)
+		if nativeTokenErr != nil {
+			return nil, nativeTokenErr
+		}
+
+		approvals = append(approvals, TokenApproval{Token: nativeToken, Spender: gatewayAddress, Amount: tokenTotalFeeAmount})
+	}
+
+	return approvals, nil
+}
+
+// Returns the allowances that a teleport needs: the teleporter pulls the amount of the L1 token and, when teleporting
+// a non-fee token to a custom fee L3, the fee token for the L3 retryable ticket.
+func GetTeleportApprovals(teleporterAddress common.Address, teleportParams *TeleportParams, teleportationType TeleportationType, requiredFeeToken *big.Int) []TokenApproval {
+	approvals := []TokenApproval{{Token: teleportParams.L1Token, Spender: teleporterAddress, Amount: teleportParams.Amount}}
+	if teleportationType == NonFeeTokenToCustomFee {
+		approvals = append(approvals, TokenApproval{Token: teleportParams.L3FeeTokenL1Addr, Spender: teleporterAddress, Amount: requiredFeeToken})
+	}
+	return approvals
+}
+
+// Returns the allowance that a CCTP burn needs: the TokenMessenger pulls the amount of USDC it burns.
+func GetCCTPApprovals(tokenMessengerAddress common.Address, burnToken common.Address, amount *big.Int) []TokenApproval {
+	return []TokenApproval{{Token: burnToken, Spender: tokenMessengerAddress, Amount: amount}}
+}
+
+// Adds the --approval flag, and the --safe-multisend flag for commands that can propose to a Safe.
+func addApprovalFlags(cmd *cobra.Command, approval *string, multiSend *string) {
+	cmd.Flags().StringVar(approval, "approval", string(ExactApproval), "How to approve the tokens the transfer spends when the allowance is too low: exact, unlimited or none")
+	if multiSend != nil {
+		cmd.Flags().StringVar(multiSend, "safe-multisend", safe.MultiSendCallOnlyAddress.Hex(), "MultiSendCallOnly address used to batch approvals into the Safe proposal")
+	}
+}
@@ -0,0 +1,461 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/G7DAO/protocol/bindings/L1Teleporter"
+	"github.com/G7DAO/protocol/safe"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var ErrNoSigner = errors.New("bridger has no signer to sign transactions with")
+var ErrNoL3Client = errors.New("bridger has no L3 client")
+var ErrNoBridgeAddress = errors.New("the bridge of every child chain is required to follow messages delivered to it")
+
+// Returned when teleporting the L3 fee token itself and the amount does not cover the fee token that the L3 retryable
+// ticket needs.
+type InsufficientTeleportAmountError struct {
+	Amount           *big.Int
+	RequiredFeeToken *big.Int
+}
+
+func (e *InsufficientTeleportAmountError) Error() string {
+	return fmt.Sprintf("teleport amount %s is less than the %s fee token required by the L3 retryable ticket", e.Amount.String(), e.RequiredFeeToken.String())
+}
+
+var _ Backend = (*ethclient.Client)(nil)
+
+// Backend is the access to a chain that the bridge needs. *ethclient.Client implements it, and so do the clients of
+// go-ethereum's simulated backends (backends.SimulatedBackend and simulated.Backend.Client()).
+type Backend interface {
+	bind.ContractBackend
+	ethereum.BlockNumberReader
+	ethereum.ChainIDReader
+	ethereum.TransactionReader
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// Bridger moves funds between L1, L2 and L3, reusing the same clients for every call instead of dialing the RPCs each
+// time. L3 is only needed to teleport and to follow transfers on to L3, and Signer is only needed to send transactions:
+// a Bridger without a signer can still quote transfers and report their status.
+type Bridger struct {
+	L1     Backend
+	L2     Backend
+	L3     Backend
+	Signer signer.Signer
+}
+
+func NewBridger(l1 Backend, l2 Backend, l3 Backend, sender signer.Signer) *Bridger {
+	return &Bridger{L1: l1, L2: l2, L3: l3, Signer: sender}
+}
+
+// Dials the given RPCs and returns a Bridger using them. The L3 RPC is optional.
+func DialBridger(ctx context.Context, l1Rpc string, l2Rpc string, l3Rpc string, sender signer.Signer) (*Bridger, error) {
+	l1Client, l1ClientErr := ethclient.DialContext(ctx, l1Rpc)
+	if l1ClientErr != nil {
+		return nil, l1ClientErr
+	}
+
+	l2Client, l2ClientErr := ethclient.DialContext(ctx, l2Rpc)
+	if l2ClientErr != nil {
+		return nil, l2ClientErr
+	}
+
+	bridger := NewBridger(l1Client, l2Client, nil, sender)
+	if l3Rpc != "" {
+		l3Client, l3ClientErr := ethclient.DialContext(ctx, l3Rpc)
+		if l3ClientErr != nil {
+			return nil, l3ClientErr
+		}
+		bridger.L3 = l3Client
+	}
+
+	return bridger, nil
+}
+
+func (b *Bridger) requireSigner() (signer.Signer, error) {
+	if b.Signer == nil {
+		return nil, ErrNoSigner
+	}
+	return b.Signer, nil
+}
+
+// Sends the given transaction on L1 and waits for it to be mined.
+func (b *Bridger) sendL1Transaction(ctx context.Context, sender signer.Signer, data []byte, to common.Address, value *big.Int, leg string) (*types.Transaction, error) {
+	fmt.Println("Sending transaction...")
+	transaction, transactionErr := SendTransaction(ctx, b.L1, sender, data, to.Hex(), value)
+	if transactionErr != nil {
+		fmt.Fprintln(os.Stderr, transactionErr.Error())
+		return nil, transactionErr
+	}
+	fmt.Println("Transaction sent! Transaction hash:", transaction.Hash().Hex())
+
+	fmt.Println("Waiting for transaction to be mined...")
+	_, receiptErr := WaitForTransaction(ctx, b.L1, transaction, leg)
+	if receiptErr != nil {
+		fmt.Fprintln(os.Stderr, receiptErr.Error())
+		return nil, receiptErr
+	}
+	fmt.Println("Transaction mined!")
+
+	return transaction, nil
+}
+
+// Deposits the native token of a custom fee token L2 through its ERC20Inbox, approving the inbox first if needed.
+func (b *Bridger) DepositNativeToken(ctx context.Context, inboxAddress common.Address, to common.Address, l2CallValue *big.Int, l2Calldata []byte, approvalMode ApprovalMode) (*types.Transaction, error) {
+	sender, senderErr := b.requireSigner()
+	if senderErr != nil {
+		return nil, senderErr
+	}
+
+	createRetryableTicketData, tokenTotalFeeAmount, createRetryableTicketDataErr := GetNativeTokenBridgeCalldata(ctx, sender.Address(), b.L1, b.L2, to, l2CallValue, l2Calldata)
+	if createRetryableTicketDataErr != nil {
+		return nil, createRetryableTicketDataErr
+	}
+
+	approvals, approvalsErr := GetNativeTokenBridgeApprovals(ctx, b.L1, inboxAddress, tokenTotalFeeAmount)
+	if approvalsErr != nil {
+		return nil, approvalsErr
+	}
+
+	approveErr := EnsureApprovals(ctx, b.L1, sender, approvals, approvalMode)
+	if approveErr != nil {
+		fmt.Fprintln(os.Stderr, approveErr.Error())
+		return nil, approveErr
+	}
+
+	return b.sendL1Transaction(ctx, sender, createRetryableTicketData, inboxAddress, big.NewInt(0), "deposit")
+}
+
+// Proposes a native token deposit to the given Safe, batching the approval of the inbox into the proposal if needed.
+func (b *Bridger) ProposeNativeTokenDeposit(ctx context.Context, inboxAddress common.Address, to common.Address, l2CallValue *big.Int, l2Calldata []byte, safeAddress common.Address, safeClient *safe.Client, safeOut string, safeForce bool, safeOperation safe.OperationType, safeNonce *big.Int, approvalMode ApprovalMode, multiSendAddress common.Address) error {
+	sender, senderErr := b.requireSigner()
+	if senderErr != nil {
+		return senderErr
+	}
+
+	createRetryableTicketData, tokenTotalFeeAmount, createRetryableTicketDataErr := GetNativeTokenBridgeCalldata(ctx, sender.Address(), b.L1, b.L2, to, l2CallValue, l2Calldata)
+	if createRetryableTicketDataErr != nil {
+		return createRetryableTicketDataErr
+	}
+
+	approvals, approvalsErr := GetNativeTokenBridgeApprovals(ctx, b.L1, inboxAddress, tokenTotalFeeAmount)
+	if approvalsErr != nil {
+		return approvalsErr
+	}
+
+	return CreateSafeProposalWithApprovals(ctx, b.L1, sender, safeAddress, approvals, approvalMode, multiSendAddress, inboxAddress, createRetryableTicketData, big.NewInt(0), safeClient, safeOut, safeForce, safeOperation, safeNonce)
+}
+
+// Deposits an ERC20 token to L2 through the L1 gateway router, approving the token's gateway first if needed.
+func (b *Bridger) DepositERC20(ctx context.Context, routerAddress common.Address, tokenAddress common.Address, to common.Address, amount *big.Int, customNativeToken bool, approvalMode ApprovalMode) (*types.Transaction, error) {
+	sender, senderErr := b.requireSigner()
+	if senderErr != nil {
+		return nil, senderErr
+	}
+
+	callData, tokenTotalFeeAmount, callDataErr := GetERC20BridgeCalldataAndValue(ctx, routerAddress, sender.Address(), b.L1, b.L2, tokenAddress, to, amount)
+	if callDataErr != nil {
+		fmt.Fprintln(os.Stderr, "callDataErr", callDataErr.Error())
+		return nil, callDataErr
+	}
+
+	approvals, approvalsErr := GetERC20BridgeApprovals(ctx, b.L1, routerAddress, tokenAddress, amount, tokenTotalFeeAmount, customNativeToken)
+	if approvalsErr != nil {
+		fmt.Fprintln(os.Stderr, "approvalsErr", approvalsErr.Error())
+		return nil, approvalsErr
+	}
+
+	approveErr := EnsureApprovals(ctx, b.L1, sender, approvals, approvalMode)
+	if approveErr != nil {
+		fmt.Fprintln(os.Stderr, "approveErr", approveErr.Error())
+		return nil, approveErr
+	}
+
+	if customNativeToken {
+		tokenTotalFeeAmount = big.NewInt(0)
+	}
+	return b.sendL1Transaction(ctx, sender, callData, routerAddress, tokenTotalFeeAmount, "deposit")
+}
+
+// Proposes an ERC20 deposit to the given Safe, batching the approvals of the token's gateway into the proposal if
+// needed.
+func (b *Bridger) ProposeERC20Deposit(ctx context.Context, routerAddress common.Address, tokenAddress common.Address, to common.Address, amount *big.Int, customNativeToken bool, safeAddress common.Address, safeClient *safe.Client, safeOut string, safeForce bool, safeOperation safe.OperationType, safeNonce *big.Int, approvalMode ApprovalMode, multiSendAddress common.Address) error {
+	sender, senderErr := b.requireSigner()
+	if senderErr != nil {
+		return senderErr
+	}
+
+	callData, tokenTotalFeeAmount, callDataErr := GetERC20BridgeCalldataAndValue(ctx, routerAddress, sender.Address(), b.L1, b.L2, tokenAddress, to, amount)
+	if callDataErr != nil {
+		fmt.Fprintln(os.Stderr, "callDataErr", callDataErr.Error())
+		return callDataErr
+	}
+
+	approvals, approvalsErr := GetERC20BridgeApprovals(ctx, b.L1, routerAddress, tokenAddress, amount, tokenTotalFeeAmount, customNativeToken)
+	if approvalsErr != nil {
+		fmt.Fprintln(os.Stderr, "approvalsErr", approvalsErr.Error())
+		return approvalsErr
+	}
+
+	if customNativeToken {
+		tokenTotalFeeAmount = big.NewInt(0)
+	}
+
+	return CreateSafeProposalWithApprovals(ctx, b.L1, sender, safeAddress, approvals, approvalMode, multiSendAddress, routerAddress, callData, tokenTotalFeeAmount, safeClient, safeOut, safeForce, safeOperation, safeNonce)
+}
+
+// Teleports tokens from L1 to L3 through the L1 teleporter. The gas parameters of the retryable tickets are estimated,
+// and the fee token that the L3 retryable ticket needs is added to the amount, on a copy of teleportParams: the
+// caller's parameters are left as they are, so they can be used again.
+func (b *Bridger) Teleport(ctx context.Context, teleporter common.Address, originalParams *TeleportParams, approvalMode ApprovalMode) (*types.Transaction, error) {
+	sender, senderErr := b.requireSigner()
+	if senderErr != nil {
+		return nil, senderErr
+	}
+	if b.L3 == nil {
+		return nil, ErrNoL3Client
+	}
+
+	params := *originalParams
+	params.Amount = new(big.Int)
+	if originalParams.Amount != nil {
+		params.Amount.Set(originalParams.Amount)
+	}
+	teleportParams := &params
+
+	teleportationType, teleportationTypeErr := GetTeleportationType(teleportParams.L1Token, teleportParams.L3FeeTokenL1Addr)
+	if teleportationTypeErr != nil {
+		return nil, teleportationTypeErr
+	}
+
+	teleportParams, teleportParamsErr := SetTeleporterGasParams(ctx, teleportParams, teleporter, b.L1, b.L2, b.L3, sender.Address(), teleportationType)
+	if teleportParamsErr != nil {
+		return nil, teleportParamsErr
+	}
+
+	requiredEth, requiredFeeToken := CalculateRequiredEth(teleportParams.GasParams, teleportationType)
+	if teleportationType == OnlyCustomFee && teleportParams.Amount.Cmp(requiredFeeToken) == -1 {
+		return nil, &InsufficientTeleportAmountError{Amount: new(big.Int).Set(teleportParams.Amount), RequiredFeeToken: requiredFeeToken}
+	}
+
+	teleportParams.Amount.Add(teleportParams.Amount, requiredFeeToken)
+
+	approveErr := EnsureApprovals(ctx, b.L1, sender, GetTeleportApprovals(teleporter, teleportParams, teleportationType, requiredFeeToken), approvalMode)
+	if approveErr != nil {
+		fmt.Fprintln(os.Stderr, approveErr.Error())
+		return nil, approveErr
+	}
+
+	teleporterAbi, teleporterAbiErr := abi.JSON(strings.NewReader(L1Teleporter.L1TeleporterABI))
+	if teleporterAbiErr != nil {
+		return nil, teleporterAbiErr
+	}
+
+	data, dataErr := teleporterAbi.Pack("teleport", teleportParams)
+	if dataErr != nil {
+		fmt.Fprintln(os.Stderr, dataErr.Error())
+		return nil, dataErr
+	}
+
+	transaction, transactionErr := SendTransaction(ctx, b.L1, sender, data, teleporter.String(), requiredEth)
+	if transactionErr != nil {
+		fmt.Fprintln(os.Stderr, transactionErr.Error())
+		return nil, transactionErr
+	}
+
+	return transaction, nil
+}
+
+// Same as the package level Quote, using the Bridger's clients: the RPC fields of params are ignored.
+func (b *Bridger) Quote(ctx context.Context, params *QuoteParams) (*BridgeQuote, error) {
+	switch params.Type {
+	case NativeTokenQuote:
+		return b.QuoteNativeTokenBridge(ctx, params.From, params.To, params.Amount, params.Calldata)
+	case ERC20Quote:
+		return b.QuoteERC20Bridge(ctx, params.Router, params.From, params.Token, params.To, params.Amount, params.CustomNativeToken)
+	case TeleportQuote:
+		return b.QuoteTeleport(ctx, params.Teleporter, params.TeleportParams, params.From)
+	default:
+		return nil, fmt.Errorf("unknown quote type: %s", params.Type)
+	}
+}
+
+// Quotes a native token deposit through the inbox of a custom fee token chain. The retryable ticket is paid for in the
+// fee token, and RequiredFeeToken includes the L2 call value.
+func (b *Bridger) QuoteNativeTokenBridge(ctx context.Context, from common.Address, to common.Address, l2CallValue *big.Int, l2Calldata []byte) (*BridgeQuote, error) {
+	gasLimit, maxSubmissionCost, maxFeePerGas, gasParamsErr := GetNativeTokenBridgeGasParams(ctx, from, b.L1, b.L2, to, l2CallValue, l2Calldata)
+	if gasParamsErr != nil {
+		return nil, gasParamsErr
+	}
+
+	leg := newRetryableQuote("l1l2Inbox", gasLimit, maxSubmissionCost, maxFeePerGas, PaidInFeeToken)
+
+	return &BridgeQuote{
+		Type:             NativeTokenQuote,
+		Legs:             []RetryableQuote{leg},
+		RequiredEth:      big.NewInt(0),
+		RequiredFeeToken: big.NewInt(0).Add(leg.TotalCost, l2CallValue),
+	}, nil
+}
+
+// Quotes an ERC20 deposit through the L1 gateway router. The retryable ticket is paid for in ETH, or in the fee token
+// when the child chain has a custom native token.
+func (b *Bridger) QuoteERC20Bridge(ctx context.Context, routerAddress common.Address, from common.Address, tokenAddress common.Address, to common.Address, amount *big.Int, customNativeToken bool) (*BridgeQuote, error) {
+	gasLimit, maxSubmissionCost, gasPriceBid, gasParamsErr := GetERC20BridgeGasParams(ctx, routerAddress, from, b.L1, b.L2, tokenAddress, to, amount)
+	if gasParamsErr != nil {
+		return nil, gasParamsErr
+	}
+
+	quote := &BridgeQuote{
+		Type:             ERC20Quote,
+		RequiredEth:      big.NewInt(0),
+		RequiredFeeToken: big.NewInt(0),
+	}
+
+	if customNativeToken {
+		leg := newRetryableQuote("l1l2TokenBridge", gasLimit, maxSubmissionCost, gasPriceBid, PaidInFeeToken)
+		quote.Legs = []RetryableQuote{leg}
+		quote.RequiredFeeToken.Set(leg.TotalCost)
+	} else {
+		leg := newRetryableQuote("l1l2TokenBridge", gasLimit, maxSubmissionCost, gasPriceBid, PaidInEth)
+		quote.Legs = []RetryableQuote{leg}
+		quote.RequiredEth.Set(leg.TotalCost)
+	}
+
+	return quote, nil
+}
+
+// Quotes a teleport from L1 to L3. The teleport parameters are not modified. RequiredFeeToken is the fee token needed
+// on top of the amount, and is only non-zero when the L3 has a custom fee token.
+func (b *Bridger) QuoteTeleport(ctx context.Context, teleporterAddress common.Address, teleportParams *TeleportParams, from common.Address) (*BridgeQuote, error) {
+	if b.L3 == nil {
+		return nil, ErrNoL3Client
+	}
+
+	teleportationType, teleportationTypeErr := GetTeleportationType(teleportParams.L1Token, teleportParams.L3FeeTokenL1Addr)
+	if teleportationTypeErr != nil {
+		return nil, teleportationTypeErr
+	}
+
+	quoteParams := *teleportParams
+	quoteParams.Amount = big.NewInt(0).Set(teleportParams.Amount)
+
+	_, gasParamsErr := SetTeleporterGasParams(ctx, &quoteParams, teleporterAddress, b.L1, b.L2, b.L3, from, teleportationType)
+	if gasParamsErr != nil {
+		return nil, gasParamsErr
+	}
+	gasParams := quoteParams.GasParams
+
+	requiredEth, requiredFeeToken := CalculateRequiredEth(gasParams, teleportationType)
+
+	l2l3PaidIn := PaidInFeeToken
+	if teleportationType == Standard {
+		l2l3PaidIn = PaidInEth
+	}
+
+	legs := []RetryableQuote{
+		newRetryableQuote("l1l2TokenBridge", gasParams.L1l2TokenBridgeGasLimit, gasParams.L1l2TokenBridgeMaxSubmissionCost, gasParams.L2GasPriceBid, PaidInEth),
+		newRetryableQuote("l2ForwarderFactory", gasParams.L2ForwarderFactoryGasLimit, gasParams.L2ForwarderFactoryMaxSubmissionCost, gasParams.L2GasPriceBid, PaidInEth),
+		newRetryableQuote("l2l3TokenBridge", gasParams.L2l3TokenBridgeGasLimit, gasParams.L2l3TokenBridgeMaxSubmissionCost, gasParams.L3GasPriceBid, l2l3PaidIn),
+	}
+	if teleportationType == NonFeeTokenToCustomFee {
+		legs = append(legs, newRetryableQuote("l1l2FeeTokenBridge", gasParams.L1l2FeeTokenBridgeGasLimit, gasParams.L1l2FeeTokenBridgeMaxSubmissionCost, gasParams.L2GasPriceBid, PaidInEth))
+	}
+
+	return &BridgeQuote{
+		Type:              TeleportQuote,
+		TeleportationType: teleportationType.String(),
+		Legs:              legs,
+		RequiredEth:       requiredEth,
+		RequiredFeeToken:  requiredFeeToken,
+	}, nil
+}
+
+// Reports the status of every leg of the cross-chain transfer started by the given transaction. See TransferStatus.
+func (b *Bridger) Status(ctx context.Context, l2BridgeAddress common.Address, l3BridgeAddress common.Address, outboxAddress common.Address, txHash common.Hash, lookback uint64) ([]*TransferLeg, error) {
+	childClients := []Backend{b.L2}
+	bridgeAddresses := []common.Address{l2BridgeAddress}
+	if b.L3 != nil {
+		childClients = append(childClients, b.L3)
+		bridgeAddresses = append(bridgeAddresses, l3BridgeAddress)
+	}
+
+	receipt, receiptErr := b.L1.TransactionReceipt(ctx, txHash)
+	if receiptErr == nil {
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return nil, fmt.Errorf("transaction %s failed on L1", txHash.Hex())
+		}
+		for _, bridgeAddress := range bridgeAddresses {
+			if bridgeAddress == (common.Address{}) {
+				return nil, ErrNoBridgeAddress
+			}
+		}
+
+		legs, legsErr := getParentToChildTransferLegs(ctx, receipt, childClients, bridgeAddresses)
+		if legsErr != nil {
+			return nil, legsErr
+		}
+		if len(legs) == 0 {
+			return nil, ErrNoParentToChildMessages
+		}
+		return legs, nil
+	} else if !errors.Is(receiptErr, ethereum.NotFound) {
+		return nil, receiptErr
+	}
+
+	events, eventsErr := GetL2ToL1TxEvents(ctx, b.L2, txHash)
+	if eventsErr != nil {
+		return nil, eventsErr
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("no L2ToL1Tx event found in transaction %s", txHash.Hex())
+	}
+
+	l1ChainID, l1ChainIDErr := b.L1.ChainID(ctx)
+	if l1ChainIDErr != nil {
+		return nil, l1ChainIDErr
+	}
+
+	messages := make([]*OutboxMessage, len(events))
+	if outboxAddress != (common.Address{}) {
+		var messagesErr error
+		messages, messagesErr = getOutboxMessages(ctx, b.L1, b.L2, outboxAddress, events, lookback)
+		if messagesErr != nil {
+			return nil, messagesErr
+		}
+	} else {
+		for i, event := range events {
+			messages[i] = &OutboxMessage{Event: event}
+		}
+	}
+
+	legs := make([]*TransferLeg, len(events))
+	for i, message := range messages {
+		leg := &TransferLeg{
+			Kind:          WithdrawalLeg,
+			Status:        LegPending,
+			ChainID:       l1ChainID,
+			OutboxMessage: message,
+		}
+		if message.Executed {
+			leg.Status = LegRedeemed
+		} else if message.Confirmed {
+			leg.Status = LegConfirmed
+		}
+		legs[i] = leg
+	}
+
+	return legs, nil
+}
@@ -0,0 +1,254 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/G7DAO/protocol/bindings/ArbitrumL1OrbitCustomGateway"
+	"github.com/G7DAO/protocol/bindings/ArbitrumL1OrbitGatewayRouter"
+	"github.com/G7DAO/protocol/bindings/L1Teleporter"
+	"github.com/G7DAO/protocol/bindings/MockERC20"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+var _ Backend = (*backends.SimulatedBackend)(nil)
+
+// Starts a simulated chain in which the account of the returned signer holds 100 ETH.
+func newSimulatedChain(t *testing.T) (*simulated.Backend, *signer.LocalSigner) {
+	privateKey, privateKeyErr := crypto.GenerateKey()
+	if privateKeyErr != nil {
+		t.Fatal(privateKeyErr)
+	}
+	sender := signer.NewLocalSigner(privateKey)
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		sender.Address(): {Balance: big.NewInt(0).Mul(big.NewInt(100), ONE_ETHER)},
+	})
+	t.Cleanup(func() { backend.Close() })
+
+	return backend, sender
+}
+
+// fakeBackend serves chain data from memory, for the states that a simulated chain cannot reach: Arbitrum headers,
+// precompiles and receipts of other chains. Calls that it does not implement panic on the nil Backend.
+type fakeBackend struct {
+	Backend
+	chainID     *big.Int
+	latestBlock uint64
+	logs        []types.Log
+	headers     map[common.Hash]*types.Header
+	receipts    map[common.Hash]*types.Receipt
+	call        func(to common.Address, data []byte) ([]byte, error)
+	gasPrice    *big.Int
+	gas         uint64
+	queries     []ethereum.FilterQuery
+}
+
+func (b *fakeBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return b.chainID, nil
+}
+
+func (b *fakeBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	return b.latestBlock, nil
+}
+
+func (b *fakeBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	b.queries = append(b.queries, query)
+
+	logs := []types.Log{}
+	for _, log := range b.logs {
+		if log.BlockNumber < query.FromBlock.Uint64() || log.BlockNumber > query.ToBlock.Uint64() {
+			continue
+		}
+		if len(query.Addresses) > 0 && !slices.Contains(query.Addresses, log.Address) {
+			continue
+		}
+		matched := len(log.Topics) >= len(query.Topics)
+		for i := 0; matched && i < len(query.Topics); i++ {
+			matched = len(query.Topics[i]) == 0 || slices.Contains(query.Topics[i], log.Topics[i])
+		}
+		if matched {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func (b *fakeBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	header, ok := b.headers[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+func (b *fakeBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, ok := b.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (b *fakeBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return b.call(*call.To, call.Data)
+}
+
+func (b *fakeBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return b.gasPrice, nil
+}
+
+func (b *fakeBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return b.gas, nil
+}
+
+func TestBridgerWithoutSignerOrL3(t *testing.T) {
+	l1, sender := newSimulatedChain(t)
+	l2, _ := newSimulatedChain(t)
+	bridger := NewBridger(l1.Client(), l2.Client(), nil, nil)
+
+	_, depositErr := bridger.DepositNativeToken(context.Background(), common.Address{}, common.Address{}, big.NewInt(1), nil, ExactApproval)
+	if !errors.Is(depositErr, ErrNoSigner) {
+		t.Errorf("Expected ErrNoSigner, got %v", depositErr)
+	}
+
+	_, teleportErr := NewBridger(l1.Client(), l2.Client(), nil, sender).Teleport(context.Background(), common.Address{}, &TeleportParams{}, ExactApproval)
+	if !errors.Is(teleportErr, ErrNoL3Client) {
+		t.Errorf("Expected ErrNoL3Client, got %v", teleportErr)
+	}
+}
+
+func TestBridgerTeleportInsufficientAmount(t *testing.T) {
+	privateKey, privateKeyErr := crypto.GenerateKey()
+	if privateKeyErr != nil {
+		t.Fatal(privateKeyErr)
+	}
+	sender := signer.NewLocalSigner(privateKey)
+
+	teleporterAbi, teleporterAbiErr := L1Teleporter.L1TeleporterMetaData.GetAbi()
+	if teleporterAbiErr != nil {
+		t.Fatal(teleporterAbiErr)
+	}
+	routerAbi, routerAbiErr := ArbitrumL1OrbitGatewayRouter.L1OrbitGatewayRouterMetaData.GetAbi()
+	if routerAbiErr != nil {
+		t.Fatal(routerAbiErr)
+	}
+	gatewayAbi, gatewayAbiErr := ArbitrumL1OrbitCustomGateway.L1OrbitCustomGatewayMetaData.GetAbi()
+	if gatewayAbiErr != nil {
+		t.Fatal(gatewayAbiErr)
+	}
+
+	// The teleporter, router and gateway calls that estimating the gas parameters of a teleport makes on L1.
+	l1 := &fakeBackend{
+		gasPrice: big.NewInt(10_000_000_000),
+		call: func(to common.Address, data []byte) ([]byte, error) {
+			for _, contractAbi := range []*abi.ABI{teleporterAbi, routerAbi, gatewayAbi} {
+				method, methodErr := contractAbi.MethodById(data[:4])
+				if methodErr != nil {
+					continue
+				}
+				switch method.Name {
+				case "l2ForwarderAddress", "getGateway", "counterpartGateway":
+					return method.Outputs.Pack(common.HexToAddress("0xf0"))
+				case "getOutboundCalldata":
+					return method.Outputs.Pack([]byte{0xca, 0xfe})
+				}
+			}
+			return nil, fmt.Errorf("unexpected call to %s", to.Hex())
+		},
+	}
+	l2 := &fakeBackend{gasPrice: big.NewInt(100_000_000), gas: 100_000}
+	l3 := &fakeBackend{gasPrice: big.NewInt(100_000_000), gas: 100_000}
+
+	feeToken := common.HexToAddress("0xfee")
+	params := &TeleportParams{L1Token: feeToken, L3FeeTokenL1Addr: feeToken, To: sender.Address(), Amount: big.NewInt(1)}
+
+	_, teleportErr := NewBridger(l1, l2, l3, sender).Teleport(context.Background(), common.HexToAddress("0x7e"), params, ExactApproval)
+	var insufficientErr *InsufficientTeleportAmountError
+	if !errors.As(teleportErr, &insufficientErr) {
+		t.Fatalf("Expected an InsufficientTeleportAmountError, got %v", teleportErr)
+	}
+	if insufficientErr.Amount.Cmp(big.NewInt(1)) != 0 || insufficientErr.RequiredFeeToken.Cmp(big.NewInt(1)) <= 0 {
+		t.Errorf("Unexpected amounts in the error: %s", insufficientErr.Error())
+	}
+
+	// The fee token is added to a copy of the parameters, so the caller's can be used again.
+	if params.Amount.Cmp(big.NewInt(1)) != 0 || params.GasParams.L3GasPriceBid != nil || params.GasParams.L2l3TokenBridgeGasLimit != 0 {
+		t.Errorf("Expected the teleport parameters to be left as they are, got %+v", params)
+	}
+}
+
+func TestBridgerStatusWithoutMessages(t *testing.T) {
+	ctx := context.Background()
+	l1, sender := newSimulatedChain(t)
+	l2, _ := newSimulatedChain(t)
+	bridger := NewBridger(l1.Client(), l2.Client(), nil, sender)
+
+	transaction, transactionErr := SendTransaction(ctx, bridger.L1, sender, nil, common.HexToAddress("0x1").Hex(), big.NewInt(1))
+	if transactionErr != nil {
+		t.Fatal(transactionErr)
+	}
+	l1.Commit()
+
+	_, statusErr := bridger.Status(ctx, common.HexToAddress("0xb1"), common.Address{}, common.Address{}, transaction.Hash(), 0)
+	if !errors.Is(statusErr, ErrNoParentToChildMessages) {
+		t.Errorf("Expected ErrNoParentToChildMessages, got %v", statusErr)
+	}
+}
+
+func TestGetMissingApprovalsOnSimulatedBackend(t *testing.T) {
+	ctx := context.Background()
+	l1, sender := newSimulatedChain(t)
+	client := l1.Client()
+
+	chainID, chainIDErr := client.ChainID(ctx)
+	if chainIDErr != nil {
+		t.Fatal(chainIDErr)
+	}
+	auth := signer.NewTransactOpts(ctx, sender, chainID)
+
+	tokenAddress, _, token, deployErr := MockERC20.DeployMockERC20(auth, client)
+	if deployErr != nil {
+		t.Fatal(deployErr)
+	}
+	l1.Commit()
+
+	spender := common.HexToAddress("0x2")
+	approvals := []TokenApproval{
+		{Token: tokenAddress, Spender: spender, Amount: big.NewInt(60)},
+		{Token: tokenAddress, Spender: spender, Amount: big.NewInt(40)},
+		{Token: tokenAddress, Spender: common.HexToAddress("0x3"), Amount: big.NewInt(0)},
+	}
+
+	missing, missingErr := GetMissingApprovals(ctx, client, sender.Address(), approvals)
+	if missingErr != nil {
+		t.Fatal(missingErr)
+	}
+	if len(missing) != 1 || missing[0].Spender != spender || missing[0].Amount.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("Expected a single missing approval of 100 for %s, got %+v", spender.Hex(), missing)
+	}
+
+	if _, approveErr := token.Approve(auth, spender, big.NewInt(100)); approveErr != nil {
+		t.Fatal(approveErr)
+	}
+	l1.Commit()
+
+	missing, missingErr = GetMissingApprovals(ctx, client, sender.Address(), approvals)
+	if missingErr != nil {
+		t.Fatal(missingErr)
+	}
+	if len(missing) != 0 {
+		t.Errorf("Expected no missing approvals, got %+v", missing)
+	}
+}
@@ -0,0 +1,334 @@
+package bridge
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/G7DAO/protocol/bindings/MessageTransmitter"
+	"github.com/G7DAO/protocol/bindings/TokenMessenger"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Circle's attestation service (Iris). The sandbox serves testnets.
+var CCTP_ATTESTATION_API = "https://iris-api.circle.com/v1"
+var CCTP_SANDBOX_ATTESTATION_API = "https://iris-api-sandbox.circle.com/v1"
+
+// CCTP domain identifiers. Source: https://developers.circle.com/stablecoins/supported-domains
+const (
+	CCTP_DOMAIN_ETHEREUM uint32 = 0
+	CCTP_DOMAIN_ARBITRUM uint32 = 3
+)
+
+var ErrCCTPAttestationTimeout = errors.New("timed out waiting for CCTP attestation")
+
+type CCTPAttestationStatus string
+
+const (
+	CCTPAttestationPendingConfirmations CCTPAttestationStatus = "pending_confirmations"
+	CCTPAttestationComplete             CCTPAttestationStatus = "complete"
+)
+
+// CCTPAttestation is the response of the attestation service for a message hash. Attestation is empty until the
+// status is complete.
+type CCTPAttestation struct {
+	Attestation string                `json:"attestation"`
+	Status      CCTPAttestationStatus `json:"status"`
+}
+
+func GetCCTPDepositForBurnCalldata(amount *big.Int, destinationDomain uint32, mintRecipient common.Address, burnToken common.Address) ([]byte, error) {
+	tokenMessengerAbi, tokenMessengerAbiErr := abi.JSON(strings.NewReader(TokenMessenger.TokenMessengerABI))
+	if tokenMessengerAbiErr != nil {
+		return nil, tokenMessengerAbiErr
+	}
+
+	// function depositForBurn(uint256 amount, uint32 destinationDomain, bytes32 mintRecipient, address burnToken) external returns (uint64 _nonce);
+	return tokenMessengerAbi.Pack("depositForBurn", amount, destinationDomain, common.BytesToHash(mintRecipient.Bytes()), burnToken)
+}
+
+func GetCCTPReceiveMessageCalldata(message []byte, attestation []byte) ([]byte, error) {
+	messageTransmitterAbi, messageTransmitterAbiErr := abi.JSON(strings.NewReader(MessageTransmitter.MessageTransmitterABI))
+	if messageTransmitterAbiErr != nil {
+		return nil, messageTransmitterAbiErr
+	}
+
+	// function receiveMessage(bytes calldata message, bytes calldata attestation) external returns (bool success);
+	return messageTransmitterAbi.Pack("receiveMessage", message, attestation)
+}
+
+// Returns the messages that the source MessageTransmitter emitted (MessageSent) in the given transaction. Events of
+// other contracts with the same signature are ignored.
+func GetCCTPMessages(ctx context.Context, client Backend, messageTransmitterAddress common.Address, txHash common.Hash) ([][]byte, error) {
+	receipt, receiptErr := client.TransactionReceipt(ctx, txHash)
+	if receiptErr != nil {
+		return nil, receiptErr
+	}
+
+	messageTransmitterAbi, messageTransmitterAbiErr := abi.JSON(strings.NewReader(MessageTransmitter.MessageTransmitterABI))
+	if messageTransmitterAbiErr != nil {
+		return nil, messageTransmitterAbiErr
+	}
+	messageSentTopic := messageTransmitterAbi.Events["MessageSent"].ID
+
+	messageTransmitterFilterer, messageTransmitterFiltererErr := MessageTransmitter.NewMessageTransmitterFilterer(common.Address{}, client)
+	if messageTransmitterFiltererErr != nil {
+		return nil, messageTransmitterFiltererErr
+	}
+
+	messages := [][]byte{}
+	for _, log := range receipt.Logs {
+		if log.Address != messageTransmitterAddress || len(log.Topics) == 0 || log.Topics[0] != messageSentTopic {
+			continue
+		}
+
+		event, eventErr := messageTransmitterFilterer.ParseMessageSent(*log)
+		if eventErr != nil {
+			return nil, eventErr
+		}
+		messages = append(messages, event.Message)
+	}
+
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("no CCTP MessageSent event of %s found in transaction %s", messageTransmitterAddress.Hex(), txHash.Hex())
+	}
+
+	return messages, nil
+}
+
+// Returns the key under which the destination MessageTransmitter records a message as used: keccak256 of its source
+// domain and nonce. A CCTP message starts with version (uint32), sourceDomain (uint32), destinationDomain (uint32) and
+// nonce (uint64).
+func GetCCTPMessageNonceKey(message []byte) (common.Hash, error) {
+	if len(message) < 20 {
+		return common.Hash{}, fmt.Errorf("CCTP message is too short (%d bytes)", len(message))
+	}
+
+	sourceDomain := binary.BigEndian.Uint32(message[4:8])
+	nonce := binary.BigEndian.Uint64(message[12:20])
+
+	packed := make([]byte, 12)
+	binary.BigEndian.PutUint32(packed[0:4], sourceDomain)
+	binary.BigEndian.PutUint64(packed[4:12], nonce)
+
+	return crypto.Keccak256Hash(packed), nil
+}
+
+// Checks whether the destination MessageTransmitter has already received the given message.
+func IsCCTPMessageReceived(ctx context.Context, client Backend, messageTransmitterAddress common.Address, message []byte) (bool, error) {
+	nonceKey, nonceKeyErr := GetCCTPMessageNonceKey(message)
+	if nonceKeyErr != nil {
+		return false, nonceKeyErr
+	}
+
+	messageTransmitter, messageTransmitterErr := MessageTransmitter.NewMessageTransmitter(messageTransmitterAddress, client)
+	if messageTransmitterErr != nil {
+		return false, messageTransmitterErr
+	}
+
+	used, usedErr := messageTransmitter.UsedNonces(&bind.CallOpts{Context: ctx}, nonceKey)
+	if usedErr != nil {
+		return false, usedErr
+	}
+
+	return used.Sign() != 0, nil
+}
+
+// Fetches the attestation for a message hash from the attestation service. The service answers 404 until it has seen
+// the message, which is reported as pending.
+func GetCCTPAttestation(ctx context.Context, attestationApi string, messageHash common.Hash) (*CCTPAttestation, error) {
+	request, requestErr := http.NewRequestWithContext(ctx, "GET", strings.TrimSuffix(attestationApi, "/")+"/attestations/"+messageHash.Hex(), nil)
+	if requestErr != nil {
+		return nil, requestErr
+	}
+	request.Header.Set("Accept", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	response, responseErr := client.Do(request)
+	if responseErr != nil {
+		return nil, responseErr
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return &CCTPAttestation{Status: CCTPAttestationPendingConfirmations}, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("attestation service returned status %d for message %s", response.StatusCode, messageHash.Hex())
+	}
+
+	var attestation CCTPAttestation
+	if decodeErr := json.NewDecoder(response.Body).Decode(&attestation); decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	return &attestation, nil
+}
+
+// Polls the attestation service every pollInterval until the attestation for the message hash is complete, or
+// returns ErrCCTPAttestationTimeout after timeout (0 waits indefinitely) or once the context is done.
+func WaitForCCTPAttestation(ctx context.Context, attestationApi string, messageHash common.Hash, pollInterval time.Duration, timeout time.Duration) ([]byte, error) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		attestation, attestationErr := GetCCTPAttestation(ctx, attestationApi, messageHash)
+		if attestationErr != nil {
+			return nil, attestationErr
+		}
+
+		if attestation.Status == CCTPAttestationComplete && attestation.Attestation != "" {
+			return hexutil.Decode(attestation.Attestation)
+		}
+
+		if !deadline.IsZero() && time.Now().Add(pollInterval).After(deadline) {
+			return nil, fmt.Errorf("%w: message %s", ErrCCTPAttestationTimeout, messageHash.Hex())
+		}
+
+		fmt.Println("Attestation for message", messageHash.Hex(), "is", string(attestation.Status)+", retrying in", pollInterval.String())
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: message %s: %w", ErrCCTPAttestationTimeout, messageHash.Hex(), ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Burns USDC on the source chain through the TokenMessenger, to be minted to mintRecipient on the destination domain.
+// The TokenMessenger is approved to spend amount of burnToken first if the sender's allowance does not cover it.
+func CCTPDepositForBurnCall(ctx context.Context, tokenMessengerAddress common.Address, sender signer.Signer, sourceRpc string, burnToken common.Address, amount *big.Int, destinationDomain uint32, mintRecipient common.Address, approvalMode ApprovalMode) (*types.Transaction, error) {
+	client, clientErr := ethclient.DialContext(ctx, sourceRpc)
+	if clientErr != nil {
+		return nil, clientErr
+	}
+
+	return CCTPDepositForBurn(ctx, client, tokenMessengerAddress, sender, burnToken, amount, destinationDomain, mintRecipient, approvalMode)
+}
+
+// Same as CCTPDepositForBurnCall, on an already connected source chain client.
+func CCTPDepositForBurn(ctx context.Context, client Backend, tokenMessengerAddress common.Address, sender signer.Signer, burnToken common.Address, amount *big.Int, destinationDomain uint32, mintRecipient common.Address, approvalMode ApprovalMode) (*types.Transaction, error) {
+	depositForBurnData, depositForBurnDataErr := GetCCTPDepositForBurnCalldata(amount, destinationDomain, mintRecipient, burnToken)
+	if depositForBurnDataErr != nil {
+		return nil, depositForBurnDataErr
+	}
+
+	approveErr := EnsureApprovals(ctx, client, sender, GetCCTPApprovals(tokenMessengerAddress, burnToken, amount), approvalMode)
+	if approveErr != nil {
+		fmt.Fprintln(os.Stderr, approveErr.Error())
+		return nil, approveErr
+	}
+
+	fmt.Println("Sending transaction...")
+	transaction, transactionErr := SendTransaction(ctx, client, sender, depositForBurnData, tokenMessengerAddress.Hex(), big.NewInt(0))
+	if transactionErr != nil {
+		fmt.Fprintln(os.Stderr, transactionErr.Error())
+		return nil, transactionErr
+	}
+	fmt.Println("Transaction sent! Transaction hash:", transaction.Hash().Hex())
+
+	fmt.Println("Waiting for transaction to be mined...")
+	receipt, receiptErr := WaitForTransaction(ctx, client, transaction, "CCTP burn")
+	if receiptErr != nil {
+		fmt.Fprintln(os.Stderr, receiptErr.Error())
+		return nil, receiptErr
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", transaction.Hash().Hex())
+	}
+	fmt.Println("Transaction mined!")
+
+	return transaction, nil
+}
+
+// Mints the burnt USDC on the destination chain by submitting the message and its attestation to the destination
+// MessageTransmitter.
+func CCTPReceiveMessage(ctx context.Context, client Backend, messageTransmitterAddress common.Address, sender signer.Signer, message []byte, attestation []byte) (*types.Transaction, error) {
+	receiveMessageData, receiveMessageDataErr := GetCCTPReceiveMessageCalldata(message, attestation)
+	if receiveMessageDataErr != nil {
+		return nil, receiveMessageDataErr
+	}
+
+	fmt.Println("Sending transaction...")
+	transaction, transactionErr := SendTransaction(ctx, client, sender, receiveMessageData, messageTransmitterAddress.Hex(), big.NewInt(0))
+	if transactionErr != nil {
+		fmt.Fprintln(os.Stderr, transactionErr.Error())
+		return nil, transactionErr
+	}
+	fmt.Println("Transaction sent! Transaction hash:", transaction.Hash().Hex())
+
+	fmt.Println("Waiting for transaction to be mined...")
+	receipt, receiptErr := WaitForTransaction(ctx, client, transaction, "CCTP mint")
+	if receiptErr != nil {
+		fmt.Fprintln(os.Stderr, receiptErr.Error())
+		return nil, receiptErr
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", transaction.Hash().Hex())
+	}
+	fmt.Println("Transaction mined!")
+
+	return transaction, nil
+}
+
+// Completes a CCTP transfer started by the given burn transaction: waits for the attestation of every message that the
+// source MessageTransmitter sent in it, and receives the messages that the destination MessageTransmitter has not
+// received yet.
+func CCTPCompleteTransfer(ctx context.Context, sourceMessageTransmitterAddress common.Address, messageTransmitterAddress common.Address, sender signer.Signer, sourceRpc string, destinationRpc string, burnTxHash common.Hash, attestationApi string, pollInterval time.Duration, timeout time.Duration) ([]*types.Transaction, error) {
+	sourceClient, sourceClientErr := ethclient.DialContext(ctx, sourceRpc)
+	if sourceClientErr != nil {
+		return nil, sourceClientErr
+	}
+
+	destinationClient, destinationClientErr := ethclient.DialContext(ctx, destinationRpc)
+	if destinationClientErr != nil {
+		return nil, destinationClientErr
+	}
+
+	messages, messagesErr := GetCCTPMessages(ctx, sourceClient, sourceMessageTransmitterAddress, burnTxHash)
+	if messagesErr != nil {
+		return nil, messagesErr
+	}
+
+	transactions := []*types.Transaction{}
+	for _, message := range messages {
+		messageHash := crypto.Keccak256Hash(message)
+
+		received, receivedErr := IsCCTPMessageReceived(ctx, destinationClient, messageTransmitterAddress, message)
+		if receivedErr != nil {
+			return nil, receivedErr
+		}
+		if received {
+			fmt.Println("Message", messageHash.Hex(), "has already been received")
+			continue
+		}
+
+		fmt.Println("Waiting for attestation of message", messageHash.Hex())
+		attestation, attestationErr := WaitForCCTPAttestation(ctx, attestationApi, messageHash, pollInterval, timeout)
+		if attestationErr != nil {
+			return nil, attestationErr
+		}
+
+		transaction, transactionErr := CCTPReceiveMessage(ctx, destinationClient, messageTransmitterAddress, sender, message, attestation)
+		if transactionErr != nil {
+			return nil, transactionErr
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,253 @@
+package bridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/G7DAO/protocol/bindings/MessageTransmitter"
+	"github.com/G7DAO/protocol/bindings/MockERC20"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Serves attestations for a single message hash: 404 for the first notFound requests, pending_confirmations for the
+// next pending requests, and complete afterwards.
+func newAttestationStub(t *testing.T, messageHash common.Hash, notFound int, pending int, attestation string) (*httptest.Server, *int) {
+	var mu sync.Mutex
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path != "/attestations/"+messageHash.Hex() {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+
+		requests++
+		switch {
+		case requests <= notFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Message hash not found"}`))
+		case requests <= notFound+pending:
+			w.Write([]byte(`{"attestation":null,"status":"pending_confirmations"}`))
+		default:
+			w.Write([]byte(`{"attestation":"` + attestation + `","status":"complete"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestGetCCTPAttestation(t *testing.T) {
+	messageHash := crypto.Keccak256Hash([]byte("message"))
+	server, _ := newAttestationStub(t, messageHash, 1, 1, "0xdeadbeef")
+
+	expected := []CCTPAttestation{
+		{Status: CCTPAttestationPendingConfirmations},
+		{Status: CCTPAttestationPendingConfirmations},
+		{Status: CCTPAttestationComplete, Attestation: "0xdeadbeef"},
+	}
+
+	for i, expectedAttestation := range expected {
+		attestation, attestationErr := GetCCTPAttestation(context.Background(), server.URL, messageHash)
+		if attestationErr != nil {
+			t.Fatalf("Request %d: Unexpected error: %s", i, attestationErr.Error())
+		}
+		if *attestation != expectedAttestation {
+			t.Fatalf("Request %d: Expected %+v, got %+v", i, expectedAttestation, *attestation)
+		}
+	}
+}
+
+func TestGetCCTPAttestationServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, attestationErr := GetCCTPAttestation(context.Background(), server.URL, common.Hash{})
+	if attestationErr == nil {
+		t.Fatal("Expected an error for a 500 response")
+	}
+}
+
+func TestWaitForCCTPAttestation(t *testing.T) {
+	messageHash := crypto.Keccak256Hash([]byte("message"))
+	server, requests := newAttestationStub(t, messageHash, 2, 2, "0xdeadbeef")
+
+	attestation, attestationErr := WaitForCCTPAttestation(context.Background(), server.URL+"/", messageHash, time.Millisecond, 0)
+	if attestationErr != nil {
+		t.Fatalf("Unexpected error: %s", attestationErr.Error())
+	}
+	if !bytes.Equal(attestation, []byte{0xde, 0xad, 0xbe, 0xef}) {
+		t.Fatalf("Expected attestation 0xdeadbeef, got %x", attestation)
+	}
+	if *requests != 5 {
+		t.Fatalf("Expected 5 requests, got %d", *requests)
+	}
+}
+
+func TestWaitForCCTPAttestationTimeout(t *testing.T) {
+	messageHash := crypto.Keccak256Hash([]byte("message"))
+	server, _ := newAttestationStub(t, messageHash, 0, 1_000_000, "0xdeadbeef")
+
+	_, attestationErr := WaitForCCTPAttestation(context.Background(), server.URL, messageHash, 5*time.Millisecond, 50*time.Millisecond)
+	if !errors.Is(attestationErr, ErrCCTPAttestationTimeout) {
+		t.Fatalf("Expected ErrCCTPAttestationTimeout, got %v", attestationErr)
+	}
+}
+
+func TestGetCCTPMessageNonceKey(t *testing.T) {
+	message := make([]byte, 116)
+	binary.BigEndian.PutUint32(message[0:4], 0)
+	binary.BigEndian.PutUint32(message[4:8], CCTP_DOMAIN_ARBITRUM)
+	binary.BigEndian.PutUint32(message[8:12], CCTP_DOMAIN_ETHEREUM)
+	binary.BigEndian.PutUint64(message[12:20], 42)
+
+	expected := crypto.Keccak256Hash([]byte{0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 42})
+
+	nonceKey, nonceKeyErr := GetCCTPMessageNonceKey(message)
+	if nonceKeyErr != nil {
+		t.Fatalf("Unexpected error: %s", nonceKeyErr.Error())
+	}
+	if nonceKey != expected {
+		t.Fatalf("Expected nonce key %s, got %s", expected.Hex(), nonceKey.Hex())
+	}
+
+	_, shortErr := GetCCTPMessageNonceKey(message[:19])
+	if shortErr == nil {
+		t.Fatal("Expected an error for a truncated message")
+	}
+}
+
+// Returns the MessageSent event that a MessageTransmitter emits for the message.
+func messageSentLog(t *testing.T, messageTransmitterAddress common.Address, message []byte) *types.Log {
+	messageTransmitterAbi, messageTransmitterAbiErr := MessageTransmitter.MessageTransmitterMetaData.GetAbi()
+	if messageTransmitterAbiErr != nil {
+		t.Fatal(messageTransmitterAbiErr)
+	}
+	event := messageTransmitterAbi.Events["MessageSent"]
+
+	data, dataErr := event.Inputs.NonIndexed().Pack(message)
+	if dataErr != nil {
+		t.Fatal(dataErr)
+	}
+
+	return &types.Log{Address: messageTransmitterAddress, Topics: []common.Hash{event.ID}, Data: data}
+}
+
+func TestGetCCTPMessages(t *testing.T) {
+	ctx := context.Background()
+	messageTransmitterAddress := common.HexToAddress("0x0a992d191DEeC32aFe36203Ad87D7d289a738F81")
+	burnTx := common.HexToHash("0x01")
+	otherTx := common.HexToHash("0x02")
+
+	source := &fakeBackend{
+		receipts: map[common.Hash]*types.Receipt{
+			burnTx: {Logs: []*types.Log{
+				messageSentLog(t, messageTransmitterAddress, []byte{0x01}),
+				// Another contract emitting an event with the same signature does not send CCTP messages.
+				messageSentLog(t, common.HexToAddress("0x1"), []byte{0x02}),
+				messageSentLog(t, messageTransmitterAddress, []byte{0x03}),
+			}},
+			otherTx: {Logs: []*types.Log{messageSentLog(t, common.HexToAddress("0x1"), []byte{0x02})}},
+		},
+	}
+
+	messages, messagesErr := GetCCTPMessages(ctx, source, messageTransmitterAddress, burnTx)
+	if messagesErr != nil {
+		t.Fatal(messagesErr)
+	}
+	if len(messages) != 2 || !bytes.Equal(messages[0], []byte{0x01}) || !bytes.Equal(messages[1], []byte{0x03}) {
+		t.Errorf("Expected the messages of the MessageTransmitter, got %x", messages)
+	}
+
+	if _, messagesErr := GetCCTPMessages(ctx, source, messageTransmitterAddress, otherTx); messagesErr == nil {
+		t.Error("Expected an error for a transaction without messages of the MessageTransmitter")
+	}
+}
+
+func TestCCTPDepositForBurnApprovesTokenMessenger(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	source, sender := newSimulatedChain(t)
+	client := source.Client()
+
+	chainID, chainIDErr := client.ChainID(ctx)
+	if chainIDErr != nil {
+		t.Fatal(chainIDErr)
+	}
+	auth := signer.NewTransactOpts(ctx, sender, chainID)
+
+	usdcAddress, _, usdc, deployErr := MockERC20.DeployMockERC20(auth, client)
+	if deployErr != nil {
+		t.Fatal(deployErr)
+	}
+	source.Commit()
+
+	// Mine the approval and the burn while CCTPDepositForBurn waits for them.
+	mining := make(chan struct{})
+	defer close(mining)
+	go func() {
+		for {
+			select {
+			case <-mining:
+				return
+			case <-time.After(100 * time.Millisecond):
+				source.Commit()
+			}
+		}
+	}()
+
+	// The TokenMessenger is an account without code, so the burn itself only checks that it is sent after the approval.
+	tokenMessenger := common.HexToAddress("0x4")
+	amount := big.NewInt(1000)
+	if _, burnErr := CCTPDepositForBurn(ctx, client, tokenMessenger, sender, usdcAddress, amount, CCTP_DOMAIN_ARBITRUM, sender.Address(), ExactApproval); burnErr != nil {
+		t.Fatal(burnErr)
+	}
+
+	allowance, allowanceErr := usdc.Allowance(&bind.CallOpts{Context: ctx}, sender.Address(), tokenMessenger)
+	if allowanceErr != nil {
+		t.Fatal(allowanceErr)
+	}
+	if allowance.Cmp(amount) != 0 {
+		t.Errorf("Expected the TokenMessenger to be approved for %s, got %s", amount.String(), allowance.String())
+	}
+
+	nonce, nonceErr := client.NonceAt(ctx, sender.Address(), nil)
+	if nonceErr != nil {
+		t.Fatal(nonceErr)
+	}
+	// Deployment, approval and burn.
+	if nonce != 3 {
+		t.Errorf("Expected 3 transactions from the sender, got %d", nonce)
+	}
+
+	// The allowance covers a second burn of the same amount, so no approval is sent.
+	if _, burnErr := CCTPDepositForBurn(ctx, client, tokenMessenger, sender, usdcAddress, amount, CCTP_DOMAIN_ARBITRUM, sender.Address(), ExactApproval); burnErr != nil {
+		t.Fatal(burnErr)
+	}
+	nonce, nonceErr = client.NonceAt(ctx, sender.Address(), nil)
+	if nonceErr != nil {
+		t.Fatal(nonceErr)
+	}
+	if nonce != 4 {
+		t.Errorf("Expected 4 transactions from the sender, got %d", nonce)
+	}
+}
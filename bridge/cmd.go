@@ -1,13 +1,17 @@
 package bridge
 
 import (
-	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
+	"time"
 
 	"github.com/G7DAO/protocol/bindings/ETHOrbitBridger"
+	"github.com/G7DAO/protocol/command"
+	"github.com/G7DAO/protocol/signer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
@@ -25,6 +29,12 @@ func CreateBridgeCommand() *cobra.Command {
 	bridgeCmd.AddCommand(CreateBridgeNativeTokenCommand())
 	bridgeCmd.AddCommand(CreateBridgeERC20Command())
 	bridgeCmd.AddCommand(ETHOrbitBridger.CreateETHOrbitBridgerCommand())
+	bridgeCmd.AddCommand(CreateBridgeClaimCommand())
+	bridgeCmd.AddCommand(CreateBridgeStatusCommand())
+	bridgeCmd.AddCommand(CreateBridgeRedeemCommand())
+	bridgeCmd.AddCommand(CreateBridgeKeepaliveCommand())
+	bridgeCmd.AddCommand(CreateBridgeCCTPCommand())
+	bridgeCmd.AddCommand(CreateBridgeQuoteCommand())
 
 	return bridgeCmd
 }
@@ -40,13 +50,17 @@ func CreateBridgeNativeTokenCommand() *cobra.Command {
 
 	nativeTokenCmd.AddCommand(CreateBridgeNativeTokenL1ToL2Command())
 	nativeTokenCmd.AddCommand(CreateBridgeNativeTokenL1ToL3Command())
+	nativeTokenCmd.AddCommand(CreateBridgeNativeTokenL2ToL1Command())
 
 	return nativeTokenCmd
 }
 
 func CreateBridgeNativeTokenL1ToL2Command() *cobra.Command {
-	var keyFile, password, l1Rpc, l2Rpc, inboxRaw, toRaw, l2CallValueRaw, l2CalldataRaw, safeAddressRaw, safeApi, safeNonceRaw string
-	var inboxAddress, to, safeAddress common.Address
+	var timeout uint
+	var keyFile, password, signerRaw, l1Rpc, l2Rpc, inboxRaw, toRaw, l2CallValueRaw, l2CalldataRaw, safeAddressRaw, safeApi, safeApiKey, safeOut, safeNonceRaw, networkRaw, networksFile, approvalRaw, multiSendRaw string
+	var safeForce bool
+	var inboxAddress, to, safeAddress, multiSendAddress common.Address
+	var approvalMode ApprovalMode
 	var l2CallValue *big.Int
 	var l2Calldata []byte
 	var safeOperation uint8
@@ -58,6 +72,20 @@ func CreateBridgeNativeTokenL1ToL2Command() *cobra.Command {
 		Long:  `Bridge tokens from L1 to L2 with a single transaction and arbitrary calldata`,
 
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				_, child, parent, networkErr := resolveNetwork(networkRaw, networksFile)
+				if networkErr != nil {
+					return networkErr
+				}
+				ethBridge, ethBridgeErr := ethBridgeOf(child)
+				if ethBridgeErr != nil {
+					return ethBridgeErr
+				}
+				setAddressFromNetwork(&inboxRaw, ethBridge.Inbox)
+				setRpcFromNetwork(&l1Rpc, parent)
+				setRpcFromNetwork(&l2Rpc, child)
+			}
+
 			if !common.IsHexAddress(inboxRaw) {
 				return errors.New("invalid inbox address")
 			}
@@ -87,58 +115,50 @@ func CreateBridgeNativeTokenL1ToL2Command() *cobra.Command {
 				}
 			}
 
-			if keyFile == "" {
-				return errors.New("keyfile is required")
+			var approvalModeErr error
+			approvalMode, approvalModeErr = ParseApprovalMode(approvalRaw)
+			if approvalModeErr != nil {
+				return approvalModeErr
 			}
 
-			if safeAddressRaw != "" {
-				if !common.IsHexAddress(safeAddressRaw) {
-					return fmt.Errorf("--safe is not a valid Ethereum address")
-				} else {
-					safeAddress = common.HexToAddress(safeAddressRaw)
-				}
-
-				if safeApi == "" {
-					client, clientErr := ethclient.DialContext(context.Background(), l1Rpc)
-					if clientErr != nil {
-						return clientErr
-					}
-
-					chainID, chainIDErr := client.ChainID(context.Background())
-					if chainIDErr != nil {
-						return chainIDErr
-					}
-					safeApi = "https://safe-client.safe.global/v1/chains/" + chainID.String() + "/transactions/" + safeAddress.Hex() + "/propose"
-					fmt.Println("--safe-api not specified, using default (", safeApi, ")")
-				}
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
 
-				if OperationType(safeOperation).String() == "Unknown" {
-					return fmt.Errorf("--safe-operation must be 0 (Call) or 1 (DelegateCall)")
+			if safeAddressRaw != "" {
+				var safeErr error
+				safeAddress, safeNonce, safeErr = resolveSafeFlags(cmd, timeout, l1Rpc, safeAddressRaw, &safeApi, safeOut, safeOperation, safeNonceRaw)
+				if safeErr != nil {
+					return safeErr
 				}
 
-				if safeNonceRaw != "" {
-					safeNonce = new(big.Int)
-					_, ok := safeNonce.SetString(safeNonceRaw, 0)
-					if !ok {
-						return fmt.Errorf("--safe-nonce is not a valid big integer")
-					}
-				} else {
-					fmt.Println("--safe-nonce not specified, fetching from Safe")
+				if !common.IsHexAddress(multiSendRaw) {
+					return fmt.Errorf("--safe-multisend is not a valid Ethereum address")
 				}
+				multiSendAddress = common.HexToAddress(multiSendRaw)
 			}
 
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
 			fmt.Println("Bridging to", to.Hex())
 			if safeAddressRaw != "" {
-				err := NativeTokenBridgePropose(inboxAddress, keyFile, password, l1Rpc, l2Rpc, to, l2CallValue, l2Calldata, safeAddress, safeApi, safeOperation, safeNonce)
+				err := NativeTokenBridgePropose(ctx, inboxAddress, sender, l1Rpc, l2Rpc, to, l2CallValue, l2Calldata, safeAddress, newSafeClient(safeApi, safeApiKey), safeOut, safeForce, safeOperation, safeNonce, approvalMode, multiSendAddress)
 				if err != nil {
 					fmt.Fprintln(cmd.ErrOrStderr(), err.Error())
 					return err
 				}
 			} else {
-				transaction, transactionErr := NativeTokenBridgeCall(inboxAddress, keyFile, password, l1Rpc, l2Rpc, to, l2CallValue, l2Calldata)
+				transaction, transactionErr := NativeTokenBridgeCall(ctx, inboxAddress, sender, l1Rpc, l2Rpc, to, l2CallValue, l2Calldata, approvalMode)
 				if transactionErr != nil {
 					fmt.Fprintln(cmd.ErrOrStderr(), transactionErr.Error())
 					return transactionErr
@@ -153,24 +173,27 @@ func CreateBridgeNativeTokenL1ToL2Command() *cobra.Command {
 
 	createCmd.Flags().StringVar(&password, "password", "", "Password to encrypt accounts with")
 	createCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile to sign transaction with")
+	signer.AddSignerFlag(createCmd, &signerRaw)
 	createCmd.Flags().StringVar(&l1Rpc, "l1-rpc", "", "L1 RPC URL")
 	createCmd.Flags().StringVar(&l2Rpc, "l2-rpc", "", "L2 RPC URL")
 	createCmd.Flags().StringVar(&inboxRaw, "inbox", "", "Inbox address")
 	createCmd.Flags().StringVar(&toRaw, "to", "", "Recipient or contract address")
 	createCmd.Flags().StringVar(&l2CallValueRaw, "amount", "", "L2 call value")
 	createCmd.Flags().StringVar(&l2CalldataRaw, "l2-calldata", "", "Calldata to send")
-	createCmd.Flags().StringVar(&safeAddressRaw, "safe", "", "Address of the Safe contract")
-	createCmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
-	createCmd.Flags().Uint8Var(&safeOperation, "safe-operation", 0, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
-	createCmd.Flags().StringVar(&safeNonceRaw, "safe-nonce", "", "Safe nonce")
+	addSafeFlags(createCmd, &safeAddressRaw, &safeApi, &safeApiKey, &safeOut, &safeForce, &safeOperation, &safeNonceRaw)
+	addApprovalFlags(createCmd, &approvalRaw, &multiSendRaw)
+	command.AddTimeoutFlag(createCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(createCmd, &networkRaw, &networksFile)
 
 	return createCmd
 }
 
 func CreateBridgeNativeTokenL1ToL3Command() *cobra.Command {
-	var keyFile, password, l1TokenRaw, l3FeeTokenL1AddrRaw, l1l2RouterRaw, l2l3RouterOrInboxRaw, toRaw, amountRaw, l3CalldataRaw, l1Rpc, l2Rpc, l3Rpc, teleporterAddressRaw string
+	var timeout uint
+	var keyFile, password, signerRaw, l1TokenRaw, l3FeeTokenL1AddrRaw, l1l2RouterRaw, l2l3RouterOrInboxRaw, toRaw, amountRaw, l3CalldataRaw, l1Rpc, l2Rpc, l3Rpc, teleporterAddressRaw, networkRaw, networksFile, approvalRaw string
 	teleportParams := &TeleportParams{}
 	var teleporterAddress common.Address
+	var approvalMode ApprovalMode
 
 	var l3CallDataErr error
 
@@ -180,6 +203,12 @@ func CreateBridgeNativeTokenL1ToL3Command() *cobra.Command {
 		Long:  `Bridge tokens from L1 to L3 with a single transaction and arbitrary calldata`,
 
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				if networkErr := setTeleportFlagsFromNetwork(networkRaw, networksFile, l1TokenRaw, l3FeeTokenL1AddrRaw, &teleporterAddressRaw, &l1l2RouterRaw, &l2l3RouterOrInboxRaw, &l1Rpc, &l2Rpc, &l3Rpc); networkErr != nil {
+					return networkErr
+				}
+			}
+
 			if l3CalldataRaw != "" {
 				teleportParams.L3CallData, l3CallDataErr = hex.DecodeString(l3CalldataRaw)
 				if l3CallDataErr != nil {
@@ -228,14 +257,29 @@ func CreateBridgeNativeTokenL1ToL3Command() *cobra.Command {
 			}
 			teleporterAddress = common.HexToAddress(teleporterAddressRaw)
 
-			if keyFile == "" {
-				return errors.New("keyfile is required")
+			var approvalModeErr error
+			approvalMode, approvalModeErr = ParseApprovalMode(approvalRaw)
+			if approvalModeErr != nil {
+				return approvalModeErr
+			}
+
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
 			}
 
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			transaction, transactionErr := Teleport(teleporterAddress, teleportParams, keyFile, password, l1Rpc, l2Rpc, l3Rpc)
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
+			transaction, transactionErr := Teleport(ctx, teleporterAddress, teleportParams, sender, l1Rpc, l2Rpc, l3Rpc, approvalMode)
 			if transactionErr != nil {
 				fmt.Fprintln(cmd.ErrOrStderr(), transactionErr.Error())
 				return transactionErr
@@ -248,6 +292,7 @@ func CreateBridgeNativeTokenL1ToL3Command() *cobra.Command {
 	}
 
 	createCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile to sign transaction with")
+	signer.AddSignerFlag(createCmd, &signerRaw)
 	createCmd.Flags().StringVar(&password, "password", "", "Password to decrypt keyfile with")
 	createCmd.Flags().StringVar(&l1TokenRaw, "l1-token", "", "L1 token address")
 	createCmd.Flags().StringVar(&l3FeeTokenL1AddrRaw, "l1l3-fee-token", "", "L3 fee token L1 address")
@@ -260,6 +305,120 @@ func CreateBridgeNativeTokenL1ToL3Command() *cobra.Command {
 	createCmd.Flags().StringVar(&l2Rpc, "l2-rpc", "", "L2 RPC URL")
 	createCmd.Flags().StringVar(&l3Rpc, "l3-rpc", "", "L3 RPC URL")
 	createCmd.Flags().StringVar(&teleporterAddressRaw, "teleporter", "", "Teleporter contract address")
+	addApprovalFlags(createCmd, &approvalRaw, nil)
+	command.AddTimeoutFlag(createCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(createCmd, &networkRaw, &networksFile)
+
+	return createCmd
+}
+
+func CreateBridgeNativeTokenL2ToL1Command() *cobra.Command {
+	var timeout uint
+	var keyFile, password, signerRaw, l2Rpc, toRaw, amountRaw, l1CalldataRaw, safeAddressRaw, safeApi, safeApiKey, safeOut, safeNonceRaw, networkRaw, networksFile string
+	var safeForce bool
+	var to, safeAddress common.Address
+	var amount *big.Int
+	var l1Calldata []byte
+	var safeOperation uint8
+	var safeNonce *big.Int
+
+	createCmd := &cobra.Command{
+		Use:   "l2-to-l1",
+		Short: "Withdraw native tokens from L2 to L1",
+		Long:  `Withdraw native tokens from L2 to L1 through ArbSys, optionally forwarding calldata to the recipient on L1. The withdrawal can be claimed on L1 once the challenge period has passed.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				_, child, _, networkErr := resolveNetwork(networkRaw, networksFile)
+				if networkErr != nil {
+					return networkErr
+				}
+				setRpcFromNetwork(&l2Rpc, child)
+			}
+
+			if !common.IsHexAddress(toRaw) {
+				return errors.New("invalid recipient address")
+			}
+			to = common.HexToAddress(toRaw)
+
+			amount = new(big.Int)
+			if amountRaw != "" {
+				_, ok := amount.SetString(amountRaw, 10)
+				if !ok {
+					return errors.New("invalid amount")
+				}
+			} else {
+				fmt.Println("No amount provided, defaulting to 0")
+				amount.SetInt64(0)
+			}
+
+			if l1CalldataRaw != "" {
+				var err error
+				l1Calldata, err = hex.DecodeString(l1CalldataRaw)
+				if err != nil {
+					return err
+				}
+			}
+
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
+
+			if l2Rpc == "" {
+				return errors.New("l2-rpc is required")
+			}
+
+			if safeAddressRaw != "" {
+				var safeErr error
+				safeAddress, safeNonce, safeErr = resolveSafeFlags(cmd, timeout, l2Rpc, safeAddressRaw, &safeApi, safeOut, safeOperation, safeNonceRaw)
+				if safeErr != nil {
+					return safeErr
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
+			fmt.Println("Withdrawing to", to.Hex())
+			if safeAddressRaw != "" {
+				err := NativeTokenWithdrawPropose(ctx, sender, l2Rpc, to, amount, l1Calldata, safeAddress, newSafeClient(safeApi, safeApiKey), safeOut, safeForce, safeOperation, safeNonce)
+				if err != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), err.Error())
+					return err
+				}
+			} else {
+				transaction, transactionErr := NativeTokenWithdrawCall(ctx, sender, l2Rpc, to, amount, l1Calldata)
+				if transactionErr != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), transactionErr.Error())
+					return transactionErr
+				}
+
+				fmt.Println("Transaction sent:", transaction.Hash().Hex())
+			}
+
+			return nil
+		},
+	}
+
+	createCmd.Flags().StringVar(&password, "password", "", "Password to decrypt keyfile with")
+	createCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile to sign transaction with")
+	signer.AddSignerFlag(createCmd, &signerRaw)
+	createCmd.Flags().StringVar(&l2Rpc, "l2-rpc", "", "L2 RPC URL")
+	createCmd.Flags().StringVar(&toRaw, "to", "", "Recipient or contract address on L1")
+	createCmd.Flags().StringVar(&amountRaw, "amount", "", "Amount to withdraw")
+	createCmd.Flags().StringVar(&l1CalldataRaw, "l1-calldata", "", "Calldata to send to the recipient on L1 (optional)")
+	addSafeFlags(createCmd, &safeAddressRaw, &safeApi, &safeApiKey, &safeOut, &safeForce, &safeOperation, &safeNonceRaw)
+	command.AddTimeoutFlag(createCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(createCmd, &networkRaw, &networksFile)
 
 	return createCmd
 }
@@ -274,17 +433,21 @@ func CreateBridgeERC20Command() *cobra.Command {
 	}
 
 	erc20Cmd.AddCommand(CreateBridgeERC20L1ToL2Command())
+	erc20Cmd.AddCommand(CreateBridgeERC20L2ToL1Command())
 
 	return erc20Cmd
 }
 
 func CreateBridgeERC20L1ToL2Command() *cobra.Command {
-	var keyFile, password, l1Rpc, l2Rpc, routerRaw, tokenAddressRaw, toRaw, amountRaw, safeAddressRaw, safeApi, safeNonceRaw string
-	var routerAddress, tokenAddress, to, safeAddress common.Address
+	var timeout uint
+	var keyFile, password, signerRaw, l1Rpc, l2Rpc, routerRaw, tokenAddressRaw, toRaw, amountRaw, safeAddressRaw, safeApi, safeApiKey, safeOut, safeNonceRaw, networkRaw, networksFile, approvalRaw, multiSendRaw string
+	var safeForce bool
+	var routerAddress, tokenAddress, to, safeAddress, multiSendAddress common.Address
 	var amount *big.Int
 	var safeOperation uint8
 	var safeNonce *big.Int
 	var isCustomNativeToken bool
+	var approvalMode ApprovalMode
 
 	createCmd := &cobra.Command{
 		Use:   "l1-to-l2",
@@ -292,6 +455,23 @@ func CreateBridgeERC20L1ToL2Command() *cobra.Command {
 		Long:  `Bridge ERC20 tokens from L1 to L2 with a single transaction and arbitrary calldata`,
 
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				_, child, parent, networkErr := resolveNetwork(networkRaw, networksFile)
+				if networkErr != nil {
+					return networkErr
+				}
+				tokenBridge, tokenBridgeErr := tokenBridgeOf(child)
+				if tokenBridgeErr != nil {
+					return tokenBridgeErr
+				}
+				setAddressFromNetwork(&routerRaw, tokenBridge.ParentGatewayRouter)
+				setRpcFromNetwork(&l1Rpc, parent)
+				setRpcFromNetwork(&l2Rpc, child)
+				if !cmd.Flags().Changed("custom-native-token") && child.NativeToken != nil {
+					isCustomNativeToken = *child.NativeToken != (common.Address{})
+				}
+			}
+
 			if !common.IsHexAddress(routerRaw) {
 				return errors.New("invalid router address")
 			}
@@ -318,44 +498,27 @@ func CreateBridgeERC20L1ToL2Command() *cobra.Command {
 				amount.SetInt64(0)
 			}
 
-			if keyFile == "" {
-				return errors.New("keyfile is required")
+			var approvalModeErr error
+			approvalMode, approvalModeErr = ParseApprovalMode(approvalRaw)
+			if approvalModeErr != nil {
+				return approvalModeErr
 			}
 
-			if safeAddressRaw != "" {
-				if !common.IsHexAddress(safeAddressRaw) {
-					return fmt.Errorf("--safe is not a valid Ethereum address")
-				} else {
-					safeAddress = common.HexToAddress(safeAddressRaw)
-				}
-
-				if safeApi == "" {
-					client, clientErr := ethclient.DialContext(context.Background(), l1Rpc)
-					if clientErr != nil {
-						return clientErr
-					}
-
-					chainID, chainIDErr := client.ChainID(context.Background())
-					if chainIDErr != nil {
-						return chainIDErr
-					}
-					safeApi = "https://safe-client.safe.global/v1/chains/" + chainID.String() + "/transactions/" + safeAddress.Hex() + "/propose"
-					fmt.Println("--safe-api not specified, using default (", safeApi, ")")
-				}
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
 
-				if OperationType(safeOperation).String() == "Unknown" {
-					return fmt.Errorf("--safe-operation must be 0 (Call) or 1 (DelegateCall)")
+			if safeAddressRaw != "" {
+				var safeErr error
+				safeAddress, safeNonce, safeErr = resolveSafeFlags(cmd, timeout, l1Rpc, safeAddressRaw, &safeApi, safeOut, safeOperation, safeNonceRaw)
+				if safeErr != nil {
+					return safeErr
 				}
 
-				if safeNonceRaw != "" {
-					safeNonce = new(big.Int)
-					_, ok := safeNonce.SetString(safeNonceRaw, 0)
-					if !ok {
-						return fmt.Errorf("--safe-nonce is not a valid big integer")
-					}
-				} else {
-					fmt.Println("--safe-nonce not specified, fetching from Safe")
+				if !common.IsHexAddress(multiSendRaw) {
+					return fmt.Errorf("--safe-multisend is not a valid Ethereum address")
 				}
+				multiSendAddress = common.HexToAddress(multiSendRaw)
 
 				if l1Rpc == "" {
 					return errors.New("l1-rpc is required")
@@ -369,16 +532,25 @@ func CreateBridgeERC20L1ToL2Command() *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
 			fmt.Println("Bridging", tokenAddress.Hex(), "to", to.Hex())
 			if safeAddressRaw == "" {
-				transaction, transactionErr := ERC20BridgeCall(routerAddress, keyFile, password, l1Rpc, l2Rpc, tokenAddress, to, amount, isCustomNativeToken)
+				transaction, transactionErr := ERC20BridgeCall(ctx, routerAddress, sender, l1Rpc, l2Rpc, tokenAddress, to, amount, isCustomNativeToken, approvalMode)
 				if transactionErr != nil {
 					fmt.Fprintln(cmd.ErrOrStderr(), transactionErr.Error())
 					return transactionErr
 				}
 				fmt.Println("Transaction sent:", transaction.Hash().Hex())
 			} else {
-				proposeErr := ERC20BridgePropose(routerAddress, keyFile, password, l1Rpc, l2Rpc, tokenAddress, to, amount, safeAddress, safeApi, safeOperation, safeNonce, isCustomNativeToken)
+				proposeErr := ERC20BridgePropose(ctx, routerAddress, sender, l1Rpc, l2Rpc, tokenAddress, to, amount, safeAddress, newSafeClient(safeApi, safeApiKey), safeOut, safeForce, safeOperation, safeNonce, isCustomNativeToken, approvalMode, multiSendAddress)
 				if proposeErr != nil {
 					fmt.Fprintln(cmd.ErrOrStderr(), proposeErr.Error())
 					return proposeErr
@@ -391,17 +563,1166 @@ func CreateBridgeERC20L1ToL2Command() *cobra.Command {
 
 	createCmd.Flags().StringVar(&password, "password", "", "Password to encrypt accounts with")
 	createCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile to sign transaction with")
+	signer.AddSignerFlag(createCmd, &signerRaw)
 	createCmd.Flags().StringVar(&l1Rpc, "l1-rpc", "", "L1 RPC URL")
 	createCmd.Flags().StringVar(&l2Rpc, "l2-rpc", "", "L2 RPC URL")
 	createCmd.Flags().StringVar(&routerRaw, "router", "", "Router address")
 	createCmd.Flags().StringVar(&toRaw, "to", "", "Recipient address")
 	createCmd.Flags().StringVar(&tokenAddressRaw, "token", "", "Token address")
 	createCmd.Flags().StringVar(&amountRaw, "amount", "", "Amount to send")
-	createCmd.Flags().StringVar(&safeAddressRaw, "safe", "", "Address of the Safe contract")
-	createCmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
-	createCmd.Flags().Uint8Var(&safeOperation, "safe-operation", 0, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
-	createCmd.Flags().StringVar(&safeNonceRaw, "safe-nonce", "", "Safe nonce")
+	addSafeFlags(createCmd, &safeAddressRaw, &safeApi, &safeApiKey, &safeOut, &safeForce, &safeOperation, &safeNonceRaw)
 	createCmd.Flags().BoolVar(&isCustomNativeToken, "custom-native-token", false, "Is custom native token")
+	addApprovalFlags(createCmd, &approvalRaw, &multiSendRaw)
+	command.AddTimeoutFlag(createCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(createCmd, &networkRaw, &networksFile)
+
+	return createCmd
+}
+
+func CreateBridgeERC20L2ToL1Command() *cobra.Command {
+	var timeout uint
+	var keyFile, password, signerRaw, l2Rpc, routerRaw, tokenAddressRaw, toRaw, amountRaw, safeAddressRaw, safeApi, safeApiKey, safeOut, safeNonceRaw, networkRaw, networksFile string
+	var safeForce bool
+	var routerAddress, tokenAddress, to, safeAddress common.Address
+	var amount *big.Int
+	var safeOperation uint8
+	var safeNonce *big.Int
+
+	createCmd := &cobra.Command{
+		Use:   "l2-to-l1",
+		Short: "Withdraw ERC20 tokens from L2 to L1",
+		Long:  `Withdraw ERC20 tokens from L2 to L1 through the L2 gateway router. The withdrawal can be claimed on L1 once the challenge period has passed.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				_, child, _, networkErr := resolveNetwork(networkRaw, networksFile)
+				if networkErr != nil {
+					return networkErr
+				}
+				tokenBridge, tokenBridgeErr := tokenBridgeOf(child)
+				if tokenBridgeErr != nil {
+					return tokenBridgeErr
+				}
+				setAddressFromNetwork(&routerRaw, tokenBridge.ChildGatewayRouter)
+				setRpcFromNetwork(&l2Rpc, child)
+			}
+
+			if !common.IsHexAddress(routerRaw) {
+				return errors.New("invalid router address")
+			}
+			routerAddress = common.HexToAddress(routerRaw)
+
+			if !common.IsHexAddress(toRaw) {
+				return errors.New("invalid recipient address")
+			}
+			to = common.HexToAddress(toRaw)
+
+			if !common.IsHexAddress(tokenAddressRaw) {
+				return errors.New("invalid token address")
+			}
+			tokenAddress = common.HexToAddress(tokenAddressRaw)
+
+			amount = new(big.Int)
+			if amountRaw != "" {
+				_, ok := amount.SetString(amountRaw, 10)
+				if !ok {
+					return errors.New("invalid amount")
+				}
+			} else {
+				fmt.Println("No amount provided, defaulting to 0")
+				amount.SetInt64(0)
+			}
+
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
+
+			if l2Rpc == "" {
+				return errors.New("l2-rpc is required")
+			}
+
+			if safeAddressRaw != "" {
+				var safeErr error
+				safeAddress, safeNonce, safeErr = resolveSafeFlags(cmd, timeout, l2Rpc, safeAddressRaw, &safeApi, safeOut, safeOperation, safeNonceRaw)
+				if safeErr != nil {
+					return safeErr
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
+			fmt.Println("Withdrawing", tokenAddress.Hex(), "to", to.Hex())
+			if safeAddressRaw == "" {
+				transaction, transactionErr := ERC20WithdrawCall(ctx, routerAddress, sender, l2Rpc, tokenAddress, to, amount)
+				if transactionErr != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), transactionErr.Error())
+					return transactionErr
+				}
+				fmt.Println("Transaction sent:", transaction.Hash().Hex())
+			} else {
+				proposeErr := ERC20WithdrawPropose(ctx, routerAddress, sender, l2Rpc, tokenAddress, to, amount, safeAddress, newSafeClient(safeApi, safeApiKey), safeOut, safeForce, safeOperation, safeNonce)
+				if proposeErr != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), proposeErr.Error())
+					return proposeErr
+				}
+			}
+
+			return nil
+		},
+	}
+
+	createCmd.Flags().StringVar(&password, "password", "", "Password to decrypt keyfile with")
+	createCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile to sign transaction with")
+	signer.AddSignerFlag(createCmd, &signerRaw)
+	createCmd.Flags().StringVar(&l2Rpc, "l2-rpc", "", "L2 RPC URL")
+	createCmd.Flags().StringVar(&routerRaw, "router", "", "L2 gateway router address")
+	createCmd.Flags().StringVar(&toRaw, "to", "", "Recipient address on L1")
+	createCmd.Flags().StringVar(&tokenAddressRaw, "token", "", "L1 address of the token")
+	createCmd.Flags().StringVar(&amountRaw, "amount", "", "Amount to withdraw")
+	addSafeFlags(createCmd, &safeAddressRaw, &safeApi, &safeApiKey, &safeOut, &safeForce, &safeOperation, &safeNonceRaw)
+	command.AddTimeoutFlag(createCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(createCmd, &networkRaw, &networksFile)
 
 	return createCmd
 }
+
+func CreateBridgeClaimCommand() *cobra.Command {
+	var timeout uint
+	var keyFile, password, signerRaw, l1Rpc, l2Rpc, outboxRaw, txHashRaw, safeAddressRaw, safeApi, safeApiKey, safeOut, safeNonceRaw, networkRaw, networksFile string
+	var safeForce bool
+	var outboxAddress, safeAddress common.Address
+	var txHash common.Hash
+	var lookback uint64
+	var safeOperation uint8
+	var safeNonce *big.Int
+
+	claimCmd := &cobra.Command{
+		Use:   "claim",
+		Short: "Claim L2-to-L1 withdrawals on L1",
+		Long:  `Execute the L2-to-L1 messages sent by an L2 transaction on the L1 outbox, once they have cleared the challenge period and been confirmed`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				_, child, parent, networkErr := resolveNetwork(networkRaw, networksFile)
+				if networkErr != nil {
+					return networkErr
+				}
+				ethBridge, ethBridgeErr := ethBridgeOf(child)
+				if ethBridgeErr != nil {
+					return ethBridgeErr
+				}
+				setAddressFromNetwork(&outboxRaw, ethBridge.Outbox)
+				setRpcFromNetwork(&l1Rpc, parent)
+				setRpcFromNetwork(&l2Rpc, child)
+			}
+
+			if !common.IsHexAddress(outboxRaw) {
+				return errors.New("invalid outbox address")
+			}
+			outboxAddress = common.HexToAddress(outboxRaw)
+
+			txHashBytes, txHashErr := hex.DecodeString(strings.TrimPrefix(txHashRaw, "0x"))
+			if txHashErr != nil || len(txHashBytes) != common.HashLength {
+				return errors.New("invalid transaction hash")
+			}
+			txHash = common.BytesToHash(txHashBytes)
+
+			if l1Rpc == "" {
+				return errors.New("l1-rpc is required")
+			}
+
+			if l2Rpc == "" {
+				return errors.New("l2-rpc is required")
+			}
+
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
+
+			if safeAddressRaw != "" {
+				var safeErr error
+				safeAddress, safeNonce, safeErr = resolveSafeFlags(cmd, timeout, l1Rpc, safeAddressRaw, &safeApi, safeOut, safeOperation, safeNonceRaw)
+				if safeErr != nil {
+					return safeErr
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
+			messages, messagesErr := GetOutboxMessages(ctx, l1Rpc, l2Rpc, outboxAddress, txHash, lookback)
+			if messagesErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), messagesErr.Error())
+				return messagesErr
+			}
+
+			if safeAddressRaw != "" {
+				var safeNonceErr error
+				safeNonce, safeNonceErr = safeNonceOrNext(ctx, l1Rpc, safeAddress, safeNonce)
+				if safeNonceErr != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), safeNonceErr.Error())
+					return safeNonceErr
+				}
+			}
+
+			for _, message := range messages {
+				fmt.Println("L2-to-L1 message", message.Event.Position.String(), "to", message.Event.Destination.Hex())
+				if message.Executed {
+					fmt.Println("Message has already been executed")
+					continue
+				}
+				if !message.Confirmed {
+					fmt.Fprintln(cmd.ErrOrStderr(), ErrL2ToL1MessageNotConfirmed.Error())
+					return ErrL2ToL1MessageNotConfirmed
+				}
+
+				if safeAddressRaw != "" {
+					err := ClaimPropose(ctx, outboxAddress, sender, l1Rpc, message, safeAddress, newSafeClient(safeApi, safeApiKey), safeOut, safeForce, safeOperation, safeNonce)
+					if err != nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), err.Error())
+						return err
+					}
+					// Proposals for later messages in the same transaction take the following Safe nonces.
+					safeNonce = new(big.Int).Add(safeNonce, big.NewInt(1))
+				} else {
+					transaction, transactionErr := ClaimCall(ctx, outboxAddress, sender, l1Rpc, message)
+					if transactionErr != nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), transactionErr.Error())
+						return transactionErr
+					}
+
+					fmt.Println("Transaction sent:", transaction.Hash().Hex())
+				}
+			}
+
+			return nil
+		},
+	}
+
+	claimCmd.Flags().StringVar(&password, "password", "", "Password to decrypt keyfile with")
+	claimCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile to sign transaction with")
+	signer.AddSignerFlag(claimCmd, &signerRaw)
+	claimCmd.Flags().StringVar(&l1Rpc, "l1-rpc", "", "L1 RPC URL")
+	claimCmd.Flags().StringVar(&l2Rpc, "l2-rpc", "", "L2 RPC URL")
+	claimCmd.Flags().StringVar(&outboxRaw, "outbox", "", "Outbox address on L1")
+	claimCmd.Flags().StringVar(&txHashRaw, "tx", "", "Hash of the L2 withdrawal transaction")
+	claimCmd.Flags().Uint64Var(&lookback, "lookback", DEFAULT_OUTBOX_LOOKBACK, "Number of L1 blocks to search for the latest confirmed send root")
+	addSafeFlags(claimCmd, &safeAddressRaw, &safeApi, &safeApiKey, &safeOut, &safeForce, &safeOperation, &safeNonceRaw)
+	command.AddTimeoutFlag(claimCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(claimCmd, &networkRaw, &networksFile)
+
+	return claimCmd
+}
+
+func CreateBridgeStatusCommand() *cobra.Command {
+	var timeout uint
+	var l1Rpc, l2Rpc, l3Rpc, l2BridgeRaw, l3BridgeRaw, outboxRaw, txHashRaw, networkRaw, networksFile string
+	var l2BridgeAddress, l3BridgeAddress, outboxAddress common.Address
+	var txHash common.Hash
+	var lookback uint64
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Check the status of a cross-chain transfer",
+		Long:  `Follow a deposit, teleport or withdrawal from its origin transaction and report the status of each leg: the L2 retryable ticket, the L3 retryable ticket for teleports, or the L2-to-L1 messages for withdrawals`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				registry, child, parent, networkErr := resolveNetwork(networkRaw, networksFile)
+				if networkErr != nil {
+					return networkErr
+				}
+				if child.EthBridge != nil {
+					setAddressFromNetwork(&l2BridgeRaw, child.EthBridge.Bridge)
+					setAddressFromNetwork(&outboxRaw, child.EthBridge.Outbox)
+				}
+				setRpcFromNetwork(&l1Rpc, parent)
+				setRpcFromNetwork(&l2Rpc, child)
+				// Follow teleports to the network that settles to this one. When several do, the L3 has to be chosen
+				// explicitly.
+				children := registry.Children(child)
+				if l3Rpc == "" && len(children) > 1 {
+					return fmt.Errorf("several networks settle to %s (%d), set --l3-rpc and --l3-bridge to follow teleports to one of them", child.Name, child.ChainID)
+				}
+				if len(children) == 1 {
+					setRpcFromNetwork(&l3Rpc, &children[0])
+					if children[0].EthBridge != nil {
+						setAddressFromNetwork(&l3BridgeRaw, children[0].EthBridge.Bridge)
+					}
+				}
+			}
+
+			if l2BridgeRaw != "" {
+				if !common.IsHexAddress(l2BridgeRaw) {
+					return errors.New("invalid L2 bridge address")
+				}
+				l2BridgeAddress = common.HexToAddress(l2BridgeRaw)
+			}
+
+			if l3BridgeRaw != "" {
+				if !common.IsHexAddress(l3BridgeRaw) {
+					return errors.New("invalid L3 bridge address")
+				}
+				l3BridgeAddress = common.HexToAddress(l3BridgeRaw)
+			}
+
+			txHashBytes, txHashErr := hex.DecodeString(strings.TrimPrefix(txHashRaw, "0x"))
+			if txHashErr != nil || len(txHashBytes) != common.HashLength {
+				return errors.New("invalid transaction hash")
+			}
+			txHash = common.BytesToHash(txHashBytes)
+
+			if l1Rpc == "" {
+				return errors.New("l1-rpc is required")
+			}
+
+			if l2Rpc == "" {
+				return errors.New("l2-rpc is required")
+			}
+
+			if outboxRaw != "" {
+				if !common.IsHexAddress(outboxRaw) {
+					return errors.New("invalid outbox address")
+				}
+				outboxAddress = common.HexToAddress(outboxRaw)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			legs, legsErr := TransferStatus(ctx, l1Rpc, l2Rpc, l3Rpc, l2BridgeAddress, l3BridgeAddress, outboxAddress, txHash, lookback)
+			if errors.Is(legsErr, ErrNoBridgeAddress) {
+				legsErr = fmt.Errorf("%w: set --network, or --l2-bridge (and --l3-bridge with --l3-rpc)", legsErr)
+			}
+			if legsErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), legsErr.Error())
+				return legsErr
+			}
+
+			for _, leg := range legs {
+				switch leg.Kind {
+				case WithdrawalLeg:
+					fmt.Println(leg.Kind.String(), leg.OutboxMessage.Event.Position.String(), "to", leg.OutboxMessage.Event.Destination.Hex(), "on chain", leg.ChainID.String()+":", leg.Status.String())
+				default:
+					fmt.Println(leg.Kind.String(), leg.ChildTxHash.Hex(), "to", leg.Message.To.Hex(), "on chain", leg.ChainID.String()+":", leg.Status.String())
+					if leg.Status == LegRedeemed {
+						fmt.Println("  Redeemed in transaction", leg.RedeemTxHash.Hex())
+					}
+				}
+			}
+
+			if outboxAddress == (common.Address{}) && len(legs) > 0 && legs[0].Kind == WithdrawalLeg {
+				fmt.Println("--outbox not specified, withdrawals are reported as pending")
+			}
+
+			return nil
+		},
+	}
+
+	statusCmd.Flags().StringVar(&l1Rpc, "l1-rpc", "", "L1 RPC URL")
+	statusCmd.Flags().StringVar(&l2Rpc, "l2-rpc", "", "L2 RPC URL")
+	statusCmd.Flags().StringVar(&l3Rpc, "l3-rpc", "", "L3 RPC URL, to follow teleports to L3 (optional)")
+	statusCmd.Flags().StringVar(&l2BridgeRaw, "l2-bridge", "", "Bridge contract of L2 on L1, to follow deposits and teleports")
+	statusCmd.Flags().StringVar(&l3BridgeRaw, "l3-bridge", "", "Bridge contract of L3 on L2, to follow teleports to L3 (required with --l3-rpc)")
+	statusCmd.Flags().StringVar(&outboxRaw, "outbox", "", "Outbox address on L1, to check whether withdrawals are confirmed (optional)")
+	statusCmd.Flags().StringVar(&txHashRaw, "tx", "", "Hash of the origin transaction (on L1 for deposits and teleports, on L2 for withdrawals)")
+	statusCmd.Flags().Uint64Var(&lookback, "lookback", DEFAULT_OUTBOX_LOOKBACK, "Number of L1 blocks to search for the latest confirmed send root")
+	command.AddTimeoutFlag(statusCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(statusCmd, &networkRaw, &networksFile)
+
+	return statusCmd
+}
+
+func CreateBridgeRedeemCommand() *cobra.Command {
+	var timeout uint
+	var keyFile, password, signerRaw, parentRpc, childRpc, bridgeRaw, ticketRaw, txHashRaw, safeAddressRaw, safeApi, safeApiKey, safeOut, safeNonceRaw, networkRaw, networksFile string
+	var safeForce bool
+	var safeAddress common.Address
+	var ticketIDs []common.Hash
+	var gasLimit uint64
+	var safeOperation uint8
+	var safeNonce *big.Int
+
+	redeemCmd := &cobra.Command{
+		Use:   "redeem",
+		Short: "Manually redeem retryable tickets",
+		Long:  `Redeem retryable tickets whose auto-redeem failed, through the ArbRetryableTx precompile. Tickets are given by ID, or looked up from the parent chain transaction that created them. To redeem L3 tickets, pass the L2 RPC as --l1-rpc and the L3 RPC as --l2-rpc`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				_, child, parent, networkErr := resolveNetwork(networkRaw, networksFile)
+				if networkErr != nil {
+					return networkErr
+				}
+				if child.EthBridge != nil {
+					setAddressFromNetwork(&bridgeRaw, child.EthBridge.Bridge)
+				}
+				setRpcFromNetwork(&parentRpc, parent)
+				setRpcFromNetwork(&childRpc, child)
+			}
+
+			if childRpc == "" {
+				return errors.New("l2-rpc is required")
+			}
+
+			if (ticketRaw == "") == (txHashRaw == "") {
+				return errors.New("exactly one of --ticket or --tx is required")
+			}
+
+			if ticketRaw != "" {
+				ticketBytes, ticketErr := hex.DecodeString(strings.TrimPrefix(ticketRaw, "0x"))
+				if ticketErr != nil || len(ticketBytes) != common.HashLength {
+					return errors.New("invalid retryable ticket ID")
+				}
+				ticketIDs = []common.Hash{common.BytesToHash(ticketBytes)}
+			} else {
+				txHashBytes, txHashErr := hex.DecodeString(strings.TrimPrefix(txHashRaw, "0x"))
+				if txHashErr != nil || len(txHashBytes) != common.HashLength {
+					return errors.New("invalid transaction hash")
+				}
+
+				if parentRpc == "" {
+					return errors.New("l1-rpc is required to look up retryable tickets from a transaction")
+				}
+
+				if bridgeRaw == "" {
+					return errors.New("bridge is required to look up retryable tickets from a transaction")
+				}
+				if !common.IsHexAddress(bridgeRaw) {
+					return errors.New("invalid bridge address")
+				}
+
+				ctx, cancel := command.Context(cmd, timeout)
+				defer cancel()
+
+				parentClient, parentClientErr := ethclient.DialContext(ctx, parentRpc)
+				if parentClientErr != nil {
+					return parentClientErr
+				}
+
+				childClient, childClientErr := ethclient.DialContext(ctx, childRpc)
+				if childClientErr != nil {
+					return childClientErr
+				}
+
+				var ticketIDsErr error
+				ticketIDs, ticketIDsErr = GetRetryableTicketIDs(ctx, parentClient, childClient, common.HexToAddress(bridgeRaw), common.BytesToHash(txHashBytes))
+				if ticketIDsErr != nil {
+					return ticketIDsErr
+				}
+			}
+
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
+
+			if safeAddressRaw != "" {
+				var safeErr error
+				safeAddress, safeNonce, safeErr = resolveSafeFlags(cmd, timeout, childRpc, safeAddressRaw, &safeApi, safeOut, safeOperation, safeNonceRaw)
+				if safeErr != nil {
+					return safeErr
+				}
+				// The Safe executes the redeem with the gas limit of the Safe transaction.
+				if gasLimit != 0 {
+					return errors.New("--gas-limit cannot be used with --safe")
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
+			if safeAddressRaw != "" {
+				var safeNonceErr error
+				safeNonce, safeNonceErr = safeNonceOrNext(ctx, childRpc, safeAddress, safeNonce)
+				if safeNonceErr != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), safeNonceErr.Error())
+					return safeNonceErr
+				}
+			}
+
+			for _, ticketID := range ticketIDs {
+				fmt.Println("Redeeming retryable ticket", ticketID.Hex())
+				if safeAddressRaw != "" {
+					err := RedeemPropose(ctx, sender, childRpc, ticketID, safeAddress, newSafeClient(safeApi, safeApiKey), safeOut, safeForce, safeOperation, safeNonce)
+					if errors.Is(err, ErrRetryableTicketNotFound) && len(ticketIDs) > 1 {
+						fmt.Println(err.Error())
+						continue
+					} else if err != nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), err.Error())
+						return err
+					}
+					// Proposals for later tickets in the same transaction take the following Safe nonces.
+					safeNonce = new(big.Int).Add(safeNonce, big.NewInt(1))
+				} else {
+					transaction, transactionErr := RedeemCall(ctx, sender, childRpc, ticketID, gasLimit)
+					if errors.Is(transactionErr, ErrRetryableTicketNotFound) && len(ticketIDs) > 1 {
+						fmt.Println(transactionErr.Error())
+						continue
+					} else if transactionErr != nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), transactionErr.Error())
+						return transactionErr
+					}
+
+					fmt.Println("Transaction sent:", transaction.Hash().Hex())
+				}
+			}
+
+			return nil
+		},
+	}
+
+	redeemCmd.Flags().StringVar(&password, "password", "", "Password to decrypt keyfile with")
+	redeemCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile to sign transaction with")
+	signer.AddSignerFlag(redeemCmd, &signerRaw)
+	redeemCmd.Flags().StringVar(&parentRpc, "l1-rpc", "", "RPC URL of the parent chain (only required with --tx)")
+	redeemCmd.Flags().StringVar(&childRpc, "l2-rpc", "", "RPC URL of the child chain the retryable ticket lives on")
+	redeemCmd.Flags().StringVar(&ticketRaw, "ticket", "", "Retryable ticket ID")
+	redeemCmd.Flags().StringVar(&bridgeRaw, "bridge", "", "Bridge contract of the child chain on the parent chain (only required with --tx)")
+	redeemCmd.Flags().StringVar(&txHashRaw, "tx", "", "Hash of the parent chain transaction that created the retryable tickets")
+	redeemCmd.Flags().Uint64Var(&gasLimit, "gas-limit", 0, "Gas limit of the redeem transaction; the gas left after the redeem is donated to the retry (estimated if not specified, not supported with --safe)")
+	addSafeFlags(redeemCmd, &safeAddressRaw, &safeApi, &safeApiKey, &safeOut, &safeForce, &safeOperation, &safeNonceRaw)
+	command.AddTimeoutFlag(redeemCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(redeemCmd, &networkRaw, &networksFile)
+
+	return redeemCmd
+}
+
+func CreateBridgeKeepaliveCommand() *cobra.Command {
+	var timeout uint
+	var keyFile, password, signerRaw, parentRpc, childRpc, bridgeRaw, ticketRaw, txHashRaw, safeAddressRaw, safeApi, safeApiKey, safeOut, safeNonceRaw, networkRaw, networksFile string
+	var safeForce bool
+	var safeAddress common.Address
+	var ticketIDs []common.Hash
+	var safeOperation uint8
+	var safeNonce *big.Int
+
+	keepaliveCmd := &cobra.Command{
+		Use:   "keepalive",
+		Short: "Extend the lifetime of retryable tickets",
+		Long:  `Extend the lifetime of retryable tickets by one retryable lifetime through the ArbRetryableTx precompile, so that they can be redeemed later. Tickets are given by ID, or looked up from the parent chain transaction that created them`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				_, child, parent, networkErr := resolveNetwork(networkRaw, networksFile)
+				if networkErr != nil {
+					return networkErr
+				}
+				if child.EthBridge != nil {
+					setAddressFromNetwork(&bridgeRaw, child.EthBridge.Bridge)
+				}
+				setRpcFromNetwork(&parentRpc, parent)
+				setRpcFromNetwork(&childRpc, child)
+			}
+
+			if childRpc == "" {
+				return errors.New("l2-rpc is required")
+			}
+
+			if (ticketRaw == "") == (txHashRaw == "") {
+				return errors.New("exactly one of --ticket or --tx is required")
+			}
+
+			if ticketRaw != "" {
+				ticketBytes, ticketErr := hex.DecodeString(strings.TrimPrefix(ticketRaw, "0x"))
+				if ticketErr != nil || len(ticketBytes) != common.HashLength {
+					return errors.New("invalid retryable ticket ID")
+				}
+				ticketIDs = []common.Hash{common.BytesToHash(ticketBytes)}
+			} else {
+				txHashBytes, txHashErr := hex.DecodeString(strings.TrimPrefix(txHashRaw, "0x"))
+				if txHashErr != nil || len(txHashBytes) != common.HashLength {
+					return errors.New("invalid transaction hash")
+				}
+
+				if parentRpc == "" {
+					return errors.New("l1-rpc is required to look up retryable tickets from a transaction")
+				}
+
+				if bridgeRaw == "" {
+					return errors.New("bridge is required to look up retryable tickets from a transaction")
+				}
+				if !common.IsHexAddress(bridgeRaw) {
+					return errors.New("invalid bridge address")
+				}
+
+				ctx, cancel := command.Context(cmd, timeout)
+				defer cancel()
+
+				parentClient, parentClientErr := ethclient.DialContext(ctx, parentRpc)
+				if parentClientErr != nil {
+					return parentClientErr
+				}
+
+				childClient, childClientErr := ethclient.DialContext(ctx, childRpc)
+				if childClientErr != nil {
+					return childClientErr
+				}
+
+				var ticketIDsErr error
+				ticketIDs, ticketIDsErr = GetRetryableTicketIDs(ctx, parentClient, childClient, common.HexToAddress(bridgeRaw), common.BytesToHash(txHashBytes))
+				if ticketIDsErr != nil {
+					return ticketIDsErr
+				}
+			}
+
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
+
+			if safeAddressRaw != "" {
+				var safeErr error
+				safeAddress, safeNonce, safeErr = resolveSafeFlags(cmd, timeout, childRpc, safeAddressRaw, &safeApi, safeOut, safeOperation, safeNonceRaw)
+				if safeErr != nil {
+					return safeErr
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
+			if safeAddressRaw != "" {
+				var safeNonceErr error
+				safeNonce, safeNonceErr = safeNonceOrNext(ctx, childRpc, safeAddress, safeNonce)
+				if safeNonceErr != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), safeNonceErr.Error())
+					return safeNonceErr
+				}
+			}
+
+			for _, ticketID := range ticketIDs {
+				fmt.Println("Extending retryable ticket", ticketID.Hex())
+				if safeAddressRaw != "" {
+					err := KeepalivePropose(ctx, sender, childRpc, ticketID, safeAddress, newSafeClient(safeApi, safeApiKey), safeOut, safeForce, safeOperation, safeNonce)
+					if errors.Is(err, ErrRetryableTicketNotFound) && len(ticketIDs) > 1 {
+						fmt.Println(err.Error())
+						continue
+					} else if err != nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), err.Error())
+						return err
+					}
+					// Proposals for later tickets in the same transaction take the following Safe nonces.
+					safeNonce = new(big.Int).Add(safeNonce, big.NewInt(1))
+				} else {
+					transaction, transactionErr := KeepaliveCall(ctx, sender, childRpc, ticketID)
+					if errors.Is(transactionErr, ErrRetryableTicketNotFound) && len(ticketIDs) > 1 {
+						fmt.Println(transactionErr.Error())
+						continue
+					} else if transactionErr != nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), transactionErr.Error())
+						return transactionErr
+					}
+
+					fmt.Println("Transaction sent:", transaction.Hash().Hex())
+				}
+			}
+
+			return nil
+		},
+	}
+
+	keepaliveCmd.Flags().StringVar(&password, "password", "", "Password to decrypt keyfile with")
+	keepaliveCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile to sign transaction with")
+	signer.AddSignerFlag(keepaliveCmd, &signerRaw)
+	keepaliveCmd.Flags().StringVar(&parentRpc, "l1-rpc", "", "RPC URL of the parent chain (only required with --tx)")
+	keepaliveCmd.Flags().StringVar(&childRpc, "l2-rpc", "", "RPC URL of the child chain the retryable ticket lives on")
+	keepaliveCmd.Flags().StringVar(&ticketRaw, "ticket", "", "Retryable ticket ID")
+	keepaliveCmd.Flags().StringVar(&bridgeRaw, "bridge", "", "Bridge contract of the child chain on the parent chain (only required with --tx)")
+	keepaliveCmd.Flags().StringVar(&txHashRaw, "tx", "", "Hash of the parent chain transaction that created the retryable tickets")
+	addSafeFlags(keepaliveCmd, &safeAddressRaw, &safeApi, &safeApiKey, &safeOut, &safeForce, &safeOperation, &safeNonceRaw)
+	command.AddTimeoutFlag(keepaliveCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(keepaliveCmd, &networkRaw, &networksFile)
+
+	return keepaliveCmd
+}
+
+func CreateBridgeCCTPCommand() *cobra.Command {
+	var timeout uint
+	var keyFile, password, signerRaw, sourceRpc, destinationRpc, tokenMessengerRaw, sourceMessageTransmitterRaw, messageTransmitterRaw, tokenRaw, toRaw, amountRaw, attestationApi, txHashRaw, approvalRaw string
+	var tokenMessengerAddress, sourceMessageTransmitterAddress, messageTransmitterAddress, token, to common.Address
+	var amount *big.Int
+	var txHash common.Hash
+	var destinationDomain uint32
+	var approvalMode ApprovalMode
+	var pollIntervalSeconds, timeoutSeconds uint
+
+	cctpCmd := &cobra.Command{
+		Use:   "cctp",
+		Short: "Bridge USDC with Circle's Cross-Chain Transfer Protocol",
+		Long: `Burn USDC on the source chain through the CCTP TokenMessenger, wait for the attestation service to attest the burn, and mint the USDC on the destination chain through its MessageTransmitter.
+
+The TokenMessenger is approved to spend the USDC being bridged first if needed (see --approval). To resume a transfer whose burn has already been mined, pass the burn transaction with --tx.`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if sourceRpc == "" {
+				return errors.New("source-rpc is required")
+			}
+
+			if destinationRpc == "" {
+				return errors.New("destination-rpc is required")
+			}
+
+			if !common.IsHexAddress(sourceMessageTransmitterRaw) {
+				return errors.New("invalid source message transmitter address")
+			}
+			sourceMessageTransmitterAddress = common.HexToAddress(sourceMessageTransmitterRaw)
+
+			if !common.IsHexAddress(messageTransmitterRaw) {
+				return errors.New("invalid message transmitter address")
+			}
+			messageTransmitterAddress = common.HexToAddress(messageTransmitterRaw)
+
+			if txHashRaw != "" {
+				txHashBytes, txHashErr := hex.DecodeString(strings.TrimPrefix(txHashRaw, "0x"))
+				if txHashErr != nil || len(txHashBytes) != common.HashLength {
+					return errors.New("invalid transaction hash")
+				}
+				txHash = common.BytesToHash(txHashBytes)
+			} else {
+				if !common.IsHexAddress(tokenMessengerRaw) {
+					return errors.New("invalid token messenger address")
+				}
+				tokenMessengerAddress = common.HexToAddress(tokenMessengerRaw)
+
+				if !common.IsHexAddress(tokenRaw) {
+					return errors.New("invalid token address")
+				}
+				token = common.HexToAddress(tokenRaw)
+
+				if !common.IsHexAddress(toRaw) {
+					return errors.New("invalid recipient address")
+				}
+				to = common.HexToAddress(toRaw)
+
+				amount = new(big.Int)
+				_, ok := amount.SetString(amountRaw, 10)
+				if !ok || amount.Sign() <= 0 {
+					return errors.New("invalid amount")
+				}
+
+				var approvalModeErr error
+				approvalMode, approvalModeErr = ParseApprovalMode(approvalRaw)
+				if approvalModeErr != nil {
+					return approvalModeErr
+				}
+			}
+
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
+
+			if pollIntervalSeconds == 0 {
+				return errors.New("poll-interval must be positive")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
+			if txHashRaw == "" {
+				fmt.Println("Burning", amount.String(), "of", token.Hex(), "for", to.Hex(), "on domain", destinationDomain)
+				transaction, transactionErr := CCTPDepositForBurnCall(ctx, tokenMessengerAddress, sender, sourceRpc, token, amount, destinationDomain, to, approvalMode)
+				if transactionErr != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), transactionErr.Error())
+					return transactionErr
+				}
+				txHash = transaction.Hash()
+			}
+
+			transactions, transactionsErr := CCTPCompleteTransfer(ctx, sourceMessageTransmitterAddress, messageTransmitterAddress, sender, sourceRpc, destinationRpc, txHash, attestationApi, time.Duration(pollIntervalSeconds)*time.Second, time.Duration(timeoutSeconds)*time.Second)
+			if transactionsErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), transactionsErr.Error())
+				fmt.Fprintln(cmd.ErrOrStderr(), "Resume the transfer with --tx", txHash.Hex())
+				return transactionsErr
+			}
+
+			for _, transaction := range transactions {
+				fmt.Println("Transaction sent:", transaction.Hash().Hex())
+			}
+
+			return nil
+		},
+	}
+
+	cctpCmd.Flags().StringVar(&password, "password", "", "Password to decrypt keyfile with")
+	cctpCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile to sign transactions with")
+	signer.AddSignerFlag(cctpCmd, &signerRaw)
+	cctpCmd.Flags().StringVar(&sourceRpc, "source-rpc", "", "RPC URL of the chain to burn USDC on")
+	cctpCmd.Flags().StringVar(&destinationRpc, "destination-rpc", "", "RPC URL of the chain to mint USDC on")
+	cctpCmd.Flags().StringVar(&tokenMessengerRaw, "token-messenger", "", "TokenMessenger address on the source chain")
+	cctpCmd.Flags().StringVar(&sourceMessageTransmitterRaw, "source-message-transmitter", "", "MessageTransmitter address on the source chain, whose messages are minted")
+	cctpCmd.Flags().StringVar(&messageTransmitterRaw, "message-transmitter", "", "MessageTransmitter address on the destination chain")
+	cctpCmd.Flags().StringVar(&tokenRaw, "token", "", "USDC address on the source chain")
+	cctpCmd.Flags().StringVar(&toRaw, "to", "", "Recipient address on the destination chain")
+	cctpCmd.Flags().StringVar(&amountRaw, "amount", "", "Amount of USDC to bridge")
+	cctpCmd.Flags().Uint32Var(&destinationDomain, "destination-domain", CCTP_DOMAIN_ARBITRUM, "CCTP domain of the destination chain (0 for Ethereum, 3 for Arbitrum)")
+	cctpCmd.Flags().StringVar(&attestationApi, "attestation-api", CCTP_ATTESTATION_API, "URL of the CCTP attestation service (use "+CCTP_SANDBOX_ATTESTATION_API+" for testnets)")
+	cctpCmd.Flags().UintVar(&pollIntervalSeconds, "poll-interval", 30, "Seconds between attestation requests")
+	cctpCmd.Flags().UintVar(&timeoutSeconds, "attestation-timeout", 0, "Seconds to wait for the attestation before giving up (0 waits indefinitely)")
+	cctpCmd.Flags().StringVar(&txHashRaw, "tx", "", "Hash of an already mined burn transaction, to resume a transfer")
+	addApprovalFlags(cctpCmd, &approvalRaw, nil)
+
+	command.AddTimeoutFlag(cctpCmd, &timeout, 0)
+	return cctpCmd
+}
+
+func CreateBridgeQuoteCommand() *cobra.Command {
+	quoteCmd := &cobra.Command{
+		Use:   "quote",
+		Short: "Estimate the fees of a transfer without sending it",
+		Long:  `Estimate the gas limit, max submission cost and gas price bid of every retryable ticket a transfer would create, and the ETH and fee token it requires. Nothing is signed or sent, so only the address of the sender is needed. The quote is printed as JSON.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	quoteCmd.AddCommand(CreateBridgeQuoteNativeTokenCommand())
+	quoteCmd.AddCommand(CreateBridgeQuoteERC20Command())
+	quoteCmd.AddCommand(CreateBridgeQuoteL1ToL3Command())
+
+	return quoteCmd
+}
+
+func printQuote(cmd *cobra.Command, quote *BridgeQuote) error {
+	quoteJSON, quoteJSONErr := json.MarshalIndent(quote, "", "  ")
+	if quoteJSONErr != nil {
+		return quoteJSONErr
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(quoteJSON))
+	return nil
+}
+
+func CreateBridgeQuoteNativeTokenCommand() *cobra.Command {
+	var timeout uint
+	var fromRaw, l1Rpc, l2Rpc, toRaw, l2CallValueRaw, l2CalldataRaw, networkRaw, networksFile string
+	params := &QuoteParams{Type: NativeTokenQuote}
+
+	quoteCmd := &cobra.Command{
+		Use:   "native-token",
+		Short: "Quote a native token deposit from L1 to L2",
+		Long:  `Quote a native token deposit through the inbox of a chain with a custom fee token, as sent by "bridge native-token l1-to-l2"`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				_, child, parent, networkErr := resolveNetwork(networkRaw, networksFile)
+				if networkErr != nil {
+					return networkErr
+				}
+				setRpcFromNetwork(&l1Rpc, parent)
+				setRpcFromNetwork(&l2Rpc, child)
+			}
+
+			if !common.IsHexAddress(fromRaw) {
+				return errors.New("invalid sender address")
+			}
+			params.From = common.HexToAddress(fromRaw)
+
+			if !common.IsHexAddress(toRaw) {
+				return errors.New("invalid recipient address")
+			}
+			params.To = common.HexToAddress(toRaw)
+
+			params.Amount = new(big.Int)
+			if l2CallValueRaw != "" {
+				_, ok := params.Amount.SetString(l2CallValueRaw, 10)
+				if !ok {
+					return errors.New("invalid L2 call value")
+				}
+			}
+
+			if l2CalldataRaw != "" {
+				var err error
+				params.Calldata, err = hex.DecodeString(l2CalldataRaw)
+				if err != nil {
+					return err
+				}
+			}
+
+			if l1Rpc == "" {
+				return errors.New("l1-rpc is required")
+			}
+			params.L1Rpc = l1Rpc
+
+			if l2Rpc == "" {
+				return errors.New("l2-rpc is required")
+			}
+			params.L2Rpc = l2Rpc
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			quote, quoteErr := Quote(ctx, params)
+			if quoteErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), quoteErr.Error())
+				return quoteErr
+			}
+
+			return printQuote(cmd, quote)
+		},
+	}
+
+	quoteCmd.Flags().StringVar(&fromRaw, "from", "", "Address of the sender")
+	quoteCmd.Flags().StringVar(&l1Rpc, "l1-rpc", "", "L1 RPC URL")
+	quoteCmd.Flags().StringVar(&l2Rpc, "l2-rpc", "", "L2 RPC URL")
+	quoteCmd.Flags().StringVar(&toRaw, "to", "", "Recipient or contract address")
+	quoteCmd.Flags().StringVar(&l2CallValueRaw, "amount", "", "L2 call value")
+	quoteCmd.Flags().StringVar(&l2CalldataRaw, "l2-calldata", "", "Calldata to send")
+	command.AddTimeoutFlag(quoteCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(quoteCmd, &networkRaw, &networksFile)
+
+	return quoteCmd
+}
+
+func CreateBridgeQuoteERC20Command() *cobra.Command {
+	var timeout uint
+	var fromRaw, l1Rpc, l2Rpc, routerRaw, tokenAddressRaw, toRaw, amountRaw, networkRaw, networksFile string
+	params := &QuoteParams{Type: ERC20Quote}
+
+	quoteCmd := &cobra.Command{
+		Use:   "erc20",
+		Short: "Quote an ERC20 deposit from L1 to L2",
+		Long:  `Quote an ERC20 deposit through the L1 gateway router, as sent by "bridge erc20 l1-to-l2"`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				_, child, parent, networkErr := resolveNetwork(networkRaw, networksFile)
+				if networkErr != nil {
+					return networkErr
+				}
+				tokenBridge, tokenBridgeErr := tokenBridgeOf(child)
+				if tokenBridgeErr != nil {
+					return tokenBridgeErr
+				}
+				setAddressFromNetwork(&routerRaw, tokenBridge.ParentGatewayRouter)
+				setRpcFromNetwork(&l1Rpc, parent)
+				setRpcFromNetwork(&l2Rpc, child)
+				if !cmd.Flags().Changed("custom-native-token") && child.NativeToken != nil {
+					params.CustomNativeToken = *child.NativeToken != (common.Address{})
+				}
+			}
+
+			if !common.IsHexAddress(fromRaw) {
+				return errors.New("invalid sender address")
+			}
+			params.From = common.HexToAddress(fromRaw)
+
+			if !common.IsHexAddress(routerRaw) {
+				return errors.New("invalid router address")
+			}
+			params.Router = common.HexToAddress(routerRaw)
+
+			if !common.IsHexAddress(toRaw) {
+				return errors.New("invalid recipient address")
+			}
+			params.To = common.HexToAddress(toRaw)
+
+			if !common.IsHexAddress(tokenAddressRaw) {
+				return errors.New("invalid token address")
+			}
+			params.Token = common.HexToAddress(tokenAddressRaw)
+
+			params.Amount = new(big.Int)
+			if amountRaw != "" {
+				_, ok := params.Amount.SetString(amountRaw, 10)
+				if !ok {
+					return errors.New("invalid amount")
+				}
+			}
+
+			if l1Rpc == "" {
+				return errors.New("l1-rpc is required")
+			}
+			params.L1Rpc = l1Rpc
+
+			if l2Rpc == "" {
+				return errors.New("l2-rpc is required")
+			}
+			params.L2Rpc = l2Rpc
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			quote, quoteErr := Quote(ctx, params)
+			if quoteErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), quoteErr.Error())
+				return quoteErr
+			}
+
+			return printQuote(cmd, quote)
+		},
+	}
+
+	quoteCmd.Flags().StringVar(&fromRaw, "from", "", "Address of the sender")
+	quoteCmd.Flags().StringVar(&l1Rpc, "l1-rpc", "", "L1 RPC URL")
+	quoteCmd.Flags().StringVar(&l2Rpc, "l2-rpc", "", "L2 RPC URL")
+	quoteCmd.Flags().StringVar(&routerRaw, "router", "", "Router address")
+	quoteCmd.Flags().StringVar(&toRaw, "to", "", "Recipient address")
+	quoteCmd.Flags().StringVar(&tokenAddressRaw, "token", "", "Token address")
+	quoteCmd.Flags().StringVar(&amountRaw, "amount", "", "Amount to send")
+	quoteCmd.Flags().BoolVar(&params.CustomNativeToken, "custom-native-token", false, "Is custom native token")
+	command.AddTimeoutFlag(quoteCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(quoteCmd, &networkRaw, &networksFile)
+
+	return quoteCmd
+}
+
+func CreateBridgeQuoteL1ToL3Command() *cobra.Command {
+	var timeout uint
+	var fromRaw, l1TokenRaw, l3FeeTokenL1AddrRaw, l1l2RouterRaw, l2l3RouterOrInboxRaw, toRaw, amountRaw, l3CalldataRaw, l1Rpc, l2Rpc, l3Rpc, teleporterAddressRaw, networkRaw, networksFile string
+	params := &QuoteParams{Type: TeleportQuote, TeleportParams: &TeleportParams{}}
+
+	quoteCmd := &cobra.Command{
+		Use:   "l1-to-l3",
+		Short: "Quote a teleport from L1 to L3",
+		Long:  `Quote a teleport from L1 to L3 through the L1 teleporter, as sent by "bridge native-token l1-to-l3"`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if networkRaw != "" {
+				if networkErr := setTeleportFlagsFromNetwork(networkRaw, networksFile, l1TokenRaw, l3FeeTokenL1AddrRaw, &teleporterAddressRaw, &l1l2RouterRaw, &l2l3RouterOrInboxRaw, &l1Rpc, &l2Rpc, &l3Rpc); networkErr != nil {
+					return networkErr
+				}
+			}
+
+			if !common.IsHexAddress(fromRaw) {
+				return fmt.Errorf("invalid \"from\" address: %s", fromRaw)
+			}
+			params.From = common.HexToAddress(fromRaw)
+
+			teleportParams := params.TeleportParams
+
+			if l3CalldataRaw != "" {
+				var l3CallDataErr error
+				teleportParams.L3CallData, l3CallDataErr = hex.DecodeString(l3CalldataRaw)
+				if l3CallDataErr != nil {
+					return l3CallDataErr
+				}
+			}
+
+			if !common.IsHexAddress(toRaw) {
+				return fmt.Errorf("invalid \"to\" address: %s", toRaw)
+			}
+			teleportParams.To = common.HexToAddress(toRaw)
+
+			if !common.IsHexAddress(l1TokenRaw) {
+				return fmt.Errorf("invalid \"l1-token\" address: %s", l1TokenRaw)
+			}
+			teleportParams.L1Token = common.HexToAddress(l1TokenRaw)
+
+			if !common.IsHexAddress(l3FeeTokenL1AddrRaw) {
+				return fmt.Errorf("invalid \"l3-fee-token-l1-addr\" address: %s", l3FeeTokenL1AddrRaw)
+			}
+			teleportParams.L3FeeTokenL1Addr = common.HexToAddress(l3FeeTokenL1AddrRaw)
+
+			if !common.IsHexAddress(l1l2RouterRaw) {
+				return fmt.Errorf("invalid \"l1l2-router\" address: %s", l1l2RouterRaw)
+			}
+			teleportParams.L1l2Router = common.HexToAddress(l1l2RouterRaw)
+
+			if !common.IsHexAddress(l2l3RouterOrInboxRaw) {
+				return fmt.Errorf("invalid \"l2l3-router-or-inbox\" address: %s", l2l3RouterOrInboxRaw)
+			}
+			teleportParams.L2l3RouterOrInbox = common.HexToAddress(l2l3RouterOrInboxRaw)
+
+			teleportParams.Amount = new(big.Int)
+			if amountRaw != "" {
+				_, ok := teleportParams.Amount.SetString(amountRaw, 10)
+				if !ok {
+					return fmt.Errorf("invalid amount: %s", amountRaw)
+				}
+			}
+
+			if !common.IsHexAddress(teleporterAddressRaw) {
+				return fmt.Errorf("invalid teleporter address: %s", teleporterAddressRaw)
+			}
+			params.Teleporter = common.HexToAddress(teleporterAddressRaw)
+
+			params.L1Rpc = l1Rpc
+			params.L2Rpc = l2Rpc
+			params.L3Rpc = l3Rpc
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, timeout)
+			defer cancel()
+
+			quote, quoteErr := Quote(ctx, params)
+			if quoteErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), quoteErr.Error())
+				return quoteErr
+			}
+
+			return printQuote(cmd, quote)
+		},
+	}
+
+	quoteCmd.Flags().StringVar(&fromRaw, "from", "", "Address of the sender")
+	quoteCmd.Flags().StringVar(&l1TokenRaw, "l1-token", "", "L1 token address")
+	quoteCmd.Flags().StringVar(&l3FeeTokenL1AddrRaw, "l1l3-fee-token", "", "L3 fee token L1 address")
+	quoteCmd.Flags().StringVar(&l1l2RouterRaw, "l1l2-router", "", "L1L2 router address")
+	quoteCmd.Flags().StringVar(&l2l3RouterOrInboxRaw, "l2l3-router", "", "L2L3 router or inbox address")
+	quoteCmd.Flags().StringVar(&toRaw, "to", "", "Recipient address")
+	quoteCmd.Flags().StringVar(&amountRaw, "amount", "", "Amount to send")
+	quoteCmd.Flags().StringVar(&l3CalldataRaw, "l3-calldata", "", "Calldata to send")
+	quoteCmd.Flags().StringVar(&l1Rpc, "l1-rpc", "", "L1 RPC URL")
+	quoteCmd.Flags().StringVar(&l2Rpc, "l2-rpc", "", "L2 RPC URL")
+	quoteCmd.Flags().StringVar(&l3Rpc, "l3-rpc", "", "L3 RPC URL")
+	quoteCmd.Flags().StringVar(&teleporterAddressRaw, "teleporter", "", "Teleporter contract address")
+	command.AddTimeoutFlag(quoteCmd, &timeout, command.DEFAULT_TIMEOUT)
+	addNetworkFlags(quoteCmd, &networkRaw, &networksFile)
+
+	return quoteCmd
+}
@@ -22,3 +22,15 @@ var DEFAULT_GAS_LIMIT_PERCENT_INCREASE = big.NewInt(100)
 
 // Source: https://github.com/OffchainLabs/arbitrum-sdk/blob/0da65020438fc3e46728ea182f1b4dcf04e3cb7f/src/lib/message/L1ToL2MessageGasEstimator.ts#L27
 var DEFAULT_SUBMISSION_FEE_PERCENT_INCREASE = big.NewInt(300)
+
+// Source: https://github.com/OffchainLabs/nitro-contracts/blob/main/src/precompiles/ArbSys.sol#L10
+var ARB_SYS_ADDRESS = common.HexToAddress("0x0000000000000000000000000000000000000064")
+
+// Source: https://github.com/OffchainLabs/nitro-contracts/blob/main/src/precompiles/ArbRetryableTx.sol#L12
+var ARB_RETRYABLE_TX_ADDRESS = common.HexToAddress("0x000000000000000000000000000000000000006E")
+
+// Number of L1 blocks to query at a time when searching the outbox for confirmed send roots
+var OUTBOX_LOG_SEARCH_WINDOW = uint64(10_000)
+
+// Number of L1 blocks to search back for a confirmed send root before giving up
+var DEFAULT_OUTBOX_LOOKBACK = uint64(1_000_000)
@@ -4,16 +4,20 @@ import (
 	"context"
 	"math/big"
 
+	"github.com/G7DAO/protocol/signer"
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 // Function to send a transaction
-func SendTransaction(client *ethclient.Client, key *keystore.Key, password string, calldata []byte, to string, value *big.Int) (*types.Transaction, error) {
-	chainID, chainIDErr := client.ChainID(context.Background())
+func SendTransaction(ctx context.Context, client Backend, sender signer.Signer, calldata []byte, to string, value *big.Int) (*types.Transaction, error) {
+	return SendTransactionWithGasLimit(ctx, client, sender, calldata, to, value, 0)
+}
+
+// Same as SendTransaction, but uses the given gas limit instead of estimating it (unless gasLimit is 0).
+func SendTransactionWithGasLimit(ctx context.Context, client Backend, sender signer.Signer, calldata []byte, to string, value *big.Int, gasLimit uint64) (*types.Transaction, error) {
+	chainID, chainIDErr := client.ChainID(ctx)
 	if chainIDErr != nil {
 		return nil, chainIDErr
 	}
@@ -21,28 +25,31 @@ func SendTransaction(client *ethclient.Client, key *keystore.Key, password strin
 	recipientAddress := common.HexToAddress(to)
 
 	callMsg := ethereum.CallMsg{
-		From:  key.Address,
+		From:  sender.Address(),
 		To:    &recipientAddress,
 		Value: value,
 		Data:  calldata,
 	}
 
-	gasLimit, gasLimitErr := client.EstimateGas(context.Background(), callMsg)
-	if gasLimitErr != nil {
-		return nil, gasLimitErr
+	if gasLimit == 0 {
+		estimatedGasLimit, gasLimitErr := client.EstimateGas(ctx, callMsg)
+		if gasLimitErr != nil {
+			return nil, gasLimitErr
+		}
+		gasLimit = estimatedGasLimit
 	}
 
-	baseFee, baseFeeErr := client.SuggestGasPrice(context.Background())
+	baseFee, baseFeeErr := client.SuggestGasPrice(ctx)
 	if baseFeeErr != nil {
 		return nil, baseFeeErr
 	}
 
-	gasTipCap, gasTipCapErr := client.SuggestGasTipCap(context.Background())
+	gasTipCap, gasTipCapErr := client.SuggestGasTipCap(ctx)
 	if gasTipCapErr != nil {
 		return nil, gasTipCapErr
 	}
 
-	nonce, nonceErr := client.PendingNonceAt(context.Background(), key.Address)
+	nonce, nonceErr := client.PendingNonceAt(ctx, sender.Address())
 	if nonceErr != nil {
 		return nil, nonceErr
 	}
@@ -58,12 +65,12 @@ func SendTransaction(client *ethclient.Client, key *keystore.Key, password strin
 		Data:      calldata,
 	})
 
-	signedTransaction, signedTransactionErr := types.SignTx(rawTransaction, types.NewLondonSigner(chainID), key.PrivateKey)
+	signedTransaction, signedTransactionErr := sender.SignTx(ctx, rawTransaction, chainID)
 	if signedTransactionErr != nil {
 		return nil, signedTransactionErr
 	}
 
-	sendTransactionErr := client.SendTransaction(context.Background(), signedTransaction)
+	sendTransactionErr := client.SendTransaction(ctx, signedTransaction)
 	if sendTransactionErr != nil {
 		return nil, sendTransactionErr
 	}
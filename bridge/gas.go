@@ -11,31 +11,30 @@ import (
 	"github.com/G7DAO/protocol/bindings/NodeInterface"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-func SetTeleporterGasParams(teleportParams *TeleportParams, teleporterAddress common.Address, l1Client *ethclient.Client, l2Client *ethclient.Client, l3Client *ethclient.Client, key *keystore.Key, teleportationType TeleportationType) (*TeleportParams, error) {
-	l1BaseFee, l1BaseFeeErr := l1Client.SuggestGasPrice(context.Background())
+func SetTeleporterGasParams(ctx context.Context, teleportParams *TeleportParams, teleporterAddress common.Address, l1Client Backend, l2Client Backend, l3Client Backend, from common.Address, teleportationType TeleportationType) (*TeleportParams, error) {
+	l1BaseFee, l1BaseFeeErr := l1Client.SuggestGasPrice(ctx)
 	if l1BaseFeeErr != nil {
 		return teleportParams, l1BaseFeeErr
 	}
 	l1BaseFee = PercentIncrease(l1BaseFee, DEFAULT_GAS_PRICE_PERCENT_INCREASE)
 
-	l2BaseFee, l2BaseFeeErr := l2Client.SuggestGasPrice(context.Background())
+	l2BaseFee, l2BaseFeeErr := l2Client.SuggestGasPrice(ctx)
 	if l2BaseFeeErr != nil {
 		return teleportParams, l2BaseFeeErr
 	}
 	l2BaseFee = PercentIncrease(l2BaseFee, DEFAULT_GAS_PRICE_PERCENT_INCREASE)
 
-	l3BaseFee, l3BaseFeeErr := l3Client.SuggestGasPrice(context.Background())
+	l3BaseFee, l3BaseFeeErr := l3Client.SuggestGasPrice(ctx)
 	if l3BaseFeeErr != nil {
 		return teleportParams, l3BaseFeeErr
 	}
 	l3BaseFee = PercentIncrease(l3BaseFee, DEFAULT_GAS_PRICE_PERCENT_INCREASE)
 
-	l2FowarderAddress, l2FowarderAddressErr := GetForwarderAddress(l1Client, teleporterAddress, key, teleportParams.L2l3RouterOrInbox, teleportParams.To)
+	l2FowarderAddress, l2FowarderAddressErr := GetForwarderAddress(ctx, l1Client, teleporterAddress, from, teleportParams.L2l3RouterOrInbox, teleportParams.To)
 	if l2FowarderAddressErr != nil {
 		return teleportParams, l2FowarderAddressErr
 	}
@@ -45,7 +44,7 @@ func SetTeleporterGasParams(teleportParams *TeleportParams, teleporterAddress co
 	teleportParams.GasParams.L3GasPriceBid = l3BaseFee
 
 	// 1. Costs to Bridge token from L1 to L2
-	l1l2TokenBridgeGasLimit, l1l2TokenBridgeMaxSubmissionCost, l1l2TokenBridgeErr := GetL1l2TokenBridgeGasParams(l1Client, l2Client, l1BaseFee, key, teleportParams, teleporterAddress, l2FowarderAddress)
+	l1l2TokenBridgeGasLimit, l1l2TokenBridgeMaxSubmissionCost, l1l2TokenBridgeErr := GetL1l2TokenBridgeGasParams(ctx, l1Client, l2Client, l1BaseFee, from, teleportParams, teleporterAddress, l2FowarderAddress)
 	if l1l2TokenBridgeErr != nil {
 		return teleportParams, l1l2TokenBridgeErr
 	}
@@ -53,7 +52,7 @@ func SetTeleporterGasParams(teleportParams *TeleportParams, teleporterAddress co
 	teleportParams.GasParams.L1l2TokenBridgeGasLimit = l1l2TokenBridgeGasLimit
 
 	// 2. Costs to Forward call from L2 to L3
-	l2ForwarderFactoryGasLimit, l2ForwarderFactoryMaxSubmissionCost, l2ForwarderFactoryMaxSubmissionCostErr := GetL2ForwaderGasParams(l1Client, key, teleportParams, l2FowarderAddress)
+	l2ForwarderFactoryGasLimit, l2ForwarderFactoryMaxSubmissionCost, l2ForwarderFactoryMaxSubmissionCostErr := GetL2ForwaderGasParams(ctx, l1Client, from, teleportParams, l2FowarderAddress)
 	if l2ForwarderFactoryMaxSubmissionCostErr != nil {
 		return teleportParams, l2ForwarderFactoryMaxSubmissionCostErr
 	}
@@ -61,7 +60,7 @@ func SetTeleporterGasParams(teleportParams *TeleportParams, teleporterAddress co
 	teleportParams.GasParams.L2ForwarderFactoryGasLimit = l2ForwarderFactoryGasLimit
 
 	// 3. Costs to bridge token from L2 to L3
-	l2l3TokenBridgeGasLimit, l2l3TokenBridgeMaxSubmissionCost, l2l3TokenBridgeErr := GetL2L3TokenBridgeGasParams(l2Client, l3Client, l2BaseFee, key, teleportParams, l2FowarderAddress, teleportationType)
+	l2l3TokenBridgeGasLimit, l2l3TokenBridgeMaxSubmissionCost, l2l3TokenBridgeErr := GetL2L3TokenBridgeGasParams(ctx, l2Client, l3Client, l2BaseFee, from, teleportParams, l2FowarderAddress, teleportationType)
 	if l2l3TokenBridgeErr != nil {
 		return teleportParams, l2l3TokenBridgeErr
 	}
@@ -70,7 +69,7 @@ func SetTeleporterGasParams(teleportParams *TeleportParams, teleporterAddress co
 
 	// 4. Costs to Fee token bridge from L1 to L2
 	if teleportationType == NonFeeTokenToCustomFee {
-		l1l2FeeTokenBridgeGasLimit, l1l2FeeTokenBridgeMaxSubmissionCost, l1l2FeeTokenBridgeErr := GetL1l2FeeTokenBridgeGasParams(l1Client, l2Client, l1BaseFee, key, teleportParams, &teleportParams.L1l2Router, teleporterAddress, l2FowarderAddress)
+		l1l2FeeTokenBridgeGasLimit, l1l2FeeTokenBridgeMaxSubmissionCost, l1l2FeeTokenBridgeErr := GetL1l2FeeTokenBridgeGasParams(ctx, l1Client, l2Client, l1BaseFee, from, teleportParams, &teleportParams.L1l2Router, teleporterAddress, l2FowarderAddress)
 		if l1l2FeeTokenBridgeErr != nil {
 			return teleportParams, l1l2FeeTokenBridgeErr
 		}
@@ -84,8 +83,8 @@ func SetTeleporterGasParams(teleportParams *TeleportParams, teleporterAddress co
 	return teleportParams, nil
 }
 
-func GetL2ForwaderGasParams(client *ethclient.Client, key *keystore.Key, teleportParams *TeleportParams, l2ForwarderAddress common.Address) (uint64, *big.Int, error) {
-	baseFee, baseFeeErr := client.SuggestGasPrice(context.Background())
+func GetL2ForwaderGasParams(ctx context.Context, client Backend, from common.Address, teleportParams *TeleportParams, l2ForwarderAddress common.Address) (uint64, *big.Int, error) {
+	baseFee, baseFeeErr := client.SuggestGasPrice(ctx)
 	if baseFeeErr != nil {
 		return uint64(0), nil, baseFeeErr
 	}
@@ -96,7 +95,7 @@ func GetL2ForwaderGasParams(client *ethclient.Client, key *keystore.Key, telepor
 	}
 
 	l2ForwarderParams := L2ForwarderParams{
-		Owner:             key.Address,
+		Owner:             from,
 		L2Token:           teleportParams.L1Token,
 		L3FeeTokenL2Addr:  teleportParams.L3FeeTokenL1Addr,
 		RouterOrInbox:     teleportParams.L2l3RouterOrInbox,
@@ -121,13 +120,13 @@ func GetL2ForwaderGasParams(client *ethclient.Client, key *keystore.Key, telepor
 	return l2ForwarderFactoryGasLimit, l2ForwarderFactoryMaxSubmissionCost, nil
 }
 
-func GetL1l2TokenBridgeGasParams(l1Client *ethclient.Client, l2Client *ethclient.Client, l1BaseFee *big.Int, key *keystore.Key, teleportParams *TeleportParams, teleporterAddress common.Address, l2ForwarderAddress common.Address) (uint64, *big.Int, error) {
+func GetL1l2TokenBridgeGasParams(ctx context.Context, l1Client Backend, l2Client Backend, l1BaseFee *big.Int, from common.Address, teleportParams *TeleportParams, teleporterAddress common.Address, l2ForwarderAddress common.Address) (uint64, *big.Int, error) {
 	router, routerErr := ArbitrumL1OrbitGatewayRouter.NewL1OrbitGatewayRouter(teleportParams.L1l2Router, l1Client)
 	if routerErr != nil {
 		return uint64(0), nil, routerErr
 	}
 
-	gatewayAddress, gatewayAddressErr := router.GetGateway(nil, teleportParams.L1Token)
+	gatewayAddress, gatewayAddressErr := router.GetGateway(&bind.CallOpts{Context: ctx}, teleportParams.L1Token)
 	if gatewayAddressErr != nil {
 		return uint64(0), nil, gatewayAddressErr
 	}
@@ -137,7 +136,7 @@ func GetL1l2TokenBridgeGasParams(l1Client *ethclient.Client, l2Client *ethclient
 		return uint64(0), nil, gatewayErr
 	}
 
-	outboundCalldata, outboundCalldataErr := gateway.GetOutboundCalldata(nil, teleportParams.L1Token, teleporterAddress, l2ForwarderAddress, teleportParams.Amount, []byte{})
+	outboundCalldata, outboundCalldataErr := gateway.GetOutboundCalldata(&bind.CallOpts{Context: ctx}, teleportParams.L1Token, teleporterAddress, l2ForwarderAddress, teleportParams.Amount, []byte{})
 	if outboundCalldataErr != nil {
 		return uint64(0), nil, outboundCalldataErr
 	}
@@ -149,12 +148,12 @@ func GetL1l2TokenBridgeGasParams(l1Client *ethclient.Client, l2Client *ethclient
 
 	// Source: https://github.com/OffchainLabs/arbitrum-sdk/blob/0da65020438fc3e46728ea182f1b4dcf04e3cb7f/src/lib/message/L1ToL2MessageGasEstimator.ts#L154
 	senderDeposit := big.NewInt(0).Add(teleportParams.Amount, ONE_ETHER)
-	counterpartGatewayAddress, counterpartGatewayAddressErr := gateway.CounterpartGateway(nil)
+	counterpartGatewayAddress, counterpartGatewayAddressErr := gateway.CounterpartGateway(&bind.CallOpts{Context: ctx})
 	if counterpartGatewayAddressErr != nil {
 		return uint64(0), nil, counterpartGatewayAddressErr
 	}
 
-	l1l2TokenBridgeGasLimit, l1l2TokenBridgeGasLimitErr := CalculateRetryableGasLimit(l2Client, gatewayAddress, senderDeposit, counterpartGatewayAddress, big.NewInt(0), l2ForwarderAddress, RemapL1Address(teleporterAddress), outboundCalldata)
+	l1l2TokenBridgeGasLimit, l1l2TokenBridgeGasLimitErr := CalculateRetryableGasLimit(ctx, l2Client, gatewayAddress, senderDeposit, counterpartGatewayAddress, big.NewInt(0), l2ForwarderAddress, RemapL1Address(teleporterAddress), outboundCalldata)
 	if l1l2TokenBridgeGasLimitErr != nil {
 		return uint64(0), nil, l1l2TokenBridgeGasLimitErr
 	}
@@ -162,7 +161,7 @@ func GetL1l2TokenBridgeGasParams(l1Client *ethclient.Client, l2Client *ethclient
 	return l1l2TokenBridgeGasLimit, l1l2TokenBridgeMaxSubmissionCost, nil
 }
 
-func GetL2L3TokenBridgeGasParams(l2Client *ethclient.Client, l3Client *ethclient.Client, l2BaseFee *big.Int, key *keystore.Key, teleportParams *TeleportParams, l2ForwarderAddress common.Address, teleportationType TeleportationType) (uint64, *big.Int, error) {
+func GetL2L3TokenBridgeGasParams(ctx context.Context, l2Client Backend, l3Client Backend, l2BaseFee *big.Int, from common.Address, teleportParams *TeleportParams, l2ForwarderAddress common.Address, teleportationType TeleportationType) (uint64, *big.Int, error) {
 	outboundCalldata := teleportParams.L3CallData
 	var outboundCalldataErr error
 
@@ -172,7 +171,7 @@ func GetL2L3TokenBridgeGasParams(l2Client *ethclient.Client, l3Client *ethclient
 			return uint64(0), nil, routerErr
 		}
 
-		gatewayAddress, gatewayAddressErr := router.GetGateway(nil, teleportParams.L1Token)
+		gatewayAddress, gatewayAddressErr := router.GetGateway(&bind.CallOpts{Context: ctx}, teleportParams.L1Token)
 		if gatewayAddressErr != nil {
 			return uint64(0), nil, gatewayAddressErr
 		}
@@ -182,7 +181,7 @@ func GetL2L3TokenBridgeGasParams(l2Client *ethclient.Client, l3Client *ethclient
 			return uint64(0), nil, gatewayErr
 		}
 
-		outboundCalldata, outboundCalldataErr = gateway.GetOutboundCalldata(nil, teleportParams.L1Token, teleportParams.To, l2ForwarderAddress, teleportParams.Amount, teleportParams.L3CallData)
+		outboundCalldata, outboundCalldataErr = gateway.GetOutboundCalldata(&bind.CallOpts{Context: ctx}, teleportParams.L1Token, teleportParams.To, l2ForwarderAddress, teleportParams.Amount, teleportParams.L3CallData)
 		if outboundCalldataErr != nil {
 			return uint64(0), nil, outboundCalldataErr
 		}
@@ -196,7 +195,7 @@ func GetL2L3TokenBridgeGasParams(l2Client *ethclient.Client, l3Client *ethclient
 	// Source: https://github.com/OffchainLabs/arbitrum-sdk/blob/0da65020438fc3e46728ea182f1b4dcf04e3cb7f/src/lib/message/L1ToL2MessageGasEstimator.ts#L154-L155
 	senderDeposit := big.NewInt(0).Add(teleportParams.Amount, ONE_ETHER)
 
-	l2l3TokenBridgeGasLimit, l2l3TokenBridgeGasLimitErr := CalculateRetryableGasLimit(l3Client, l2ForwarderAddress, senderDeposit, teleportParams.To, teleportParams.Amount, teleportParams.To, teleportParams.To, teleportParams.L3CallData)
+	l2l3TokenBridgeGasLimit, l2l3TokenBridgeGasLimitErr := CalculateRetryableGasLimit(ctx, l3Client, l2ForwarderAddress, senderDeposit, teleportParams.To, teleportParams.Amount, teleportParams.To, teleportParams.To, teleportParams.L3CallData)
 	if l2l3TokenBridgeGasLimitErr != nil {
 		return uint64(0), nil, l2l3TokenBridgeGasLimitErr
 	}
@@ -204,13 +203,13 @@ func GetL2L3TokenBridgeGasParams(l2Client *ethclient.Client, l3Client *ethclient
 	return l2l3TokenBridgeGasLimit, l2l3TokenBridgeMaxSubmissionCost, nil
 }
 
-func GetL1l2FeeTokenBridgeGasParams(l1Client *ethclient.Client, l2Client *ethclient.Client, l1BaseFee *big.Int, key *keystore.Key, teleportParams *TeleportParams, l1l2RouterAddress *common.Address, teleporterAddress common.Address, l2ForwarderAddress common.Address) (uint64, *big.Int, error) {
+func GetL1l2FeeTokenBridgeGasParams(ctx context.Context, l1Client Backend, l2Client Backend, l1BaseFee *big.Int, from common.Address, teleportParams *TeleportParams, l1l2RouterAddress *common.Address, teleporterAddress common.Address, l2ForwarderAddress common.Address) (uint64, *big.Int, error) {
 	router, routerErr := ArbitrumL1OrbitGatewayRouter.NewL1OrbitGatewayRouter(teleportParams.L1l2Router, l1Client)
 	if routerErr != nil {
 		return uint64(0), nil, routerErr
 	}
 
-	gatewayAddress, gatewayAddressErr := router.GetGateway(nil, teleportParams.L1Token)
+	gatewayAddress, gatewayAddressErr := router.GetGateway(&bind.CallOpts{Context: ctx}, teleportParams.L1Token)
 	if gatewayAddressErr != nil {
 		return uint64(0), nil, gatewayAddressErr
 	}
@@ -221,7 +220,7 @@ func GetL1l2FeeTokenBridgeGasParams(l1Client *ethclient.Client, l2Client *ethcli
 	}
 
 	feeAmount := big.NewInt(0).Mul(big.NewInt(int64(teleportParams.GasParams.L2l3TokenBridgeGasLimit)), teleportParams.GasParams.L3GasPriceBid)
-	outboundCalldata, outboundCalldataErr := gateway.GetOutboundCalldata(nil, teleportParams.L3FeeTokenL1Addr, teleporterAddress, l2ForwarderAddress, feeAmount, []byte{})
+	outboundCalldata, outboundCalldataErr := gateway.GetOutboundCalldata(&bind.CallOpts{Context: ctx}, teleportParams.L3FeeTokenL1Addr, teleporterAddress, l2ForwarderAddress, feeAmount, []byte{})
 	if outboundCalldataErr != nil {
 		return uint64(0), nil, outboundCalldataErr
 	}
@@ -235,7 +234,7 @@ func GetL1l2FeeTokenBridgeGasParams(l1Client *ethclient.Client, l2Client *ethcli
 	senderDeposit := big.NewInt(0).Add(teleportParams.Amount, ONE_ETHER)
 	counterpartGatewayAddress := RemapL1Address(gatewayAddress)
 
-	l1l2FeeTokenBridgeGasLimit, l1l2FeeTokenBridgeGasLimitErr := CalculateRetryableGasLimit(l2Client, gatewayAddress, senderDeposit, counterpartGatewayAddress, big.NewInt(0), counterpartGatewayAddress, RemapL1Address(key.Address), outboundCalldata)
+	l1l2FeeTokenBridgeGasLimit, l1l2FeeTokenBridgeGasLimitErr := CalculateRetryableGasLimit(ctx, l2Client, gatewayAddress, senderDeposit, counterpartGatewayAddress, big.NewInt(0), counterpartGatewayAddress, RemapL1Address(from), outboundCalldata)
 	if l1l2FeeTokenBridgeGasLimitErr != nil {
 		return uint64(0), nil, l1l2FeeTokenBridgeGasLimitErr
 	}
@@ -244,7 +243,7 @@ func GetL1l2FeeTokenBridgeGasParams(l1Client *ethclient.Client, l2Client *ethcli
 }
 
 // Source: https://github.com/OffchainLabs/nitro-contracts/blob/main/src/node-interface/NodeInterface.sol#L25
-func CalculateRetryableGasLimit(client *ethclient.Client, sender common.Address, deposit *big.Int, to common.Address, l2CallValue *big.Int, excessFeeRefundAddress common.Address, callValueRefundAddress common.Address, calldata []byte) (uint64, error) {
+func CalculateRetryableGasLimit(ctx context.Context, client Backend, sender common.Address, deposit *big.Int, to common.Address, l2CallValue *big.Int, excessFeeRefundAddress common.Address, callValueRefundAddress common.Address, calldata []byte) (uint64, error) {
 	nodeInterfaceAbi, nodeInterfaceAbiErr := abi.JSON(strings.NewReader(NodeInterface.NodeInterfaceABI))
 	if nodeInterfaceAbiErr != nil {
 		return uint64(0), nodeInterfaceAbiErr
@@ -265,7 +264,7 @@ func CalculateRetryableGasLimit(client *ethclient.Client, sender common.Address,
 		Data:  retryableTicketCalldata,
 	}
 
-	retryableTicketGasLimit, retryableTicketGasLimitErr := client.EstimateGas(context.Background(), retryableTicketCallMsg)
+	retryableTicketGasLimit, retryableTicketGasLimitErr := client.EstimateGas(ctx, retryableTicketCallMsg)
 	if retryableTicketGasLimitErr != nil {
 		return uint64(0), retryableTicketGasLimitErr
 	}
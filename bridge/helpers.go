@@ -1,24 +1,24 @@
 package bridge
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
 	"github.com/G7DAO/protocol/bindings/L1Teleporter"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-func GetForwarderAddress(client *ethclient.Client, teleporterAddress common.Address, key *keystore.Key, l2l3RouterOrInbox common.Address, to common.Address) (common.Address, error) {
+func GetForwarderAddress(ctx context.Context, client Backend, teleporterAddress common.Address, from common.Address, l2l3RouterOrInbox common.Address, to common.Address) (common.Address, error) {
 	teleporter, teleporterErr := L1Teleporter.NewL1Teleporter(teleporterAddress, client)
 	if teleporterErr != nil {
 		return common.Address{}, teleporterErr
 	}
 
 	l2ForwarderAddress, l2ForwarderAddressErr := teleporter.L2ForwarderAddress(
-		nil,
-		key.Address,
+		&bind.CallOpts{Context: ctx},
+		from,
 		l2l3RouterOrInbox,
 		to,
 	)
@@ -0,0 +1,114 @@
+package bridge
+
+import (
+	"fmt"
+
+	"github.com/G7DAO/protocol/networks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+// Adds the --network and --networks-file flags to a bridge command.
+func addNetworkFlags(cmd *cobra.Command, network *string, networksFile *string) {
+	cmd.Flags().StringVar(network, "network", "", "Network to bridge to: mainnet, testnet or a chain ID. Fills in contract addresses and RPC URLs that are not set explicitly")
+	cmd.Flags().StringVar(networksFile, "networks-file", "", "JSON file with network configurations that add to or replace the built-in ones (optional)")
+}
+
+// Returns the registry and the configuration of the network selected with --network and --networks-file, along with
+// its parent chain if it has one.
+func resolveNetwork(network string, networksFile string) (networks.Registry, *networks.BridgeNetworkConfig, *networks.BridgeNetworkConfig, error) {
+	registry, registryErr := networks.LoadRegistry(networksFile)
+	if registryErr != nil {
+		return nil, nil, nil, registryErr
+	}
+
+	child, childErr := registry.Resolve(network)
+	if childErr != nil {
+		return nil, nil, nil, childErr
+	}
+
+	var parent *networks.BridgeNetworkConfig
+	if child.ParentChainID != 0 {
+		var parentErr error
+		parent, parentErr = registry.Get(child.ParentChainID)
+		if parentErr != nil {
+			return nil, nil, nil, parentErr
+		}
+	}
+
+	return registry, child, parent, nil
+}
+
+// Sets the flags of a teleport from L1 to L3 that were not given explicitly from the L3 network selected with --network
+// and --networks-file: the teleporter and L1-to-L2 router of its parent chain, the L2-to-L3 router or inbox, and the
+// RPC URLs of the three chains.
+func setTeleportFlagsFromNetwork(network string, networksFile string, l1TokenRaw string, l3FeeTokenL1AddrRaw string, teleporterAddressRaw, l1l2RouterRaw, l2l3RouterOrInboxRaw, l1Rpc, l2Rpc, l3Rpc *string) error {
+	registry, l3Network, l2Network, networkErr := resolveNetwork(network, networksFile)
+	if networkErr != nil {
+		return networkErr
+	}
+	if l2Network == nil {
+		return fmt.Errorf("network %s (%d) has no parent chain", l3Network.Name, l3Network.ChainID)
+	}
+	l1Network, l1NetworkErr := registry.Parent(l2Network)
+	if l1NetworkErr != nil {
+		return l1NetworkErr
+	}
+	if l2Network.Teleporter == nil {
+		return fmt.Errorf("network %s (%d) has no teleporter", l2Network.Name, l2Network.ChainID)
+	}
+	l1l2TokenBridge, l1l2TokenBridgeErr := tokenBridgeOf(l2Network)
+	if l1l2TokenBridgeErr != nil {
+		return l1l2TokenBridgeErr
+	}
+	l2l3TokenBridge, l2l3TokenBridgeErr := tokenBridgeOf(l3Network)
+	if l2l3TokenBridgeErr != nil {
+		return l2l3TokenBridgeErr
+	}
+	l3EthBridge, l3EthBridgeErr := ethBridgeOf(l3Network)
+	if l3EthBridgeErr != nil {
+		return l3EthBridgeErr
+	}
+
+	setAddressFromNetwork(teleporterAddressRaw, l2Network.Teleporter.L1Teleporter)
+	setAddressFromNetwork(l1l2RouterRaw, l1l2TokenBridge.ParentGatewayRouter)
+	// Teleporting the L3 fee token itself goes through the L3 inbox rather than its token bridge.
+	if l1TokenRaw != "" && l3FeeTokenL1AddrRaw != "" && common.HexToAddress(l1TokenRaw) == common.HexToAddress(l3FeeTokenL1AddrRaw) {
+		setAddressFromNetwork(l2l3RouterOrInboxRaw, l3EthBridge.Inbox)
+	} else {
+		setAddressFromNetwork(l2l3RouterOrInboxRaw, l2l3TokenBridge.ParentGatewayRouter)
+	}
+	setRpcFromNetwork(l1Rpc, l1Network)
+	setRpcFromNetwork(l2Rpc, l2Network)
+	setRpcFromNetwork(l3Rpc, l3Network)
+
+	return nil
+}
+
+// Sets an address flag from the network registry, unless it was given explicitly.
+func setAddressFromNetwork(raw *string, address common.Address) {
+	if *raw == "" && address != (common.Address{}) {
+		*raw = address.Hex()
+	}
+}
+
+// Sets an RPC flag from the network registry, unless it was given explicitly.
+func setRpcFromNetwork(raw *string, network *networks.BridgeNetworkConfig) {
+	if *raw == "" && network != nil {
+		*raw = network.Rpc()
+	}
+}
+
+func ethBridgeOf(network *networks.BridgeNetworkConfig) (*networks.EthBridge, error) {
+	if network.EthBridge == nil {
+		return nil, fmt.Errorf("network %s (%d) has no bridge contracts", network.Name, network.ChainID)
+	}
+	return network.EthBridge, nil
+}
+
+func tokenBridgeOf(network *networks.BridgeNetworkConfig) (*networks.TokenBridge, error) {
+	if network.TokenBridge == nil {
+		return nil, fmt.Errorf("network %s (%d) has no token bridge", network.Name, network.ChainID)
+	}
+	return network.TokenBridge, nil
+}
@@ -0,0 +1,284 @@
+package bridge
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/G7DAO/protocol/bindings/ArbSys"
+	"github.com/G7DAO/protocol/bindings/ArbitrumOutbox"
+	"github.com/G7DAO/protocol/bindings/NodeInterface"
+	"github.com/G7DAO/protocol/safe"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var ErrL2ToL1MessageNotConfirmed = errors.New("L2-to-L1 message has not been confirmed on L1 yet")
+
+// OutboxMessage is an L2-to-L1 message, as emitted by ArbSys, together with its state on the L1 outbox.
+type OutboxMessage struct {
+	Event     *ArbSys.ArbSysL2ToL1Tx
+	Confirmed bool
+	Executed  bool
+	Proof     [][32]byte
+}
+
+// Returns the L2ToL1Tx events that ArbSys emitted in the given L2 transaction.
+func GetL2ToL1TxEvents(ctx context.Context, l2Client Backend, txHash common.Hash) ([]*ArbSys.ArbSysL2ToL1Tx, error) {
+	receipt, receiptErr := l2Client.TransactionReceipt(ctx, txHash)
+	if receiptErr != nil {
+		return nil, receiptErr
+	}
+
+	arbSysFilterer, arbSysFiltererErr := ArbSys.NewArbSysFilterer(ARB_SYS_ADDRESS, l2Client)
+	if arbSysFiltererErr != nil {
+		return nil, arbSysFiltererErr
+	}
+
+	arbSysAbi, arbSysAbiErr := abi.JSON(strings.NewReader(ArbSys.ArbSysABI))
+	if arbSysAbiErr != nil {
+		return nil, arbSysAbiErr
+	}
+	l2ToL1TxTopic := arbSysAbi.Events["L2ToL1Tx"].ID
+
+	events := []*ArbSys.ArbSysL2ToL1Tx{}
+	for _, log := range receipt.Logs {
+		if log.Address != ARB_SYS_ADDRESS || len(log.Topics) == 0 || log.Topics[0] != l2ToL1TxTopic {
+			continue
+		}
+
+		event, eventErr := arbSysFilterer.ParseL2ToL1Tx(*log)
+		if eventErr != nil {
+			return nil, eventErr
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// Returns the number of L2-to-L1 messages covered by the most recent send root that was confirmed on L1. Messages
+// whose position is below this count can be executed on the outbox.
+//
+// The outbox emits SendRootUpdated every time the rollup confirms an assertion. The L2 block referenced by the most
+// recent of these events carries the size of the send merkle tree at that point (sendCount). The search walks back
+// from the latest L1 block in windows of OUTBOX_LOG_SEARCH_WINDOW blocks, up to lookback blocks.
+func GetConfirmedSendCount(ctx context.Context, l1Client Backend, l2Client Backend, outboxAddress common.Address, lookback uint64) (uint64, common.Hash, error) {
+	outboxAbi, outboxAbiErr := abi.JSON(strings.NewReader(ArbitrumOutbox.OutboxABI))
+	if outboxAbiErr != nil {
+		return 0, common.Hash{}, outboxAbiErr
+	}
+	sendRootUpdatedTopic := outboxAbi.Events["SendRootUpdated"].ID
+
+	latestBlock, latestBlockErr := l1Client.BlockNumber(ctx)
+	if latestBlockErr != nil {
+		return 0, common.Hash{}, latestBlockErr
+	}
+
+	earliestBlock := uint64(0)
+	if latestBlock > lookback {
+		earliestBlock = latestBlock - lookback
+	}
+
+	var sendRootUpdated *types.Log
+	for toBlock := latestBlock; sendRootUpdated == nil; {
+		fromBlock := earliestBlock
+		if toBlock-earliestBlock > OUTBOX_LOG_SEARCH_WINDOW {
+			fromBlock = toBlock - OUTBOX_LOG_SEARCH_WINDOW
+		}
+
+		logs, logsErr := l1Client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(fromBlock),
+			ToBlock:   new(big.Int).SetUint64(toBlock),
+			Addresses: []common.Address{outboxAddress},
+			Topics:    [][]common.Hash{{sendRootUpdatedTopic}},
+		})
+		if logsErr != nil {
+			return 0, common.Hash{}, logsErr
+		}
+		if len(logs) > 0 {
+			sendRootUpdated = &logs[len(logs)-1]
+			break
+		}
+
+		if fromBlock == earliestBlock {
+			return 0, common.Hash{}, fmt.Errorf("no SendRootUpdated event found on outbox %s in the last %d blocks", outboxAddress.Hex(), lookback)
+		}
+		toBlock = fromBlock - 1
+	}
+
+	sendRoot := sendRootUpdated.Topics[1]
+	l2BlockHash := sendRootUpdated.Topics[2]
+
+	// Arbitrum chains store the state of the send merkle tree in the block header: the send root in extraData and the
+	// send count in the first 8 bytes of mixHash.
+	// Source: https://github.com/OffchainLabs/go-ethereum/blob/master/core/types/arb_types.go (DeserializeHeaderExtraInformation)
+	l2Header, l2HeaderErr := l2Client.HeaderByHash(ctx, l2BlockHash)
+	if l2HeaderErr != nil {
+		return 0, common.Hash{}, l2HeaderErr
+	}
+	if common.BytesToHash(l2Header.Extra) != sendRoot {
+		return 0, common.Hash{}, fmt.Errorf("L2 block %s has no matching send root (is the L2 RPC an Arbitrum chain?)", l2BlockHash.Hex())
+	}
+
+	return binary.BigEndian.Uint64(l2Header.MixDigest[:8]), sendRoot, nil
+}
+
+// Looks up the state of an L2-to-L1 message on the outbox, and builds its merkle proof through the NodeInterface
+// precompile if the message has been confirmed.
+func GetOutboxMessage(ctx context.Context, l1Client Backend, l2Client Backend, outboxAddress common.Address, event *ArbSys.ArbSysL2ToL1Tx, lookback uint64) (*OutboxMessage, error) {
+	messages, messagesErr := getOutboxMessages(ctx, l1Client, l2Client, outboxAddress, []*ArbSys.ArbSysL2ToL1Tx{event}, lookback)
+	if messagesErr != nil {
+		return nil, messagesErr
+	}
+	return messages[0], nil
+}
+
+// Looks up the state of the given L2-to-L1 messages on the outbox. The confirmed send count is only searched for once,
+// and only if one of the messages has not been executed yet.
+func getOutboxMessages(ctx context.Context, l1Client Backend, l2Client Backend, outboxAddress common.Address, events []*ArbSys.ArbSysL2ToL1Tx, lookback uint64) ([]*OutboxMessage, error) {
+	outbox, outboxErr := ArbitrumOutbox.NewOutbox(outboxAddress, l1Client)
+	if outboxErr != nil {
+		return nil, outboxErr
+	}
+
+	nodeInterface, nodeInterfaceErr := NodeInterface.NewNodeInterface(NODE_INTERFACE_ADDRESS, l2Client)
+	if nodeInterfaceErr != nil {
+		return nil, nodeInterfaceErr
+	}
+
+	var sendCount *uint64
+	var sendRoot common.Hash
+
+	messages := make([]*OutboxMessage, len(events))
+	for i, event := range events {
+		message := &OutboxMessage{Event: event}
+		messages[i] = message
+
+		spent, spentErr := outbox.IsSpent(&bind.CallOpts{Context: ctx}, event.Position)
+		if spentErr != nil {
+			return nil, spentErr
+		}
+		if spent {
+			message.Confirmed = true
+			message.Executed = true
+			continue
+		}
+
+		if sendCount == nil {
+			count, root, sendCountErr := GetConfirmedSendCount(ctx, l1Client, l2Client, outboxAddress, lookback)
+			if sendCountErr != nil {
+				return nil, sendCountErr
+			}
+			sendCount, sendRoot = &count, root
+		}
+		if event.Position.Cmp(new(big.Int).SetUint64(*sendCount)) >= 0 {
+			continue
+		}
+
+		outboxProof, outboxProofErr := nodeInterface.ConstructOutboxProof(&bind.CallOpts{Context: ctx}, *sendCount, event.Position.Uint64())
+		if outboxProofErr != nil {
+			return nil, outboxProofErr
+		}
+		if outboxProof.Root != sendRoot {
+			return nil, fmt.Errorf("outbox proof root %s does not match confirmed send root %s", common.Hash(outboxProof.Root).Hex(), sendRoot.Hex())
+		}
+
+		message.Confirmed = true
+		message.Proof = outboxProof.Proof
+	}
+
+	return messages, nil
+}
+
+func GetOutboxExecuteCalldata(message *OutboxMessage) ([]byte, error) {
+	if !message.Confirmed {
+		return nil, ErrL2ToL1MessageNotConfirmed
+	}
+
+	outboxAbi, outboxAbiErr := abi.JSON(strings.NewReader(ArbitrumOutbox.OutboxABI))
+	if outboxAbiErr != nil {
+		return nil, outboxAbiErr
+	}
+
+	event := message.Event
+
+	// function executeTransaction(bytes32[] calldata proof, uint256 index, address l2Sender, address to, uint256 l2Block, uint256 l1Block, uint256 l2Timestamp, uint256 value, bytes calldata data) external;
+	return outboxAbi.Pack("executeTransaction", message.Proof, event.Position, event.Caller, event.Destination, event.ArbBlockNum, event.EthBlockNum, event.Timestamp, event.Callvalue, event.Data)
+}
+
+// Returns the outbox state of every L2-to-L1 message sent by the given L2 transaction.
+func GetOutboxMessages(ctx context.Context, l1Rpc string, l2Rpc string, outboxAddress common.Address, l2TxHash common.Hash, lookback uint64) ([]*OutboxMessage, error) {
+	l1Client, l1ClientErr := ethclient.DialContext(ctx, l1Rpc)
+	if l1ClientErr != nil {
+		return nil, l1ClientErr
+	}
+
+	l2Client, l2ClientErr := ethclient.DialContext(ctx, l2Rpc)
+	if l2ClientErr != nil {
+		return nil, l2ClientErr
+	}
+
+	events, eventsErr := GetL2ToL1TxEvents(ctx, l2Client, l2TxHash)
+	if eventsErr != nil {
+		return nil, eventsErr
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("no L2ToL1Tx event found in transaction %s", l2TxHash.Hex())
+	}
+
+	return getOutboxMessages(ctx, l1Client, l2Client, outboxAddress, events, lookback)
+}
+
+func ClaimCall(ctx context.Context, outboxAddress common.Address, sender signer.Signer, l1Rpc string, message *OutboxMessage) (*types.Transaction, error) {
+	l1Client, l1ClientErr := ethclient.DialContext(ctx, l1Rpc)
+	if l1ClientErr != nil {
+		return nil, l1ClientErr
+	}
+
+	executeData, executeDataErr := GetOutboxExecuteCalldata(message)
+	if executeDataErr != nil {
+		return nil, executeDataErr
+	}
+
+	fmt.Println("Sending transaction...")
+	transaction, transactionErr := SendTransaction(ctx, l1Client, sender, executeData, outboxAddress.Hex(), big.NewInt(0))
+	if transactionErr != nil {
+		fmt.Fprintln(os.Stderr, transactionErr.Error())
+		return nil, transactionErr
+	}
+	fmt.Println("Transaction sent! Transaction hash:", transaction.Hash().Hex())
+
+	fmt.Println("Waiting for transaction to be mined...")
+	_, receiptErr := WaitForTransaction(ctx, l1Client, transaction, "claim")
+	if receiptErr != nil {
+		fmt.Fprintln(os.Stderr, receiptErr.Error())
+		return nil, receiptErr
+	}
+	fmt.Println("Transaction mined!")
+
+	return transaction, nil
+}
+
+func ClaimPropose(ctx context.Context, outboxAddress common.Address, sender signer.Signer, l1Rpc string, message *OutboxMessage, safeAddress common.Address, safeClient *safe.Client, safeOut string, safeForce bool, safeOperation uint8, safeNonce *big.Int) error {
+	l1Client, l1ClientErr := ethclient.DialContext(ctx, l1Rpc)
+	if l1ClientErr != nil {
+		return l1ClientErr
+	}
+
+	executeData, executeDataErr := GetOutboxExecuteCalldata(message)
+	if executeDataErr != nil {
+		return executeDataErr
+	}
+
+	return safe.CreateProposal(ctx, l1Client, sender, safeClient, safeOut, safeForce, safeAddress, outboxAddress, executeData, big.NewInt(0), safe.OperationType(safeOperation), safeNonce)
+}
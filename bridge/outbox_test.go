@@ -0,0 +1,154 @@
+package bridge
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/G7DAO/protocol/bindings/ArbSys"
+	"github.com/G7DAO/protocol/bindings/ArbitrumOutbox"
+	"github.com/G7DAO/protocol/bindings/NodeInterface"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Returns an L2 header carrying the state of the send merkle tree the way Arbitrum chains do.
+func arbitrumHeader(sendRoot common.Hash, sendCount uint64) *types.Header {
+	header := &types.Header{Number: big.NewInt(1), Extra: sendRoot.Bytes()}
+	binary.BigEndian.PutUint64(header.MixDigest[:8], sendCount)
+	return header
+}
+
+func sendRootUpdatedLog(t *testing.T, outboxAddress common.Address, blockNumber uint64, sendRoot common.Hash, l2BlockHash common.Hash) types.Log {
+	outboxAbi, outboxAbiErr := ArbitrumOutbox.OutboxMetaData.GetAbi()
+	if outboxAbiErr != nil {
+		t.Fatal(outboxAbiErr)
+	}
+	return types.Log{
+		Address:     outboxAddress,
+		BlockNumber: blockNumber,
+		Topics:      []common.Hash{outboxAbi.Events["SendRootUpdated"].ID, sendRoot, l2BlockHash},
+	}
+}
+
+func TestGetConfirmedSendCount(t *testing.T) {
+	ctx := context.Background()
+	outboxAddress := common.HexToAddress("0x0B9857ae2D4A3DBe74ffE1d7DF045bb7F96E4840")
+	oldRoot, oldBlock := common.HexToHash("0x01"), common.HexToHash("0xb1")
+	sendRoot, l2Block := common.HexToHash("0x02"), common.HexToHash("0xb2")
+
+	l1 := &fakeBackend{
+		latestBlock: 3*OUTBOX_LOG_SEARCH_WINDOW + 500,
+		logs: []types.Log{
+			sendRootUpdatedLog(t, outboxAddress, 200, oldRoot, oldBlock),
+			sendRootUpdatedLog(t, outboxAddress, 300, sendRoot, l2Block),
+			// Events of other contracts are ignored.
+			sendRootUpdatedLog(t, common.HexToAddress("0x1"), 2*OUTBOX_LOG_SEARCH_WINDOW, oldRoot, oldBlock),
+		},
+	}
+	l2 := &fakeBackend{
+		headers: map[common.Hash]*types.Header{
+			oldBlock: arbitrumHeader(oldRoot, 10),
+			l2Block:  arbitrumHeader(sendRoot, 42),
+		},
+	}
+
+	sendCount, confirmedRoot, sendCountErr := GetConfirmedSendCount(ctx, l1, l2, outboxAddress, DEFAULT_OUTBOX_LOOKBACK)
+	if sendCountErr != nil {
+		t.Fatal(sendCountErr)
+	}
+	if sendCount != 42 || confirmedRoot != sendRoot {
+		t.Errorf("Expected send count 42 with root %s, got %d with root %s", sendRoot.Hex(), sendCount, confirmedRoot.Hex())
+	}
+	// The search walks back from the latest block one window at a time until it finds the event.
+	if len(l1.queries) != 4 || l1.queries[0].ToBlock.Uint64() != l1.latestBlock || l1.queries[3].FromBlock.Uint64() != 0 {
+		t.Errorf("Unexpected log queries: %+v", l1.queries)
+	}
+
+	l1.queries = nil
+	if _, _, sendCountErr := GetConfirmedSendCount(ctx, l1, l2, outboxAddress, 2*OUTBOX_LOG_SEARCH_WINDOW); sendCountErr == nil {
+		t.Error("Expected an error when the event is older than the lookback")
+	}
+	if len(l1.queries) != 2 || l1.queries[1].FromBlock.Uint64() != l1.latestBlock-2*OUTBOX_LOG_SEARCH_WINDOW {
+		t.Errorf("Unexpected log queries: %+v", l1.queries)
+	}
+
+	// The header of an L2 that is not an Arbitrum chain does not carry the send root.
+	l2.headers[l2Block] = &types.Header{Number: big.NewInt(1)}
+	if _, _, sendCountErr := GetConfirmedSendCount(ctx, l1, l2, outboxAddress, DEFAULT_OUTBOX_LOOKBACK); sendCountErr == nil {
+		t.Error("Expected an error when the L2 header has no matching send root")
+	}
+}
+
+func TestGetOutboxMessages(t *testing.T) {
+	ctx := context.Background()
+	outboxAddress := common.HexToAddress("0x0B9857ae2D4A3DBe74ffE1d7DF045bb7F96E4840")
+	sendRoot, l2Block := common.HexToHash("0x02"), common.HexToHash("0xb2")
+	proof := [][32]byte{common.HexToHash("0xaa"), common.HexToHash("0xbb")}
+
+	outboxAbi, outboxAbiErr := ArbitrumOutbox.OutboxMetaData.GetAbi()
+	if outboxAbiErr != nil {
+		t.Fatal(outboxAbiErr)
+	}
+	nodeInterfaceAbi, nodeInterfaceAbiErr := NodeInterface.NodeInterfaceMetaData.GetAbi()
+	if nodeInterfaceAbiErr != nil {
+		t.Fatal(nodeInterfaceAbiErr)
+	}
+
+	l1 := &fakeBackend{
+		latestBlock: 1000,
+		logs:        []types.Log{sendRootUpdatedLog(t, outboxAddress, 900, sendRoot, l2Block)},
+		call: func(to common.Address, data []byte) ([]byte, error) {
+			// Only the message at position 1 has been executed.
+			args, unpackErr := outboxAbi.Methods["isSpent"].Inputs.Unpack(data[4:])
+			if unpackErr != nil {
+				return nil, unpackErr
+			}
+			return outboxAbi.Methods["isSpent"].Outputs.Pack(args[0].(*big.Int).Int64() == 1)
+		},
+	}
+	l2 := &fakeBackend{
+		headers: map[common.Hash]*types.Header{l2Block: arbitrumHeader(sendRoot, 42)},
+		call: func(to common.Address, data []byte) ([]byte, error) {
+			args, unpackErr := nodeInterfaceAbi.Methods["constructOutboxProof"].Inputs.Unpack(data[4:])
+			if unpackErr != nil {
+				return nil, unpackErr
+			}
+			if args[0].(uint64) != 42 {
+				t.Errorf("Proof was constructed for send count %d, expected 42", args[0])
+			}
+			return nodeInterfaceAbi.Methods["constructOutboxProof"].Outputs.Pack([32]byte{}, [32]byte(sendRoot), proof)
+		},
+	}
+
+	events := []*ArbSys.ArbSysL2ToL1Tx{
+		{Position: big.NewInt(1)},
+		{Position: big.NewInt(41)},
+		{Position: big.NewInt(42)},
+	}
+	messages, messagesErr := getOutboxMessages(ctx, l1, l2, outboxAddress, events, DEFAULT_OUTBOX_LOOKBACK)
+	if messagesErr != nil {
+		t.Fatal(messagesErr)
+	}
+
+	if !messages[0].Executed || !messages[0].Confirmed {
+		t.Errorf("Expected message 1 to be executed, got %+v", messages[0])
+	}
+	if messages[1].Executed || !messages[1].Confirmed || len(messages[1].Proof) != len(proof) || messages[1].Proof[1] != proof[1] {
+		t.Errorf("Expected message 41 to be confirmed with its proof, got %+v", messages[1])
+	}
+	if messages[2].Executed || messages[2].Confirmed {
+		t.Errorf("Expected message 42 to be pending, got %+v", messages[2])
+	}
+	if len(l1.queries) != 1 {
+		t.Errorf("Expected the confirmed send count to be searched for once, got %d log queries", len(l1.queries))
+	}
+
+	// A proof against another root than the confirmed send root is rejected.
+	l1.logs = []types.Log{sendRootUpdatedLog(t, outboxAddress, 900, common.HexToHash("0x03"), l2Block)}
+	l2.headers[l2Block] = arbitrumHeader(common.HexToHash("0x03"), 42)
+	if _, messagesErr := getOutboxMessages(ctx, l1, l2, outboxAddress, events[1:2], DEFAULT_OUTBOX_LOOKBACK); messagesErr == nil {
+		t.Error("Expected an error for a proof that does not match the confirmed send root")
+	}
+}
@@ -0,0 +1,137 @@
+package bridge
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type QuoteType string
+
+const (
+	NativeTokenQuote QuoteType = "native-token" // Native token deposit through the inbox of a custom fee token chain
+	ERC20Quote       QuoteType = "erc20"        // ERC20 deposit through the L1 gateway router
+	TeleportQuote    QuoteType = "l1-to-l3"     // Teleport from L1 to L3 through the L1 teleporter
+)
+
+// Currency that the cost of a retryable ticket is paid in.
+type FeeCurrency string
+
+const (
+	PaidInEth      FeeCurrency = "eth"
+	PaidInFeeToken FeeCurrency = "fee-token"
+)
+
+func (t TeleportationType) String() string {
+	switch t {
+	case Standard:
+		return "Standard"
+	case OnlyCustomFee:
+		return "OnlyCustomFee"
+	case NonFeeTokenToCustomFee:
+		return "NonFeeTokenToCustomFee"
+	default:
+		return "Unknown"
+	}
+}
+
+// Cost breakdown of a single retryable ticket created by a transfer. TotalCost is the max submission cost plus the gas
+// limit times the gas price bid.
+type RetryableQuote struct {
+	Leg               string      `json:"leg"`
+	GasLimit          uint64      `json:"gasLimit"`
+	MaxSubmissionCost *big.Int    `json:"maxSubmissionCost"`
+	GasPriceBid       *big.Int    `json:"gasPriceBid"`
+	TotalCost         *big.Int    `json:"totalCost"`
+	PaidIn            FeeCurrency `json:"paidIn"`
+}
+
+// Cost breakdown of a transfer. RequiredEth is the value sent with the transaction on the origin chain and
+// RequiredFeeToken is the fee token the sender must hold (and have approved) on the origin chain, on top of the amount
+// being transferred unless noted otherwise.
+type BridgeQuote struct {
+	Type              QuoteType        `json:"type"`
+	TeleportationType string           `json:"teleportationType,omitempty"`
+	Legs              []RetryableQuote `json:"legs"`
+	RequiredEth       *big.Int         `json:"requiredEth"`
+	RequiredFeeToken  *big.Int         `json:"requiredFeeToken"`
+}
+
+// Parameters of the transfer to quote. Which fields are used depends on Type:
+//   - NativeTokenQuote: To, Amount (the L2 call value), Calldata, L1Rpc and L2Rpc.
+//   - ERC20Quote: Router, Token, To, Amount, CustomNativeToken, L1Rpc and L2Rpc.
+//   - TeleportQuote: Teleporter, TeleportParams, L1Rpc, L2Rpc and L3Rpc.
+type QuoteParams struct {
+	Type              QuoteType
+	From              common.Address
+	To                common.Address
+	Amount            *big.Int
+	Calldata          []byte
+	Router            common.Address
+	Token             common.Address
+	CustomNativeToken bool
+	Teleporter        common.Address
+	TeleportParams    *TeleportParams
+	L1Rpc             string
+	L2Rpc             string
+	L3Rpc             string
+}
+
+func newRetryableQuote(leg string, gasLimit uint64, maxSubmissionCost *big.Int, gasPriceBid *big.Int, paidIn FeeCurrency) RetryableQuote {
+	totalCost := big.NewInt(0).Mul(big.NewInt(0).SetUint64(gasLimit), gasPriceBid)
+	totalCost.Add(totalCost, maxSubmissionCost)
+
+	return RetryableQuote{
+		Leg:               leg,
+		GasLimit:          gasLimit,
+		MaxSubmissionCost: maxSubmissionCost,
+		GasPriceBid:       gasPriceBid,
+		TotalCost:         totalCost,
+		PaidIn:            paidIn,
+	}
+}
+
+// Estimates the gas limits, submission costs and gas price bids of every retryable ticket a transfer would create, and
+// the ETH and fee token it requires, without signing or sending anything.
+func Quote(ctx context.Context, params *QuoteParams) (*BridgeQuote, error) {
+	bridger, bridgerErr := DialBridger(ctx, params.L1Rpc, params.L2Rpc, params.L3Rpc, nil)
+	if bridgerErr != nil {
+		return nil, bridgerErr
+	}
+
+	return bridger.Quote(ctx, params)
+}
+
+// Quotes a native token deposit through the inbox of a custom fee token chain. The retryable ticket is paid for in the
+// fee token, and RequiredFeeToken includes the L2 call value.
+func QuoteNativeTokenBridge(ctx context.Context, from common.Address, l1Rpc string, l2Rpc string, to common.Address, l2CallValue *big.Int, l2Calldata []byte) (*BridgeQuote, error) {
+	bridger, bridgerErr := DialBridger(ctx, l1Rpc, l2Rpc, "", nil)
+	if bridgerErr != nil {
+		return nil, bridgerErr
+	}
+
+	return bridger.QuoteNativeTokenBridge(ctx, from, to, l2CallValue, l2Calldata)
+}
+
+// Quotes an ERC20 deposit through the L1 gateway router. The retryable ticket is paid for in ETH, or in the fee token
+// when the child chain has a custom native token.
+func QuoteERC20Bridge(ctx context.Context, routerAddress common.Address, from common.Address, l1Rpc string, l2Rpc string, tokenAddress common.Address, to common.Address, amount *big.Int, customNativeToken bool) (*BridgeQuote, error) {
+	bridger, bridgerErr := DialBridger(ctx, l1Rpc, l2Rpc, "", nil)
+	if bridgerErr != nil {
+		return nil, bridgerErr
+	}
+
+	return bridger.QuoteERC20Bridge(ctx, routerAddress, from, tokenAddress, to, amount, customNativeToken)
+}
+
+// Quotes a teleport from L1 to L3. The teleport parameters are not modified. RequiredFeeToken is the fee token needed
+// on top of the amount, and is only non-zero when the L3 has a custom fee token.
+func QuoteTeleport(ctx context.Context, teleporterAddress common.Address, teleportParams *TeleportParams, from common.Address, l1Rpc string, l2Rpc string, l3Rpc string) (*BridgeQuote, error) {
+	bridger, bridgerErr := DialBridger(ctx, l1Rpc, l2Rpc, l3Rpc, nil)
+	if bridgerErr != nil {
+		return nil, bridgerErr
+	}
+
+	return bridger.QuoteTeleport(ctx, teleporterAddress, teleportParams, from)
+}
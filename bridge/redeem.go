@@ -0,0 +1,190 @@
+package bridge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/G7DAO/protocol/bindings/ArbRetryableTx"
+	"github.com/G7DAO/protocol/safe"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var ErrRetryableTicketNotFound = errors.New("retryable ticket not found (it was already redeemed, expired, or has not been created yet)")
+
+// Returns the IDs of the retryable tickets that the given parent chain transaction created on the child chain through
+// its bridge.
+func GetRetryableTicketIDs(ctx context.Context, parentClient Backend, childClient Backend, bridgeAddress common.Address, txHash common.Hash) ([]common.Hash, error) {
+	messages, messagesErr := GetParentToChildMessagesFromTx(ctx, parentClient, childClient, bridgeAddress, txHash)
+	if messagesErr != nil {
+		return nil, messagesErr
+	}
+
+	ticketIDs := []common.Hash{}
+	for _, message := range messages {
+		if message.Kind != L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX {
+			continue
+		}
+
+		ticketID, ticketIDErr := message.ChildTxHash()
+		if ticketIDErr != nil {
+			return nil, ticketIDErr
+		}
+		ticketIDs = append(ticketIDs, ticketID)
+	}
+
+	if len(ticketIDs) == 0 {
+		return nil, fmt.Errorf("no retryable tickets found in transaction %s", txHash.Hex())
+	}
+
+	return ticketIDs, nil
+}
+
+// Returns the timestamp at which the retryable ticket expires. If the ticket does not exist (anymore), the
+// ArbRetryableTx precompile reverts with NoTicketWithID and ErrRetryableTicketNotFound is returned.
+func GetRetryableTicketTimeout(ctx context.Context, client Backend, ticketID common.Hash) (*big.Int, error) {
+	arbRetryableTx, arbRetryableTxErr := ArbRetryableTx.NewArbRetryableTx(ARB_RETRYABLE_TX_ADDRESS, client)
+	if arbRetryableTxErr != nil {
+		return nil, arbRetryableTxErr
+	}
+
+	timeout, timeoutErr := arbRetryableTx.GetTimeout(&bind.CallOpts{Context: ctx}, ticketID)
+	if timeoutErr != nil {
+		arbRetryableTxAbi, arbRetryableTxAbiErr := abi.JSON(strings.NewReader(ArbRetryableTx.ArbRetryableTxABI))
+		if arbRetryableTxAbiErr != nil {
+			return nil, arbRetryableTxAbiErr
+		}
+
+		var dataErr rpc.DataError
+		if errors.As(timeoutErr, &dataErr) {
+			revertData, revertDataOk := dataErr.ErrorData().(string)
+			noTicketWithID := arbRetryableTxAbi.Errors["NoTicketWithID"].ID
+			if revertDataOk && bytes.HasPrefix(common.FromHex(revertData), noTicketWithID[:4]) {
+				return nil, ErrRetryableTicketNotFound
+			}
+		}
+
+		return nil, timeoutErr
+	}
+
+	return timeout, nil
+}
+
+func GetRedeemCalldata(ticketID common.Hash) ([]byte, error) {
+	arbRetryableTxAbi, arbRetryableTxAbiErr := abi.JSON(strings.NewReader(ArbRetryableTx.ArbRetryableTxABI))
+	if arbRetryableTxAbiErr != nil {
+		return nil, arbRetryableTxAbiErr
+	}
+
+	// function redeem(bytes32 ticketId) external returns (bytes32);
+	return arbRetryableTxAbi.Pack("redeem", ticketID)
+}
+
+func GetKeepaliveCalldata(ticketID common.Hash) ([]byte, error) {
+	arbRetryableTxAbi, arbRetryableTxAbiErr := abi.JSON(strings.NewReader(ArbRetryableTx.ArbRetryableTxABI))
+	if arbRetryableTxAbiErr != nil {
+		return nil, arbRetryableTxAbiErr
+	}
+
+	// function keepalive(bytes32 ticketId) external returns (uint256);
+	return arbRetryableTxAbi.Pack("keepalive", ticketID)
+}
+
+// Sends a transaction to the ArbRetryableTx precompile on the child chain and waits for it to be mined. leg names the
+// transaction in timeout errors, and a gasLimit of 0 lets the node estimate it.
+func sendArbRetryableTxTransaction(ctx context.Context, leg string, sender signer.Signer, childRpc string, ticketID common.Hash, calldata []byte, gasLimit uint64) (*types.Transaction, error) {
+	client, clientErr := ethclient.DialContext(ctx, childRpc)
+	if clientErr != nil {
+		return nil, clientErr
+	}
+
+	timeout, timeoutErr := GetRetryableTicketTimeout(ctx, client, ticketID)
+	if timeoutErr != nil {
+		return nil, timeoutErr
+	}
+	fmt.Println("Retryable ticket", ticketID.Hex(), "expires at", timeout.String())
+
+	fmt.Println("Sending transaction...")
+	transaction, transactionErr := SendTransactionWithGasLimit(ctx, client, sender, calldata, ARB_RETRYABLE_TX_ADDRESS.Hex(), big.NewInt(0), gasLimit)
+	if transactionErr != nil {
+		fmt.Fprintln(os.Stderr, transactionErr.Error())
+		return nil, transactionErr
+	}
+	fmt.Println("Transaction sent! Transaction hash:", transaction.Hash().Hex())
+
+	fmt.Println("Waiting for transaction to be mined...")
+	receipt, receiptErr := WaitForTransaction(ctx, client, transaction, leg)
+	if receiptErr != nil {
+		fmt.Fprintln(os.Stderr, receiptErr.Error())
+		return nil, receiptErr
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", transaction.Hash().Hex())
+	}
+	fmt.Println("Transaction mined!")
+
+	return transaction, nil
+}
+
+func proposeArbRetryableTxTransaction(ctx context.Context, sender signer.Signer, childRpc string, ticketID common.Hash, calldata []byte, safeAddress common.Address, safeClient *safe.Client, safeOut string, safeForce bool, safeOperation uint8, safeNonce *big.Int) error {
+	client, clientErr := ethclient.DialContext(ctx, childRpc)
+	if clientErr != nil {
+		return clientErr
+	}
+
+	_, timeoutErr := GetRetryableTicketTimeout(ctx, client, ticketID)
+	if timeoutErr != nil {
+		return timeoutErr
+	}
+
+	return safe.CreateProposal(ctx, client, sender, safeClient, safeOut, safeForce, safeAddress, ARB_RETRYABLE_TX_ADDRESS, calldata, big.NewInt(0), safe.OperationType(safeOperation), safeNonce)
+}
+
+// Manually redeems a retryable ticket whose auto-redeem failed. All the gas of the redeem transaction that is not
+// spent by the precompile itself is donated to the retry, so gasLimit should cover the execution of the ticket.
+func RedeemCall(ctx context.Context, sender signer.Signer, childRpc string, ticketID common.Hash, gasLimit uint64) (*types.Transaction, error) {
+	redeemData, redeemDataErr := GetRedeemCalldata(ticketID)
+	if redeemDataErr != nil {
+		return nil, redeemDataErr
+	}
+
+	return sendArbRetryableTxTransaction(ctx, "redeem", sender, childRpc, ticketID, redeemData, gasLimit)
+}
+
+func RedeemPropose(ctx context.Context, sender signer.Signer, childRpc string, ticketID common.Hash, safeAddress common.Address, safeClient *safe.Client, safeOut string, safeForce bool, safeOperation uint8, safeNonce *big.Int) error {
+	redeemData, redeemDataErr := GetRedeemCalldata(ticketID)
+	if redeemDataErr != nil {
+		return redeemDataErr
+	}
+
+	return proposeArbRetryableTxTransaction(ctx, sender, childRpc, ticketID, redeemData, safeAddress, safeClient, safeOut, safeForce, safeOperation, safeNonce)
+}
+
+// Extends the lifetime of a retryable ticket by one retryable lifetime (7 days by default).
+func KeepaliveCall(ctx context.Context, sender signer.Signer, childRpc string, ticketID common.Hash) (*types.Transaction, error) {
+	keepaliveData, keepaliveDataErr := GetKeepaliveCalldata(ticketID)
+	if keepaliveDataErr != nil {
+		return nil, keepaliveDataErr
+	}
+
+	return sendArbRetryableTxTransaction(ctx, "keepalive", sender, childRpc, ticketID, keepaliveData, 0)
+}
+
+func KeepalivePropose(ctx context.Context, sender signer.Signer, childRpc string, ticketID common.Hash, safeAddress common.Address, safeClient *safe.Client, safeOut string, safeForce bool, safeOperation uint8, safeNonce *big.Int) error {
+	keepaliveData, keepaliveDataErr := GetKeepaliveCalldata(ticketID)
+	if keepaliveDataErr != nil {
+		return keepaliveDataErr
+	}
+
+	return proposeArbRetryableTxTransaction(ctx, sender, childRpc, ticketID, keepaliveData, safeAddress, safeClient, safeOut, safeForce, safeOperation, safeNonce)
+}
@@ -0,0 +1,60 @@
+package bridge
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestGetRetryableTicketIDs(t *testing.T) {
+	ctx := context.Background()
+	bridgeAddress := common.HexToAddress("0xb1")
+	inboxAddress := common.HexToAddress("0xa1")
+	sender := common.HexToAddress("0x5e")
+	retryableData := retryableInboxData(common.HexToAddress("0x70"), big.NewInt(1), big.NewInt(2), big.NewInt(3), sender, sender, big.NewInt(100_000), big.NewInt(100_000_000), []byte{0xca, 0xfe})
+
+	ticketTx := common.HexToHash("0x01")
+	depositTx := common.HexToHash("0x02")
+	parent := &fakeBackend{
+		receipts: map[common.Hash]*types.Receipt{
+			ticketTx: {Logs: []*types.Log{
+				// Messages of other kinds are not retryable tickets.
+				messageDeliveredLog(t, bridgeAddress, inboxAddress, 6, 3, sender),
+				inboxMessageDeliveredLog(t, inboxAddress, 6, nil),
+				messageDeliveredLog(t, bridgeAddress, inboxAddress, 7, L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX, sender),
+				inboxMessageDeliveredLog(t, inboxAddress, 7, retryableData),
+				messageDeliveredLog(t, bridgeAddress, inboxAddress, 8, L1_MESSAGE_TYPE_ETH_DEPOSIT, sender),
+				inboxMessageDeliveredLog(t, inboxAddress, 8, depositInboxData(sender, big.NewInt(5))),
+			}},
+			depositTx: {Logs: []*types.Log{
+				messageDeliveredLog(t, bridgeAddress, inboxAddress, 9, L1_MESSAGE_TYPE_ETH_DEPOSIT, sender),
+				inboxMessageDeliveredLog(t, inboxAddress, 9, depositInboxData(sender, big.NewInt(5))),
+			}},
+		},
+	}
+	child := &fakeBackend{chainID: big.NewInt(2187)}
+
+	ticketIDs, ticketIDsErr := GetRetryableTicketIDs(ctx, parent, child, bridgeAddress, ticketTx)
+	if ticketIDsErr != nil {
+		t.Fatal(ticketIDsErr)
+	}
+
+	ticket := &ParentToChildMessage{ChildChainID: child.chainID, MessageIndex: big.NewInt(7), Kind: L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX, From: sender, L1BaseFee: big.NewInt(30_000_000_000)}
+	if decodeErr := ticket.decodeInboxData(retryableData); decodeErr != nil {
+		t.Fatal(decodeErr)
+	}
+	ticketID, ticketIDErr := ticket.ChildTxHash()
+	if ticketIDErr != nil {
+		t.Fatal(ticketIDErr)
+	}
+	if len(ticketIDs) != 1 || ticketIDs[0] != ticketID {
+		t.Errorf("Expected ticket %s, got %v", ticketID.Hex(), ticketIDs)
+	}
+
+	if _, ticketIDsErr := GetRetryableTicketIDs(ctx, parent, child, bridgeAddress, depositTx); ticketIDsErr == nil {
+		t.Error("Expected an error for a transaction that only deposits")
+	}
+}
@@ -0,0 +1,271 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/G7DAO/protocol/bindings/ArbitrumBridge"
+	"github.com/G7DAO/protocol/bindings/ERC20Inbox"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Message kinds delivered through the bridge's delayed inbox.
+// Source: https://github.com/OffchainLabs/nitro-contracts/blob/main/src/libraries/MessageTypes.sol
+const (
+	L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX uint8 = 9
+	L1_MESSAGE_TYPE_ETH_DEPOSIT         uint8 = 12
+)
+
+// Transaction types that the child chain creates for delayed inbox messages.
+// Source: https://github.com/OffchainLabs/go-ethereum/blob/master/core/types/transaction.go
+const (
+	ARBITRUM_DEPOSIT_TX_TYPE          = 0x64
+	ARBITRUM_SUBMIT_RETRYABLE_TX_TYPE = 0x69
+)
+
+var ErrNoParentToChildMessages = errors.New("no parent-to-child messages found in transaction")
+
+// ParentToChildMessage is a message that a parent chain transaction delivered to a child chain's inbox, decoded
+// from the bridge's MessageDelivered event and the inbox's InboxMessageDelivered event.
+//
+// Retryable tickets (kind 9) populate every field. Plain deposits (kind 12) only populate To and Deposit.
+type ParentToChildMessage struct {
+	ChildChainID           *big.Int
+	Inbox                  common.Address
+	MessageIndex           *big.Int
+	Kind                   uint8
+	From                   common.Address
+	L1BaseFee              *big.Int
+	To                     common.Address
+	L2CallValue            *big.Int
+	Deposit                *big.Int
+	MaxSubmissionCost      *big.Int
+	ExcessFeeRefundAddress common.Address
+	CallValueRefundAddress common.Address
+	GasLimit               *big.Int
+	MaxFeePerGas           *big.Int
+	Data                   []byte
+}
+
+// Mirrors ArbitrumSubmitRetryableTx from Arbitrum's fork of go-ethereum, in RLP field order.
+type arbitrumSubmitRetryableTx struct {
+	ChainId          *big.Int
+	RequestId        common.Hash
+	From             common.Address
+	L1BaseFee        *big.Int
+	DepositValue     *big.Int
+	GasFeeCap        *big.Int
+	Gas              uint64
+	To               *common.Address `rlp:"nil"`
+	Value            *big.Int
+	Beneficiary      common.Address
+	MaxSubmissionFee *big.Int
+	FeeRefundAddr    common.Address
+	RetryData        []byte
+}
+
+// Mirrors ArbitrumDepositTx from Arbitrum's fork of go-ethereum, in RLP field order.
+type arbitrumDepositTx struct {
+	ChainId     *big.Int
+	L1RequestId common.Hash
+	From        common.Address
+	To          common.Address
+	Value       *big.Int
+}
+
+// Returns the hash of the transaction that the child chain creates for this message. For retryable tickets, this is
+// the retryable ticket ID.
+func (m *ParentToChildMessage) ChildTxHash() (common.Hash, error) {
+	var payload interface{}
+	var txType byte
+
+	requestId := common.BigToHash(m.MessageIndex)
+
+	switch m.Kind {
+	case L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX:
+		if !m.GasLimit.IsUint64() {
+			return common.Hash{}, fmt.Errorf("retryable gas limit %s does not fit in 64 bits", m.GasLimit.String())
+		}
+
+		var to *common.Address
+		if m.To != (common.Address{}) {
+			to = &m.To
+		}
+
+		txType = ARBITRUM_SUBMIT_RETRYABLE_TX_TYPE
+		payload = arbitrumSubmitRetryableTx{
+			ChainId:          m.ChildChainID,
+			RequestId:        requestId,
+			From:             m.From,
+			L1BaseFee:        m.L1BaseFee,
+			DepositValue:     m.Deposit,
+			GasFeeCap:        m.MaxFeePerGas,
+			Gas:              m.GasLimit.Uint64(),
+			To:               to,
+			Value:            m.L2CallValue,
+			Beneficiary:      m.CallValueRefundAddress,
+			MaxSubmissionFee: m.MaxSubmissionCost,
+			FeeRefundAddr:    m.ExcessFeeRefundAddress,
+			RetryData:        m.Data,
+		}
+	case L1_MESSAGE_TYPE_ETH_DEPOSIT:
+		txType = ARBITRUM_DEPOSIT_TX_TYPE
+		payload = arbitrumDepositTx{
+			ChainId:     m.ChildChainID,
+			L1RequestId: requestId,
+			From:        m.From,
+			To:          m.To,
+			Value:       m.Deposit,
+		}
+	default:
+		return common.Hash{}, fmt.Errorf("unsupported inbox message kind %d", m.Kind)
+	}
+
+	encoded, encodedErr := rlp.EncodeToBytes(payload)
+	if encodedErr != nil {
+		return common.Hash{}, encodedErr
+	}
+
+	return crypto.Keccak256Hash(append([]byte{txType}, encoded...)), nil
+}
+
+// Decodes the data of an InboxMessageDelivered event into the message. The inbox packs retryable tickets as:
+// to, l2CallValue, amount, maxSubmissionCost, excessFeeRefundAddress, callValueRefundAddress, gasLimit,
+// maxFeePerGas, data.length, data (every field but data padded to 32 bytes), and deposits as: to (20 bytes), amount.
+func (m *ParentToChildMessage) decodeInboxData(data []byte) error {
+	word := func(i int) *big.Int {
+		return new(big.Int).SetBytes(data[i*32 : (i+1)*32])
+	}
+
+	switch m.Kind {
+	case L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX:
+		if len(data) < 9*32 {
+			return fmt.Errorf("retryable message %s is too short", m.MessageIndex.String())
+		}
+		m.To = common.BigToAddress(word(0))
+		m.L2CallValue = word(1)
+		m.Deposit = word(2)
+		m.MaxSubmissionCost = word(3)
+		m.ExcessFeeRefundAddress = common.BigToAddress(word(4))
+		m.CallValueRefundAddress = common.BigToAddress(word(5))
+		m.GasLimit = word(6)
+		m.MaxFeePerGas = word(7)
+		dataLength := word(8)
+		if !dataLength.IsUint64() || uint64(len(data)-9*32) < dataLength.Uint64() {
+			return fmt.Errorf("retryable message %s has invalid data length", m.MessageIndex.String())
+		}
+		m.Data = data[9*32 : 9*32+int(dataLength.Uint64())]
+	case L1_MESSAGE_TYPE_ETH_DEPOSIT:
+		if len(data) < 20+32 {
+			return fmt.Errorf("deposit message %s is too short", m.MessageIndex.String())
+		}
+		m.To = common.BytesToAddress(data[:20])
+		m.Deposit = new(big.Int).SetBytes(data[20 : 20+32])
+	}
+
+	return nil
+}
+
+// Returns the retryable tickets and deposits that the given receipt delivered to a child chain through its bridge.
+// Messages are matched across the bridge's MessageDelivered and the inbox's InboxMessageDelivered events by inbox and
+// message index. Messages of other bridges and other message kinds are ignored.
+func GetParentToChildMessages(receipt *types.Receipt, bridgeAddress common.Address, childChainID *big.Int) ([]*ParentToChildMessage, error) {
+	bridgeAbi, bridgeAbiErr := abi.JSON(strings.NewReader(ArbitrumBridge.BridgeABI))
+	if bridgeAbiErr != nil {
+		return nil, bridgeAbiErr
+	}
+	messageDeliveredTopic := bridgeAbi.Events["MessageDelivered"].ID
+
+	inboxAbi, inboxAbiErr := abi.JSON(strings.NewReader(ERC20Inbox.ERC20InboxABI))
+	if inboxAbiErr != nil {
+		return nil, inboxAbiErr
+	}
+	inboxMessageDeliveredTopic := inboxAbi.Events["InboxMessageDelivered"].ID
+
+	bridgeFilterer, bridgeFiltererErr := ArbitrumBridge.NewBridgeFilterer(bridgeAddress, nil)
+	if bridgeFiltererErr != nil {
+		return nil, bridgeFiltererErr
+	}
+
+	inboxFilterer, inboxFiltererErr := ERC20Inbox.NewERC20InboxFilterer(common.Address{}, nil)
+	if inboxFiltererErr != nil {
+		return nil, inboxFiltererErr
+	}
+
+	// Message indices are only unique within a bridge, and a transaction may deliver messages to several bridges.
+	type inboxMessage struct {
+		inbox common.Address
+		index common.Hash
+	}
+
+	messages := []*ParentToChildMessage{}
+	inboxData := map[inboxMessage][]byte{}
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+
+		switch log.Topics[0] {
+		case messageDeliveredTopic:
+			if log.Address != bridgeAddress {
+				continue
+			}
+			event, eventErr := bridgeFilterer.ParseMessageDelivered(*log)
+			if eventErr != nil {
+				return nil, eventErr
+			}
+			if event.Kind != L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX && event.Kind != L1_MESSAGE_TYPE_ETH_DEPOSIT {
+				continue
+			}
+			messages = append(messages, &ParentToChildMessage{
+				ChildChainID: childChainID,
+				Inbox:        event.Inbox,
+				MessageIndex: event.MessageIndex,
+				Kind:         event.Kind,
+				From:         event.Sender,
+				L1BaseFee:    event.BaseFeeL1,
+			})
+		case inboxMessageDeliveredTopic:
+			event, eventErr := inboxFilterer.ParseInboxMessageDelivered(*log)
+			if eventErr != nil {
+				return nil, eventErr
+			}
+			inboxData[inboxMessage{log.Address, common.BigToHash(event.MessageNum)}] = event.Data
+		}
+	}
+
+	for _, message := range messages {
+		data, ok := inboxData[inboxMessage{message.Inbox, common.BigToHash(message.MessageIndex)}]
+		if !ok {
+			return nil, fmt.Errorf("no InboxMessageDelivered event found for message %s from inbox %s", message.MessageIndex.String(), message.Inbox.Hex())
+		}
+		if decodeErr := message.decodeInboxData(data); decodeErr != nil {
+			return nil, decodeErr
+		}
+	}
+
+	return messages, nil
+}
+
+// Returns the retryable tickets and deposits that the given parent chain transaction delivered to the child chain
+// through its bridge.
+func GetParentToChildMessagesFromTx(ctx context.Context, parentClient Backend, childClient Backend, bridgeAddress common.Address, txHash common.Hash) ([]*ParentToChildMessage, error) {
+	receipt, receiptErr := parentClient.TransactionReceipt(ctx, txHash)
+	if receiptErr != nil {
+		return nil, receiptErr
+	}
+
+	childChainID, childChainIDErr := childClient.ChainID(ctx)
+	if childChainIDErr != nil {
+		return nil, childChainIDErr
+	}
+
+	return GetParentToChildMessages(receipt, bridgeAddress, childChainID)
+}
@@ -0,0 +1,339 @@
+package bridge
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/G7DAO/protocol/bindings/ArbitrumBridge"
+	"github.com/G7DAO/protocol/bindings/ERC20Inbox"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Returns the MessageDelivered event that a bridge emits when one of its inboxes enqueues a message.
+func messageDeliveredLog(t *testing.T, bridgeAddress common.Address, inboxAddress common.Address, messageIndex int64, kind uint8, sender common.Address) *types.Log {
+	bridgeAbi, bridgeAbiErr := ArbitrumBridge.BridgeMetaData.GetAbi()
+	if bridgeAbiErr != nil {
+		t.Fatal(bridgeAbiErr)
+	}
+	event := bridgeAbi.Events["MessageDelivered"]
+
+	data, dataErr := event.Inputs.NonIndexed().Pack(inboxAddress, kind, sender, [32]byte{}, big.NewInt(30_000_000_000), uint64(1_700_000_000))
+	if dataErr != nil {
+		t.Fatal(dataErr)
+	}
+
+	return &types.Log{
+		Address: bridgeAddress,
+		Topics:  []common.Hash{event.ID, common.BigToHash(big.NewInt(messageIndex)), {}},
+		Data:    data,
+	}
+}
+
+// Returns the InboxMessageDelivered event that an inbox emits with the data of the message it enqueued.
+func inboxMessageDeliveredLog(t *testing.T, inboxAddress common.Address, messageIndex int64, messageData []byte) *types.Log {
+	inboxAbi, inboxAbiErr := ERC20Inbox.ERC20InboxMetaData.GetAbi()
+	if inboxAbiErr != nil {
+		t.Fatal(inboxAbiErr)
+	}
+	event := inboxAbi.Events["InboxMessageDelivered"]
+
+	data, dataErr := event.Inputs.NonIndexed().Pack(messageData)
+	if dataErr != nil {
+		t.Fatal(dataErr)
+	}
+
+	return &types.Log{
+		Address: inboxAddress,
+		Topics:  []common.Hash{event.ID, common.BigToHash(big.NewInt(messageIndex))},
+		Data:    data,
+	}
+}
+
+// Packs a retryable ticket the way the inbox does in the data of InboxMessageDelivered.
+func retryableInboxData(to common.Address, l2CallValue, deposit, maxSubmissionCost *big.Int, excessFeeRefundAddress, callValueRefundAddress common.Address, gasLimit, maxFeePerGas *big.Int, data []byte) []byte {
+	packed := []byte{}
+	for _, word := range [][]byte{
+		to.Bytes(), l2CallValue.Bytes(), deposit.Bytes(), maxSubmissionCost.Bytes(),
+		excessFeeRefundAddress.Bytes(), callValueRefundAddress.Bytes(), gasLimit.Bytes(), maxFeePerGas.Bytes(),
+		big.NewInt(int64(len(data))).Bytes(),
+	} {
+		packed = append(packed, common.LeftPadBytes(word, 32)...)
+	}
+	return append(packed, data...)
+}
+
+// Packs a deposit the way the inbox does in the data of InboxMessageDelivered.
+func depositInboxData(to common.Address, amount *big.Int) []byte {
+	return append(to.Bytes(), common.LeftPadBytes(amount.Bytes(), 32)...)
+}
+
+// Returns the hash of an Arbitrum transaction of the given type with the given fields, RLP encoded in the order of
+// Arbitrum's fork of go-ethereum. A nil destination is encoded as an empty string.
+func arbitrumTxHash(t *testing.T, txType byte, fields ...interface{}) common.Hash {
+	encoded, encodedErr := rlp.EncodeToBytes(fields)
+	if encodedErr != nil {
+		t.Fatal(encodedErr)
+	}
+	return crypto.Keccak256Hash(append([]byte{txType}, encoded...))
+}
+
+func TestChildTxHash(t *testing.T) {
+	chainID := big.NewInt(2187)
+	sender := common.HexToAddress("0xf7f39e2b39d6dd0fbe2f3bd1e5f3b8d3dbd7c7a2")
+	to := common.HexToAddress("0x70997970c51812dc3a010c7d01b50e0d17dc79c8")
+	refund := common.HexToAddress("0x3c44cdddb6a900fa2b585dd299e03d12fa4293bc")
+	callValueRefund := common.HexToAddress("0x90f79bf6eb2c4f870365e785982e1f101e93b906")
+
+	retryable := func(to common.Address, data []byte) *ParentToChildMessage {
+		return &ParentToChildMessage{
+			ChildChainID:           chainID,
+			MessageIndex:           big.NewInt(123456),
+			Kind:                   L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX,
+			From:                   sender,
+			L1BaseFee:              big.NewInt(8_000_000_000),
+			To:                     to,
+			L2CallValue:            big.NewInt(1_000_000_000_000_000),
+			Deposit:                big.NewInt(1_002_000_000_000_000),
+			MaxSubmissionCost:      big.NewInt(1_000_000_000_000),
+			ExcessFeeRefundAddress: refund,
+			CallValueRefundAddress: callValueRefund,
+			GasLimit:               big.NewInt(300_000),
+			MaxFeePerGas:           big.NewInt(100_000_000),
+			Data:                   data,
+		}
+	}
+	requestID := common.BigToHash(big.NewInt(123456))
+	data := common.FromHex("0x2e567b36000000000000000000000000")
+
+	cases := []struct {
+		name     string
+		message  *ParentToChildMessage
+		expected common.Hash
+	}{
+		{
+			name:    "retryable ticket",
+			message: retryable(to, data),
+			expected: arbitrumTxHash(t, 0x69, chainID, requestID, sender, big.NewInt(8_000_000_000), big.NewInt(1_002_000_000_000_000), big.NewInt(100_000_000),
+				uint64(300_000), to.Bytes(), big.NewInt(1_000_000_000_000_000), callValueRefund, big.NewInt(1_000_000_000_000), refund, data),
+		},
+		{
+			name:    "retryable ticket creating a contract",
+			message: retryable(common.Address{}, data),
+			expected: arbitrumTxHash(t, 0x69, chainID, requestID, sender, big.NewInt(8_000_000_000), big.NewInt(1_002_000_000_000_000), big.NewInt(100_000_000),
+				uint64(300_000), []byte{}, big.NewInt(1_000_000_000_000_000), callValueRefund, big.NewInt(1_000_000_000_000), refund, data),
+		},
+		{
+			name:     "deposit",
+			message:  &ParentToChildMessage{ChildChainID: chainID, MessageIndex: big.NewInt(123457), Kind: L1_MESSAGE_TYPE_ETH_DEPOSIT, From: sender, To: to, Deposit: big.NewInt(5)},
+			expected: arbitrumTxHash(t, 0x64, chainID, common.BigToHash(big.NewInt(123457)), sender, to, big.NewInt(5)),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			childTxHash, childTxHashErr := c.message.ChildTxHash()
+			if childTxHashErr != nil {
+				t.Fatal(childTxHashErr)
+			}
+			if childTxHash != c.expected {
+				t.Errorf("Expected %s, got %s", c.expected.Hex(), childTxHash.Hex())
+			}
+		})
+	}
+
+	tooMuchGas := retryable(to, data)
+	tooMuchGas.GasLimit = new(big.Int).Lsh(big.NewInt(1), 64)
+	if _, childTxHashErr := tooMuchGas.ChildTxHash(); childTxHashErr == nil {
+		t.Error("Expected an error for a gas limit that does not fit in 64 bits")
+	}
+
+	if _, childTxHashErr := (&ParentToChildMessage{MessageIndex: big.NewInt(1), Kind: 3}).ChildTxHash(); childTxHashErr == nil {
+		t.Error("Expected an error for an unsupported message kind")
+	}
+}
+
+func TestDecodeInboxData(t *testing.T) {
+	to := common.HexToAddress("0x70997970c51812dc3a010c7d01b50e0d17dc79c8")
+	refund := common.HexToAddress("0x3c44cdddb6a900fa2b585dd299e03d12fa4293bc")
+	retryableData := retryableInboxData(to, big.NewInt(1), big.NewInt(2), big.NewInt(3), refund, to, big.NewInt(4), big.NewInt(5), []byte{0xca, 0xfe})
+
+	// The length of the call data says 3 bytes, but only 2 follow.
+	truncated := append([]byte{}, retryableData...)
+	truncated[9*32-1] = 3
+
+	cases := []struct {
+		name     string
+		kind     uint8
+		data     []byte
+		expected *ParentToChildMessage
+	}{
+		{
+			name: "retryable ticket",
+			kind: L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX,
+			data: retryableData,
+			expected: &ParentToChildMessage{To: to, L2CallValue: big.NewInt(1), Deposit: big.NewInt(2), MaxSubmissionCost: big.NewInt(3), ExcessFeeRefundAddress: refund,
+				CallValueRefundAddress: to, GasLimit: big.NewInt(4), MaxFeePerGas: big.NewInt(5), Data: []byte{0xca, 0xfe}},
+		},
+		{name: "retryable ticket shorter than its fields", kind: L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX, data: retryableData[:8*32]},
+		{name: "retryable ticket shorter than its call data", kind: L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX, data: truncated},
+		{
+			name:     "deposit",
+			kind:     L1_MESSAGE_TYPE_ETH_DEPOSIT,
+			data:     depositInboxData(to, big.NewInt(1_000_000_000_000_000_000)),
+			expected: &ParentToChildMessage{To: to, Deposit: big.NewInt(1_000_000_000_000_000_000)},
+		},
+		{name: "deposit without an amount", kind: L1_MESSAGE_TYPE_ETH_DEPOSIT, data: to.Bytes()},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			message := &ParentToChildMessage{MessageIndex: big.NewInt(1), Kind: c.kind}
+			decodeErr := message.decodeInboxData(c.data)
+			if c.expected == nil {
+				if decodeErr == nil {
+					t.Error("Expected an error")
+				}
+				return
+			}
+			if decodeErr != nil {
+				t.Fatal(decodeErr)
+			}
+
+			if message.To != c.expected.To || message.Deposit.Cmp(c.expected.Deposit) != 0 || string(message.Data) != string(c.expected.Data) {
+				t.Errorf("Expected %+v, got %+v", c.expected, message)
+			}
+			if c.kind == L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX && (message.L2CallValue.Cmp(c.expected.L2CallValue) != 0 || message.MaxSubmissionCost.Cmp(c.expected.MaxSubmissionCost) != 0 ||
+				message.ExcessFeeRefundAddress != c.expected.ExcessFeeRefundAddress || message.CallValueRefundAddress != c.expected.CallValueRefundAddress ||
+				message.GasLimit.Cmp(c.expected.GasLimit) != 0 || message.MaxFeePerGas.Cmp(c.expected.MaxFeePerGas) != 0) {
+				t.Errorf("Expected %+v, got %+v", c.expected, message)
+			}
+		})
+	}
+}
+
+func TestGetParentToChildMessages(t *testing.T) {
+	bridgeAddress, inboxAddress := common.HexToAddress("0xb1"), common.HexToAddress("0xa1")
+	otherBridgeAddress, otherInboxAddress := common.HexToAddress("0xb2"), common.HexToAddress("0xa2")
+	sender := common.HexToAddress("0x5e")
+	to := common.HexToAddress("0x70")
+
+	// Both bridges deliver a message with index 7 in the same transaction.
+	receipt := &types.Receipt{Logs: []*types.Log{
+		messageDeliveredLog(t, otherBridgeAddress, otherInboxAddress, 7, L1_MESSAGE_TYPE_ETH_DEPOSIT, sender),
+		inboxMessageDeliveredLog(t, otherInboxAddress, 7, depositInboxData(sender, big.NewInt(1))),
+		messageDeliveredLog(t, bridgeAddress, inboxAddress, 7, L1_MESSAGE_TYPE_ETH_DEPOSIT, sender),
+		inboxMessageDeliveredLog(t, inboxAddress, 7, depositInboxData(to, big.NewInt(2))),
+	}}
+
+	messages, messagesErr := GetParentToChildMessages(receipt, bridgeAddress, big.NewInt(2187))
+	if messagesErr != nil {
+		t.Fatal(messagesErr)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if messages[0].Inbox != inboxAddress || messages[0].To != to || messages[0].Deposit.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("Expected the deposit of %s to %s, got %+v", bridgeAddress.Hex(), to.Hex(), messages[0])
+	}
+
+	// The data of a message is only taken from the inbox that the bridge names.
+	receipt.Logs = receipt.Logs[:3]
+	if _, messagesErr := GetParentToChildMessages(receipt, bridgeAddress, big.NewInt(2187)); messagesErr == nil {
+		t.Error("Expected an error for a message whose inbox did not emit its data")
+	}
+}
+
+// Runtime code of a contract standing in for a bridge: it emits its call data as a log with three topics and 192 bytes
+// of data, the shape of MessageDelivered.
+const bridgeStandInCode = "0x" +
+	"366000600037" + // calldatacopy(0, 0, calldatasize)
+	"604051602051600051" + // topics: mload(0x40), mload(0x20), mload(0)
+	"60c06060a3" + // log3(0x60, 0xc0, ...)
+	"00" // stop
+
+// Returns the runtime code of a contract standing in for an inbox: it passes the first 288 bytes of its call data to
+// the bridge stand-in at bridgeAddress, and emits the rest as a log with two topics, the shape of InboxMessageDelivered.
+func inboxStandInCode(bridgeAddress common.Address) string {
+	return "0x" +
+		"366000600037" + // calldatacopy(0, 0, calldatasize)
+		"600060006101206000600073" + strings.TrimPrefix(bridgeAddress.Hex(), "0x") + "5af150" + // pop(call(gas, bridge, 0, 0, 0x120, 0, 0))
+		"6101405161012051" + // topics: mload(0x140), mload(0x120)
+		"6101603603610160a2" + // log2(0x160, calldatasize - 0x160, ...)
+		"00" // stop
+}
+
+func TestGetParentToChildMessagesFromTx(t *testing.T) {
+	ctx := context.Background()
+	privateKey, privateKeyErr := crypto.GenerateKey()
+	if privateKeyErr != nil {
+		t.Fatal(privateKeyErr)
+	}
+	sender := signer.NewLocalSigner(privateKey)
+
+	bridgeAddress := common.HexToAddress("0x8315177aB297bA92A06054cE80a67Ed4DBd7ed3a")
+	inboxAddress := common.HexToAddress("0x4Dbd4fc535Ac27206064B68FfCf827b0A60BAB3f")
+	parent := simulated.NewBackend(types.GenesisAlloc{
+		sender.Address(): {Balance: ONE_ETHER},
+		bridgeAddress:    {Code: common.FromHex(bridgeStandInCode), Balance: big.NewInt(0)},
+		inboxAddress:     {Code: common.FromHex(inboxStandInCode(bridgeAddress)), Balance: big.NewInt(0)},
+	})
+	t.Cleanup(func() { parent.Close() })
+	child := &fakeBackend{chainID: big.NewInt(2187)}
+
+	to := common.HexToAddress("0x70997970c51812dc3a010c7d01b50e0d17dc79c8")
+	retryableData := retryableInboxData(to, big.NewInt(1), big.NewInt(2), big.NewInt(3), sender.Address(), sender.Address(), big.NewInt(100_000), big.NewInt(100_000_000), []byte{0xca, 0xfe})
+
+	messageDelivered := messageDeliveredLog(t, bridgeAddress, inboxAddress, 42, L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX, sender.Address())
+	inboxMessageDelivered := inboxMessageDeliveredLog(t, inboxAddress, 42, retryableData)
+	calldata := []byte{}
+	for _, topic := range messageDelivered.Topics {
+		calldata = append(calldata, topic.Bytes()...)
+	}
+	calldata = append(calldata, messageDelivered.Data...)
+	for _, topic := range inboxMessageDelivered.Topics {
+		calldata = append(calldata, topic.Bytes()...)
+	}
+	calldata = append(calldata, inboxMessageDelivered.Data...)
+
+	transaction, transactionErr := SendTransaction(ctx, parent.Client(), sender, calldata, inboxAddress.Hex(), big.NewInt(0))
+	if transactionErr != nil {
+		t.Fatal(transactionErr)
+	}
+	parent.Commit()
+
+	messages, messagesErr := GetParentToChildMessagesFromTx(ctx, parent.Client(), child, bridgeAddress, transaction.Hash())
+	if messagesErr != nil {
+		t.Fatal(messagesErr)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+
+	message := messages[0]
+	if message.ChildChainID.Cmp(child.chainID) != 0 || message.Inbox != inboxAddress || message.MessageIndex.Int64() != 42 || message.From != sender.Address() ||
+		message.L1BaseFee.Cmp(big.NewInt(30_000_000_000)) != 0 || message.To != to || message.GasLimit.Int64() != 100_000 || string(message.Data) != "\xca\xfe" {
+		t.Errorf("Unexpected message: %+v", message)
+	}
+
+	ticketID, ticketIDErr := message.ChildTxHash()
+	if ticketIDErr != nil {
+		t.Fatal(ticketIDErr)
+	}
+	expected := arbitrumTxHash(t, 0x69, child.chainID, common.BigToHash(big.NewInt(42)), sender.Address(), big.NewInt(30_000_000_000), big.NewInt(2), big.NewInt(100_000_000),
+		uint64(100_000), to.Bytes(), big.NewInt(1), sender.Address(), big.NewInt(3), sender.Address(), []byte{0xca, 0xfe})
+	if ticketID != expected {
+		t.Errorf("Expected ticket %s, got %s", expected.Hex(), ticketID.Hex())
+	}
+
+	// Messages of another bridge in the same transaction are not returned.
+	if messages, _ := GetParentToChildMessagesFromTx(ctx, parent.Client(), child, inboxAddress, transaction.Hash()); len(messages) != 0 {
+		t.Errorf("Expected no messages from another bridge, got %d", len(messages))
+	}
+}
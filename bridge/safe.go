@@ -1,212 +1,92 @@
 package bridge
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"math/big"
-	"net/http"
 
-	"github.com/G7DAO/protocol/bindings/GnosisSafe"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/G7DAO/protocol/command"
+	"github.com/G7DAO/protocol/safe"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/math"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/spf13/cobra"
 )
 
-// OperationType represents the type of operation for a Safe transaction
-type OperationType uint8
-
-const (
-	Call         OperationType = 0
-	DelegateCall OperationType = 1
-)
-
-// String returns the string representation of the OperationType
-func (o OperationType) String() string {
-	switch o {
-	case Call:
-		return "Call"
-	case DelegateCall:
-		return "DelegateCall"
-	default:
-		return "Unknown"
-	}
+// Returns the client that the --safe mode of the bridge commands proposes through. safeApi is the endpoint that
+// proposals are posted to: the value of --safe-api, or the Safe client gateway of the chain by default.
+func newSafeClient(safeApi string, safeApiKey string) *safe.Client {
+	client := safe.NewClient("")
+	client.ProposeURL = safeApi
+	client.APIKey = safeApiKey
+	client.Origin.Name = "game7 bridge"
+	return client
 }
 
-// SafeTransactionData represents the data for a Safe transaction
-type SafeTransactionData struct {
-	To             string        `json:"to"`
-	Value          string        `json:"value"`
-	Data           string        `json:"data"`
-	Operation      OperationType `json:"operation"`
-	SafeTxGas      uint64        `json:"safeTxGas"`
-	BaseGas        uint64        `json:"baseGas"`
-	GasPrice       string        `json:"gasPrice"`
-	GasToken       string        `json:"gasToken"`
-	RefundReceiver string        `json:"refundReceiver"`
-	Nonce          *big.Int      `json:"nonce"`
-	SafeTxHash     string        `json:"safeTxHash"`
-	Sender         string        `json:"sender"`
-	Signature      string        `json:"signature"`
-	Origin         string        `json:"origin"`
+// Adds the flags that make a bridge command propose its transaction to a Safe instead of sending it.
+func addSafeFlags(cmd *cobra.Command, safeAddress, safeApi, safeApiKey, safeOut *string, safeForce *bool, safeOperation *uint8, safeNonce *string) {
+	cmd.Flags().StringVar(safeAddress, "safe", "", "Address of the Safe contract")
+	cmd.Flags().StringVar(safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
+	cmd.Flags().StringVar(safeApiKey, "safe-api-key", "", "API key sent to the Safe API, required by https://api.safe.global (optional)")
+	cmd.Flags().StringVar(safeOut, "safe-out", "", "Write the signed Safe transaction to this bundle file instead of proposing it to the Safe Transaction Service")
+	cmd.Flags().BoolVar(safeForce, "force", false, "Propose the Safe transaction even if it reverts in simulation")
+	cmd.Flags().Uint8Var(safeOperation, "safe-operation", 0, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
+	cmd.Flags().StringVar(safeNonce, "safe-nonce", "", "Safe nonce")
 }
 
-const (
-	NativeTokenAddress = "0x0000000000000000000000000000000000000000"
-)
-
-func CreateSafeProposal(client *ethclient.Client, key *keystore.Key, safeAddress common.Address, to common.Address, data []byte, value *big.Int, safeApi string, safeOperation OperationType, safeNonce *big.Int) error {
-	chainID, err := client.ChainID(context.Background())
-	if err != nil {
-		return fmt.Errorf("failed to get chain ID: %v", err)
+// Validates the flags added by addSafeFlags and returns the Safe address and the nonce given with --safe-nonce, or nil
+// to use the next nonce of the Safe. Unless the transaction is written to a bundle, safeApi defaults to the Safe client
+// gateway of the chain at rpc, which the Safe lives on.
+func resolveSafeFlags(cmd *cobra.Command, timeout uint, rpc string, safeAddressRaw string, safeApi *string, safeOut string, safeOperation uint8, safeNonceRaw string) (common.Address, *big.Int, error) {
+	if !common.IsHexAddress(safeAddressRaw) {
+		return common.Address{}, nil, fmt.Errorf("--safe is not a valid Ethereum address")
 	}
+	safeAddress := common.HexToAddress(safeAddressRaw)
 
-	// Create a new instance of the GnosisSafe contract
-	safeInstance, err := GnosisSafe.NewGnosisSafe(safeAddress, client)
-	if err != nil {
-		return fmt.Errorf("failed to create GnosisSafe instance: %v", err)
-	}
+	if *safeApi == "" && safeOut == "" {
+		ctx, cancel := command.Context(cmd, timeout)
+		defer cancel()
 
-	nonce := big.NewInt(0)
-	if safeNonce == nil {
-		// Fetch the current nonce from the Safe contract
-		fetchNonce, err := safeInstance.Nonce(&bind.CallOpts{})
-		if err != nil {
-			return fmt.Errorf("failed to fetch nonce from Safe contract: %v", err)
+		client, clientErr := ethclient.DialContext(ctx, rpc)
+		if clientErr != nil {
+			return common.Address{}, nil, clientErr
 		}
-		nonce = fetchNonce
-	} else {
-		nonce = safeNonce
-	}
-
-	safeTransactionData := SafeTransactionData{
-		To:             to.Hex(),
-		Value:          value.String(),
-		Data:           common.Bytes2Hex(data),
-		Operation:      safeOperation,
-		SafeTxGas:      0,
-		BaseGas:        0,
-		GasPrice:       "0",
-		GasToken:       NativeTokenAddress,
-		RefundReceiver: NativeTokenAddress,
-		Nonce:          nonce,
-	}
-
-	// Calculate SafeTxHash
-	safeTxHash, err := CalculateSafeTxHash(safeAddress, safeTransactionData, chainID)
-	if err != nil {
-		return fmt.Errorf("failed to calculate SafeTxHash: %v", err)
-	}
 
-	// Sign the SafeTxHash
-	signature, err := crypto.Sign(safeTxHash.Bytes(), key.PrivateKey)
-	if err != nil {
-		return fmt.Errorf("failed to sign SafeTxHash: %v", err)
-	}
-
-	// Adjust V value for Ethereum's replay protection
-	signature[64] += 27
-
-	// Convert signature to hex
-	senderSignature := "0x" + common.Bytes2Hex(signature)
-
-	// Prepare the request body
-	requestBody := map[string]interface{}{
-		"to":             safeTransactionData.To,
-		"value":          safeTransactionData.Value,
-		"data":           "0x" + safeTransactionData.Data,
-		"operation":      int(safeTransactionData.Operation),
-		"safeTxGas":      fmt.Sprintf("%d", safeTransactionData.SafeTxGas),
-		"baseGas":        fmt.Sprintf("%d", safeTransactionData.BaseGas),
-		"gasPrice":       safeTransactionData.GasPrice,
-		"gasToken":       safeTransactionData.GasToken,
-		"refundReceiver": safeTransactionData.RefundReceiver,
-		"nonce":          fmt.Sprintf("%d", safeTransactionData.Nonce),
-		"safeTxHash":     safeTxHash.Hex(),
-		"sender":         key.Address.Hex(),
-		"signature":      senderSignature,
-		"origin":         fmt.Sprintf("{\"url\":\"%s\",\"name\":\"TokenSender Deployment\"}", safeApi),
-	}
-
-	// Marshal the request body to JSON
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %v", err)
+		chainID, chainIDErr := client.ChainID(ctx)
+		if chainIDErr != nil {
+			return common.Address{}, nil, chainIDErr
+		}
+		*safeApi = safe.GatewayProposeURL(chainID, safeAddress)
+		fmt.Println("--safe-api not specified, using default (", *safeApi, ")")
 	}
 
-	// Send the request to the Safe Transaction Service
-	req, err := http.NewRequest("POST", safeApi, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
+	if safe.OperationType(safeOperation).String() == "Unknown" {
+		return common.Address{}, nil, fmt.Errorf("--safe-operation must be 0 (Call) or 1 (DelegateCall)")
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %v", err)
+	if safeNonceRaw == "" {
+		fmt.Println("--safe-nonce not specified, fetching from Safe")
+		return safeAddress, nil, nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	safeNonce := new(big.Int)
+	if _, ok := safeNonce.SetString(safeNonceRaw, 0); !ok {
+		return common.Address{}, nil, fmt.Errorf("--safe-nonce is not a valid big integer")
 	}
-
-	fmt.Println("Safe proposal created successfully")
-	return nil
+	return safeAddress, safeNonce, nil
 }
 
-func CalculateSafeTxHash(safeAddress common.Address, txData SafeTransactionData, chainID *big.Int) (common.Hash, error) {
-	domainSeparator := apitypes.TypedDataDomain{
-		ChainId:           (*math.HexOrDecimal256)(chainID),
-		VerifyingContract: safeAddress.Hex(),
-	}
-
-	typedData := apitypes.TypedData{
-		Types: apitypes.Types{
-			"EIP712Domain": []apitypes.Type{
-				{Name: "chainId", Type: "uint256"},
-				{Name: "verifyingContract", Type: "address"},
-			},
-			"SafeTx": []apitypes.Type{
-				{Name: "to", Type: "address"},
-				{Name: "value", Type: "uint256"},
-				{Name: "data", Type: "bytes"},
-				{Name: "operation", Type: "uint8"},
-				{Name: "safeTxGas", Type: "uint256"},
-				{Name: "baseGas", Type: "uint256"},
-				{Name: "gasPrice", Type: "uint256"},
-				{Name: "gasToken", Type: "address"},
-				{Name: "refundReceiver", Type: "address"},
-				{Name: "nonce", Type: "uint256"},
-			},
-		},
-		Domain:      domainSeparator,
-		PrimaryType: "SafeTx",
-		Message: apitypes.TypedDataMessage{
-			"to":             txData.To,
-			"value":          txData.Value,
-			"data":           "0x" + txData.Data,
-			"operation":      fmt.Sprintf("%d", txData.Operation),
-			"safeTxGas":      fmt.Sprintf("%d", txData.SafeTxGas),
-			"baseGas":        fmt.Sprintf("%d", txData.BaseGas),
-			"gasPrice":       txData.GasPrice,
-			"gasToken":       txData.GasToken,
-			"refundReceiver": txData.RefundReceiver,
-			"nonce":          fmt.Sprintf("%d", txData.Nonce),
-		},
+// Returns nonce or, if it is nil, the next nonce of the Safe on the chain at rpc. Commands that propose several
+// transactions fetch the nonce once and increment it for each proposal, so that the proposals do not all take the same
+// nonce.
+func safeNonceOrNext(ctx context.Context, rpc string, safeAddress common.Address, nonce *big.Int) (*big.Int, error) {
+	if nonce != nil {
+		return nonce, nil
 	}
 
-	typedDataHash, _, err := apitypes.TypedDataAndHash(typedData)
-	if err != nil {
-		return common.Hash{}, fmt.Errorf("failed to hash typed data: %v", err)
+	client, clientErr := ethclient.DialContext(ctx, rpc)
+	if clientErr != nil {
+		return nil, clientErr
 	}
 
-	return common.BytesToHash(typedDataHash), nil
+	return safe.NextNonce(ctx, client, safeAddress)
 }
@@ -0,0 +1,292 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/G7DAO/protocol/bindings/ArbRetryableTx"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type LegStatus int
+
+const (
+	LegPending   LegStatus = 0 // The message has not arrived on the destination chain, or has not been confirmed yet
+	LegConfirmed LegStatus = 1 // A withdrawal has been confirmed and can be claimed on the outbox
+	LegRedeemed  LegStatus = 2 // The message has been executed on the destination chain
+	LegFailed    LegStatus = 3 // The retryable ticket was created but its redemption failed; it can be redeemed manually
+	LegExpired   LegStatus = 4 // The retryable ticket expired without being redeemed
+)
+
+func (s LegStatus) String() string {
+	switch s {
+	case LegPending:
+		return "Pending"
+	case LegConfirmed:
+		return "Confirmed"
+	case LegRedeemed:
+		return "Redeemed"
+	case LegFailed:
+		return "Failed"
+	case LegExpired:
+		return "Expired"
+	default:
+		return "Unknown"
+	}
+}
+
+type LegKind int
+
+const (
+	RetryableLeg  LegKind = 0 // Retryable ticket from a parent chain to a child chain
+	DepositLeg    LegKind = 1 // Native token deposit from a parent chain to a child chain
+	WithdrawalLeg LegKind = 2 // L2-to-L1 message through the outbox
+)
+
+func (k LegKind) String() string {
+	switch k {
+	case RetryableLeg:
+		return "Retryable"
+	case DepositLeg:
+		return "Deposit"
+	case WithdrawalLeg:
+		return "Withdrawal"
+	default:
+		return "Unknown"
+	}
+}
+
+// TransferLeg is a single hop of a cross-chain transfer. For retryables and deposits, ChildTxHash is the retryable
+// ticket ID or the deposit transaction hash on the child chain, and RedeemTxHash is the transaction that executed the
+// ticket. Withdrawals carry their outbox message instead.
+type TransferLeg struct {
+	Kind          LegKind
+	Status        LegStatus
+	ChainID       *big.Int
+	Message       *ParentToChildMessage
+	ChildTxHash   common.Hash
+	RedeemTxHash  common.Hash
+	OutboxMessage *OutboxMessage
+}
+
+// Returns the state of the retryable ticket or deposit that a parent chain delivered to the child chain.
+func GetParentToChildMessageStatus(ctx context.Context, childClient Backend, message *ParentToChildMessage) (*TransferLeg, error) {
+	childTxHash, childTxHashErr := message.ChildTxHash()
+	if childTxHashErr != nil {
+		return nil, childTxHashErr
+	}
+
+	leg := &TransferLeg{
+		Kind:        RetryableLeg,
+		Status:      LegPending,
+		ChainID:     message.ChildChainID,
+		Message:     message,
+		ChildTxHash: childTxHash,
+	}
+	if message.Kind == L1_MESSAGE_TYPE_ETH_DEPOSIT {
+		leg.Kind = DepositLeg
+	}
+
+	receipt, receiptErr := childClient.TransactionReceipt(ctx, childTxHash)
+	if errors.Is(receiptErr, ethereum.NotFound) {
+		return leg, nil
+	} else if receiptErr != nil {
+		return nil, receiptErr
+	}
+
+	if leg.Kind == DepositLeg {
+		leg.Status = LegRedeemed
+		leg.RedeemTxHash = childTxHash
+		return leg, nil
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		leg.Status = LegFailed
+		return leg, nil
+	}
+
+	// The ticket creation receipt schedules the auto-redeem.
+	redeemTxHash, redeemStatus, redeemErr := findSuccessfulRedeem(ctx, childClient, childTxHash, receipt.Logs)
+	if redeemErr != nil {
+		return nil, redeemErr
+	}
+	if redeemStatus != LegFailed {
+		leg.Status = redeemStatus
+		leg.RedeemTxHash = redeemTxHash
+		return leg, nil
+	}
+
+	// The auto-redeem failed. While the ticket is alive it can still be redeemed manually; once it is gone it was
+	// either redeemed manually or it expired.
+	_, timeoutErr := GetRetryableTicketTimeout(ctx, childClient, childTxHash)
+	if timeoutErr == nil {
+		leg.Status = LegFailed
+		return leg, nil
+	} else if !errors.Is(timeoutErr, ErrRetryableTicketNotFound) {
+		return nil, timeoutErr
+	}
+
+	redeemTxHash, redeemStatus, redeemErr = findManualRedeem(ctx, childClient, childTxHash, receipt.BlockNumber.Uint64())
+	if redeemErr != nil {
+		return nil, redeemErr
+	}
+	if redeemStatus == LegRedeemed {
+		leg.Status = LegRedeemed
+		leg.RedeemTxHash = redeemTxHash
+		return leg, nil
+	}
+
+	leg.Status = LegExpired
+	return leg, nil
+}
+
+// Looks for a successful redeem among the RedeemScheduled events in the given logs. Returns LegPending if a redeem was
+// scheduled but has not been mined yet, and LegFailed if none of the scheduled redeems succeeded.
+func findSuccessfulRedeem(ctx context.Context, childClient Backend, ticketID common.Hash, logs []*types.Log) (common.Hash, LegStatus, error) {
+	arbRetryableTxAbi, arbRetryableTxAbiErr := abi.JSON(strings.NewReader(ArbRetryableTx.ArbRetryableTxABI))
+	if arbRetryableTxAbiErr != nil {
+		return common.Hash{}, LegPending, arbRetryableTxAbiErr
+	}
+	redeemScheduledTopic := arbRetryableTxAbi.Events["RedeemScheduled"].ID
+
+	arbRetryableTxFilterer, arbRetryableTxFiltererErr := ArbRetryableTx.NewArbRetryableTxFilterer(ARB_RETRYABLE_TX_ADDRESS, childClient)
+	if arbRetryableTxFiltererErr != nil {
+		return common.Hash{}, LegPending, arbRetryableTxFiltererErr
+	}
+
+	status := LegFailed
+	for _, log := range logs {
+		if log.Address != ARB_RETRYABLE_TX_ADDRESS || len(log.Topics) < 2 || log.Topics[0] != redeemScheduledTopic || log.Topics[1] != ticketID {
+			continue
+		}
+
+		event, eventErr := arbRetryableTxFilterer.ParseRedeemScheduled(*log)
+		if eventErr != nil {
+			return common.Hash{}, LegPending, eventErr
+		}
+
+		retryReceipt, retryReceiptErr := childClient.TransactionReceipt(ctx, event.RetryTxHash)
+		if errors.Is(retryReceiptErr, ethereum.NotFound) {
+			status = LegPending
+			continue
+		} else if retryReceiptErr != nil {
+			return common.Hash{}, LegPending, retryReceiptErr
+		}
+
+		if retryReceipt.Status == types.ReceiptStatusSuccessful {
+			return event.RetryTxHash, LegRedeemed, nil
+		}
+	}
+
+	return common.Hash{}, status, nil
+}
+
+// Searches the child chain for a manual redeem of the ticket, from the block it was created in up to the latest block,
+// in windows of OUTBOX_LOG_SEARCH_WINDOW blocks.
+func findManualRedeem(ctx context.Context, childClient Backend, ticketID common.Hash, fromBlock uint64) (common.Hash, LegStatus, error) {
+	arbRetryableTxAbi, arbRetryableTxAbiErr := abi.JSON(strings.NewReader(ArbRetryableTx.ArbRetryableTxABI))
+	if arbRetryableTxAbiErr != nil {
+		return common.Hash{}, LegPending, arbRetryableTxAbiErr
+	}
+	redeemScheduledTopic := arbRetryableTxAbi.Events["RedeemScheduled"].ID
+
+	latestBlock, latestBlockErr := childClient.BlockNumber(ctx)
+	if latestBlockErr != nil {
+		return common.Hash{}, LegPending, latestBlockErr
+	}
+
+	for windowStart := fromBlock; windowStart <= latestBlock; windowStart += OUTBOX_LOG_SEARCH_WINDOW + 1 {
+		windowEnd := windowStart + OUTBOX_LOG_SEARCH_WINDOW
+		if windowEnd > latestBlock {
+			windowEnd = latestBlock
+		}
+
+		logs, logsErr := childClient.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(windowStart),
+			ToBlock:   new(big.Int).SetUint64(windowEnd),
+			Addresses: []common.Address{ARB_RETRYABLE_TX_ADDRESS},
+			Topics:    [][]common.Hash{{redeemScheduledTopic}, {ticketID}},
+		})
+		if logsErr != nil {
+			return common.Hash{}, LegPending, logsErr
+		}
+
+		logPointers := make([]*types.Log, len(logs))
+		for i := range logs {
+			logPointers[i] = &logs[i]
+		}
+
+		redeemTxHash, status, redeemErr := findSuccessfulRedeem(ctx, childClient, ticketID, logPointers)
+		if redeemErr != nil || status == LegRedeemed {
+			return redeemTxHash, status, redeemErr
+		}
+	}
+
+	return common.Hash{}, LegFailed, nil
+}
+
+// Follows a parent-to-child transfer through every chain it touches. Messages that a redeemed ticket delivers onwards
+// (for example the L2 forwarder of a teleport bridging to L3) are followed on the next client in childClients.
+// bridgeAddresses holds the bridge of each of these chains on its parent chain.
+func getParentToChildTransferLegs(ctx context.Context, receipt *types.Receipt, childClients []Backend, bridgeAddresses []common.Address) ([]*TransferLeg, error) {
+	if len(childClients) == 0 || childClients[0] == nil {
+		return []*TransferLeg{}, nil
+	}
+	childClient := childClients[0]
+
+	childChainID, childChainIDErr := childClient.ChainID(ctx)
+	if childChainIDErr != nil {
+		return nil, childChainIDErr
+	}
+
+	messages, messagesErr := GetParentToChildMessages(receipt, bridgeAddresses[0], childChainID)
+	if messagesErr != nil {
+		return nil, messagesErr
+	}
+
+	legs := []*TransferLeg{}
+	for _, message := range messages {
+		leg, legErr := GetParentToChildMessageStatus(ctx, childClient, message)
+		if legErr != nil {
+			return nil, legErr
+		}
+		legs = append(legs, leg)
+
+		if leg.Kind != RetryableLeg || leg.Status != LegRedeemed || len(childClients) < 2 {
+			continue
+		}
+
+		redeemReceipt, redeemReceiptErr := childClient.TransactionReceipt(ctx, leg.RedeemTxHash)
+		if redeemReceiptErr != nil {
+			return nil, redeemReceiptErr
+		}
+
+		nextLegs, nextLegsErr := getParentToChildTransferLegs(ctx, redeemReceipt, childClients[1:], bridgeAddresses[1:])
+		if nextLegsErr != nil {
+			return nil, nextLegsErr
+		}
+		legs = append(legs, nextLegs...)
+	}
+
+	return legs, nil
+}
+
+// Reports the status of every leg of the cross-chain transfer started by the given transaction.
+//
+// If the transaction is found on L1, it is treated as a deposit or teleport: retryable tickets and deposits delivered
+// by the L2 bridge (l2BridgeAddress, on L1) are followed to L2 and, when l3Rpc is set, on to L3 through the L3 bridge
+// (l3BridgeAddress, on L2). Otherwise, the transaction is looked up on L2 and its L2-to-L1 messages are checked against
+// the outbox (if outboxAddress is set) or reported as pending.
+func TransferStatus(ctx context.Context, l1Rpc string, l2Rpc string, l3Rpc string, l2BridgeAddress common.Address, l3BridgeAddress common.Address, outboxAddress common.Address, txHash common.Hash, lookback uint64) ([]*TransferLeg, error) {
+	bridger, bridgerErr := DialBridger(ctx, l1Rpc, l2Rpc, l3Rpc, nil)
+	if bridgerErr != nil {
+		return nil, bridgerErr
+	}
+
+	return bridger.Status(ctx, l2BridgeAddress, l3BridgeAddress, outboxAddress, txHash, lookback)
+}
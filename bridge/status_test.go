@@ -0,0 +1,145 @@
+package bridge
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/G7DAO/protocol/bindings/ArbRetryableTx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// revertError is the error that an RPC returns for a call that reverted, with the revert data.
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string          { return "execution reverted" }
+func (e *revertError) ErrorCode() int         { return 3 }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+// Returns the RedeemScheduled event that ArbRetryableTx emits when it schedules a redeem of the ticket.
+func redeemScheduledLog(t *testing.T, ticketID common.Hash, retryTxHash common.Hash, blockNumber uint64) types.Log {
+	arbRetryableTxAbi, arbRetryableTxAbiErr := ArbRetryableTx.ArbRetryableTxMetaData.GetAbi()
+	if arbRetryableTxAbiErr != nil {
+		t.Fatal(arbRetryableTxAbiErr)
+	}
+	event := arbRetryableTxAbi.Events["RedeemScheduled"]
+
+	data, dataErr := event.Inputs.NonIndexed().Pack(uint64(100_000), common.HexToAddress("0xd0"), big.NewInt(0), big.NewInt(0))
+	if dataErr != nil {
+		t.Fatal(dataErr)
+	}
+
+	return types.Log{
+		Address:     ARB_RETRYABLE_TX_ADDRESS,
+		BlockNumber: blockNumber,
+		Topics:      []common.Hash{event.ID, ticketID, retryTxHash, {}},
+		Data:        data,
+	}
+}
+
+func TestGetParentToChildMessageStatus(t *testing.T) {
+	ctx := context.Background()
+	sender := common.HexToAddress("0x5e")
+
+	ticket := &ParentToChildMessage{ChildChainID: big.NewInt(2187), MessageIndex: big.NewInt(7), Kind: L1_MESSAGE_TYPE_SUBMIT_RETRYABLE_TX, From: sender, L1BaseFee: big.NewInt(1)}
+	if decodeErr := ticket.decodeInboxData(retryableInboxData(common.HexToAddress("0x70"), big.NewInt(1), big.NewInt(2), big.NewInt(3), sender, sender, big.NewInt(100_000), big.NewInt(100_000_000), nil)); decodeErr != nil {
+		t.Fatal(decodeErr)
+	}
+	ticketID, ticketIDErr := ticket.ChildTxHash()
+	if ticketIDErr != nil {
+		t.Fatal(ticketIDErr)
+	}
+
+	deposit := &ParentToChildMessage{ChildChainID: big.NewInt(2187), MessageIndex: big.NewInt(8), Kind: L1_MESSAGE_TYPE_ETH_DEPOSIT, From: sender}
+	if decodeErr := deposit.decodeInboxData(depositInboxData(sender, big.NewInt(5))); decodeErr != nil {
+		t.Fatal(decodeErr)
+	}
+	depositTxHash, depositTxHashErr := deposit.ChildTxHash()
+	if depositTxHashErr != nil {
+		t.Fatal(depositTxHashErr)
+	}
+
+	autoRedeem := common.HexToHash("0xa0")
+	manualRedeem := common.HexToHash("0xa1")
+	succeeded := &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(10)}
+	failed := &types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(10)}
+	scheduled := redeemScheduledLog(t, ticketID, autoRedeem, 10)
+	created := &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(10), Logs: []*types.Log{&scheduled}}
+
+	arbRetryableTxAbi, arbRetryableTxAbiErr := ArbRetryableTx.ArbRetryableTxMetaData.GetAbi()
+	if arbRetryableTxAbiErr != nil {
+		t.Fatal(arbRetryableTxAbiErr)
+	}
+	noTicketWithID := arbRetryableTxAbi.Errors["NoTicketWithID"].ID
+	// getTimeout reverts with NoTicketWithID once the ticket has been redeemed or has expired.
+	ticketGone := func(to common.Address, data []byte) ([]byte, error) {
+		return nil, &revertError{data: common.Bytes2Hex(noTicketWithID[:4])}
+	}
+	ticketAlive := func(to common.Address, data []byte) ([]byte, error) {
+		return arbRetryableTxAbi.Methods["getTimeout"].Outputs.Pack(big.NewInt(1_800_000_000))
+	}
+
+	cases := []struct {
+		name         string
+		message      *ParentToChildMessage
+		receipts     map[common.Hash]*types.Receipt
+		logs         []types.Log
+		call         func(to common.Address, data []byte) ([]byte, error)
+		status       LegStatus
+		redeemTxHash common.Hash
+	}{
+		{name: "ticket not created yet", message: ticket, status: LegPending},
+		{name: "deposit", message: deposit, receipts: map[common.Hash]*types.Receipt{depositTxHash: succeeded}, status: LegRedeemed, redeemTxHash: depositTxHash},
+		{name: "deposit not executed yet", message: deposit, status: LegPending},
+		{name: "ticket creation failed", message: ticket, receipts: map[common.Hash]*types.Receipt{ticketID: failed}, status: LegFailed},
+		{
+			name:         "auto-redeemed",
+			message:      ticket,
+			receipts:     map[common.Hash]*types.Receipt{ticketID: created, autoRedeem: succeeded},
+			status:       LegRedeemed,
+			redeemTxHash: autoRedeem,
+		},
+		{name: "auto-redeem not mined yet", message: ticket, receipts: map[common.Hash]*types.Receipt{ticketID: created}, status: LegPending},
+		{
+			name:     "auto-redeem failed",
+			message:  ticket,
+			receipts: map[common.Hash]*types.Receipt{ticketID: created, autoRedeem: failed},
+			call:     ticketAlive,
+			status:   LegFailed,
+		},
+		{
+			name:         "manually redeemed",
+			message:      ticket,
+			receipts:     map[common.Hash]*types.Receipt{ticketID: created, autoRedeem: failed, manualRedeem: succeeded},
+			logs:         []types.Log{scheduled, redeemScheduledLog(t, ticketID, manualRedeem, 5000)},
+			call:         ticketGone,
+			status:       LegRedeemed,
+			redeemTxHash: manualRedeem,
+		},
+		{
+			name:     "expired",
+			message:  ticket,
+			receipts: map[common.Hash]*types.Receipt{ticketID: created, autoRedeem: failed},
+			logs:     []types.Log{scheduled, redeemScheduledLog(t, common.HexToHash("0xff"), manualRedeem, 5000)},
+			call:     ticketGone,
+			status:   LegExpired,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			child := &fakeBackend{latestBlock: 2 * OUTBOX_LOG_SEARCH_WINDOW, receipts: c.receipts, logs: c.logs, call: c.call}
+
+			leg, legErr := GetParentToChildMessageStatus(ctx, child, c.message)
+			if legErr != nil {
+				t.Fatal(legErr)
+			}
+			if leg.Status != c.status || leg.RedeemTxHash != c.redeemTxHash {
+				t.Errorf("Expected status %s with redeem %s, got %s with redeem %s", c.status, c.redeemTxHash.Hex(), leg.Status, leg.RedeemTxHash.Hex())
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var ErrTransactionTimeout = errors.New("timed out waiting for transaction to be mined")
+
+// Waits for the transaction sent for the given leg of a transfer (for example "deposit" or "approval") to be mined. If
+// the context's deadline passes first, the error wraps ErrTransactionTimeout and names the leg and the transaction, so
+// that the transfer can be followed up with the status command.
+func WaitForTransaction(ctx context.Context, backend bind.DeployBackend, transaction *types.Transaction, leg string) (*types.Receipt, error) {
+	receipt, receiptErr := bind.WaitMined(ctx, backend, transaction)
+	if receiptErr != nil {
+		if errors.Is(receiptErr, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: %s transaction %s", ErrTransactionTimeout, leg, transaction.Hash().Hex())
+		}
+		return nil, receiptErr
+	}
+	return receipt, nil
+}
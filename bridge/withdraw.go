@@ -0,0 +1,140 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/G7DAO/protocol/bindings/ArbSys"
+	"github.com/G7DAO/protocol/bindings/ArbitrumL2CustomGateway"
+	"github.com/G7DAO/protocol/safe"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Builds the calldata for an ArbSys withdrawal. Without L1 calldata this is a plain withdrawEth, otherwise the
+// calldata is forwarded to the destination on L1 with sendTxToL1.
+func GetNativeTokenWithdrawCalldata(to common.Address, l1Calldata []byte) ([]byte, error) {
+	arbSysAbi, arbSysAbiErr := abi.JSON(strings.NewReader(ArbSys.ArbSysABI))
+	if arbSysAbiErr != nil {
+		return nil, arbSysAbiErr
+	}
+
+	if len(l1Calldata) == 0 {
+		// function withdrawEth(address destination) external payable returns (uint256);
+		return arbSysAbi.Pack("withdrawEth", to)
+	}
+
+	// function sendTxToL1(address destination, bytes calldata data) external payable returns (uint256);
+	return arbSysAbi.Pack("sendTxToL1", to, l1Calldata)
+}
+
+func NativeTokenWithdrawCall(ctx context.Context, sender signer.Signer, l2Rpc string, to common.Address, amount *big.Int, l1Calldata []byte) (*types.Transaction, error) {
+	l2Client, l2ClientErr := ethclient.DialContext(ctx, l2Rpc)
+	if l2ClientErr != nil {
+		return nil, l2ClientErr
+	}
+
+	withdrawData, withdrawDataErr := GetNativeTokenWithdrawCalldata(to, l1Calldata)
+	if withdrawDataErr != nil {
+		return nil, withdrawDataErr
+	}
+
+	fmt.Println("Sending transaction...")
+	transaction, transactionErr := SendTransaction(ctx, l2Client, sender, withdrawData, ARB_SYS_ADDRESS.Hex(), amount)
+	if transactionErr != nil {
+		fmt.Fprintln(os.Stderr, transactionErr.Error())
+		return nil, transactionErr
+	}
+	fmt.Println("Transaction sent! Transaction hash:", transaction.Hash().Hex())
+
+	fmt.Println("Waiting for transaction to be mined...")
+	_, receiptErr := WaitForTransaction(ctx, l2Client, transaction, "withdrawal")
+	if receiptErr != nil {
+		fmt.Fprintln(os.Stderr, receiptErr.Error())
+		return nil, receiptErr
+	}
+	fmt.Println("Transaction mined!")
+
+	return transaction, nil
+}
+
+func NativeTokenWithdrawPropose(ctx context.Context, sender signer.Signer, l2Rpc string, to common.Address, amount *big.Int, l1Calldata []byte, safeAddress common.Address, safeClient *safe.Client, safeOut string, safeForce bool, safeOperation uint8, safeNonce *big.Int) error {
+	l2Client, l2ClientErr := ethclient.DialContext(ctx, l2Rpc)
+	if l2ClientErr != nil {
+		return l2ClientErr
+	}
+
+	withdrawData, withdrawDataErr := GetNativeTokenWithdrawCalldata(to, l1Calldata)
+	if withdrawDataErr != nil {
+		return withdrawDataErr
+	}
+
+	return safe.CreateProposal(ctx, l2Client, sender, safeClient, safeOut, safeForce, safeAddress, ARB_SYS_ADDRESS, withdrawData, amount, safe.OperationType(safeOperation), safeNonce)
+}
+
+// Builds the calldata for an ERC20 withdrawal through the L2 gateway router. The router has the same
+// outboundTransfer(address,address,uint256,bytes) interface as the L2 gateways, so the L2 custom gateway ABI is
+// used to encode it.
+func GetERC20WithdrawCalldata(l1TokenAddress common.Address, to common.Address, amount *big.Int) ([]byte, error) {
+	gatewayAbi, gatewayAbiErr := abi.JSON(strings.NewReader(ArbitrumL2CustomGateway.L2CustomGatewayABI))
+	if gatewayAbiErr != nil {
+		return nil, gatewayAbiErr
+	}
+
+	// function outboundTransfer(address _l1Token, address _to, uint256 _amount, bytes calldata _data) public payable returns (bytes memory);
+	return gatewayAbi.Pack("outboundTransfer", l1TokenAddress, to, amount, []byte{})
+}
+
+func ERC20WithdrawCall(ctx context.Context, routerAddress common.Address, sender signer.Signer, l2Rpc string, l1TokenAddress common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	l2Client, l2ClientErr := ethclient.DialContext(ctx, l2Rpc)
+	if l2ClientErr != nil {
+		fmt.Fprintln(os.Stderr, "l2ClientErr", l2ClientErr.Error())
+		return nil, l2ClientErr
+	}
+
+	callData, callDataErr := GetERC20WithdrawCalldata(l1TokenAddress, to, amount)
+	if callDataErr != nil {
+		fmt.Fprintln(os.Stderr, "callDataErr", callDataErr.Error())
+		return nil, callDataErr
+	}
+
+	fmt.Println("Sending transaction...")
+	transaction, transactionErr := SendTransaction(ctx, l2Client, sender, callData, routerAddress.Hex(), big.NewInt(0))
+	if transactionErr != nil {
+		fmt.Fprintln(os.Stderr, "transactionErr", transactionErr.Error())
+		return nil, transactionErr
+	}
+	fmt.Println("Transaction sent! Transaction hash:", transaction.Hash().Hex())
+
+	fmt.Println("Waiting for transaction to be mined...")
+	_, receiptErr := WaitForTransaction(ctx, l2Client, transaction, "withdrawal")
+	if receiptErr != nil {
+		fmt.Fprintln(os.Stderr, "receiptErr", receiptErr.Error())
+		return nil, receiptErr
+	}
+	fmt.Println("Transaction mined!")
+
+	return transaction, nil
+}
+
+func ERC20WithdrawPropose(ctx context.Context, routerAddress common.Address, sender signer.Signer, l2Rpc string, l1TokenAddress common.Address, to common.Address, amount *big.Int, safeAddress common.Address, safeClient *safe.Client, safeOut string, safeForce bool, safeOperation uint8, safeNonce *big.Int) error {
+	l2Client, l2ClientErr := ethclient.DialContext(ctx, l2Rpc)
+	if l2ClientErr != nil {
+		fmt.Fprintln(os.Stderr, "l2ClientErr", l2ClientErr.Error())
+		return l2ClientErr
+	}
+
+	callData, callDataErr := GetERC20WithdrawCalldata(l1TokenAddress, to, amount)
+	if callDataErr != nil {
+		fmt.Fprintln(os.Stderr, "callDataErr", callDataErr.Error())
+		return callDataErr
+	}
+
+	return safe.CreateProposal(ctx, l2Client, sender, safeClient, safeOut, safeForce, safeAddress, routerAddress, callData, big.NewInt(0), safe.OperationType(safeOperation), safeNonce)
+}
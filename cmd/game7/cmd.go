@@ -29,6 +29,8 @@ import (
 	"github.com/G7DAO/protocol/bridge"
 	terminus "github.com/G7DAO/protocol/cmd/game7/diamondLaunch"
 	"github.com/G7DAO/protocol/cmd/game7/version"
+	"github.com/G7DAO/protocol/safe"
+	"github.com/G7DAO/protocol/signer"
 )
 
 func CreateRootCommand() *cobra.Command {
@@ -77,6 +79,9 @@ func CreateRootCommand() *cobra.Command {
 	accountsCmd := accounts.CreateAccountsCommand()
 	accountsCmd.Use = "accounts"
 
+	safeCmd := safe.CreateSafeCommand()
+	safeCmd.Use = "safe"
+
 	wrappedNativeTokenCmd := WrappedNativeToken.CreateWrappedNativeTokenCommand()
 	wrappedNativeTokenCmd.Use = "wrapped-native-token"
 
@@ -99,7 +104,10 @@ func CreateRootCommand() *cobra.Command {
 
 	erc20OrbitBridgerCmd := ERC20OrbitBridger.CreateERC20OrbitBridgerCommand()
 
-	rootCmd.AddCommand(completionCmd, versionCmd, tokenCmd, arbitrumL1OrbitCustomGatewayCmd, arbitrumL2CustomGatewayCmd, arbitrumUpgradeExecutorCmd, arbitrumL1OrbitGatewayRouterCmd, arbSysCmd, erc20InboxCmd, bridgeCmd, faucetCmd, accountsCmd, wrappedNativeTokenCmd, stakerCmd, mockCmd, positionMetadataCmd, tokenSenderCmd, metronomeCmd, terminusCMD, usdcOrbitBridgerCmd, erc20OrbitBridgerCmd, nativeBalancesCmd)
+	rootCmd.AddCommand(completionCmd, versionCmd, tokenCmd, arbitrumL1OrbitCustomGatewayCmd, arbitrumL2CustomGatewayCmd, arbitrumUpgradeExecutorCmd, arbitrumL1OrbitGatewayRouterCmd, arbSysCmd, erc20InboxCmd, bridgeCmd, faucetCmd, accountsCmd, safeCmd, wrappedNativeTokenCmd, stakerCmd, mockCmd, positionMetadataCmd, tokenSenderCmd, metronomeCmd, terminusCMD, usdcOrbitBridgerCmd, erc20OrbitBridgerCmd, nativeBalancesCmd)
+
+	// Every command taking a password can also read it from a file or an environment variable.
+	signer.AddPasswordSourceFlags(rootCmd)
 
 	// By default, cobra Command objects write to stderr. We have to forcibly set them to output to
 	// stdout.
@@ -2,112 +2,533 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"math/big"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/G7DAO/protocol/bindings/Metronome"
+	"github.com/G7DAO/protocol/signer"
 )
 
-func isBountyAvailable(nextBlockNumber *big.Int, metronome *Metronome.Metronome, scheduleID *big.Int) (bool, error) {
-	opts := &bind.CallOpts{}
-	schedule, err := metronome.Schedules(opts, scheduleID)
+// A Metronome schedule. The parameters of a schedule never change once it is created, so they are only read once.
+type Schedule struct {
+	ID        *big.Int
+	Remainder *big.Int
+	Divisor   *big.Int
+	Bounty    *big.Int
+}
+
+// Whether the schedule pays a bounty for claims made in the given block.
+func (s Schedule) IsDue(blockNumber *big.Int) bool {
+	if s.Divisor.Sign() == 0 {
+		return false
+	}
+	r := new(big.Int).Mod(blockNumber, s.Divisor)
+	return r.Cmp(s.Remainder) == 0
+}
+
+func loadSchedule(ctx context.Context, metronome *Metronome.Metronome, scheduleID *big.Int) (Schedule, error) {
+	schedule, err := metronome.Schedules(&bind.CallOpts{Context: ctx}, scheduleID)
 	if err != nil {
-		return false, err
+		return Schedule{}, wrapRPCError("eth_call", err)
 	}
-	r := new(big.Int).Mod(nextBlockNumber, schedule.Divisor)
-	return r.Cmp(schedule.Remainder) == 0, nil
+	return Schedule{ID: scheduleID, Remainder: schedule.Remainder, Divisor: schedule.Divisor, Bounty: schedule.Bounty}, nil
 }
 
-func Run(metronomeAddress common.Address, claimant *keystore.Key, client *ethclient.Client, intervalMilliseconds uint64, scheduleID *big.Int, resilient bool) error {
-	ctx := context.Background()
+// Loads the schedules with the given IDs. If scheduleIDs is nil, every schedule of the contract is loaded, starting
+// after the ones already loaded, so that schedules created while the bot runs are picked up.
+func loadSchedules(ctx context.Context, metronome *Metronome.Metronome, scheduleIDs []*big.Int, loaded []Schedule) ([]Schedule, error) {
+	if scheduleIDs == nil {
+		numSchedules, numSchedulesErr := metronome.NumSchedules(&bind.CallOpts{Context: ctx})
+		if numSchedulesErr != nil {
+			return loaded, wrapRPCError("eth_call", numSchedulesErr)
+		}
+		for i := int64(len(loaded)); i < numSchedules.Int64(); i++ {
+			scheduleIDs = append(scheduleIDs, big.NewInt(i))
+		}
+	} else if len(loaded) == len(scheduleIDs) {
+		return loaded, nil
+	} else {
+		scheduleIDs = scheduleIDs[len(loaded):]
+	}
 
-	metronome, metronomeErr := Metronome.NewMetronome(metronomeAddress, client)
-	if metronomeErr != nil {
-		return fmt.Errorf("failed to create Metronome contract binding: %s", metronomeErr.Error())
+	for _, scheduleID := range scheduleIDs {
+		schedule, scheduleErr := loadSchedule(ctx, metronome, scheduleID)
+		if scheduleErr != nil {
+			return loaded, fmt.Errorf("failed to load schedule %s: %w", scheduleID.String(), scheduleErr)
+		}
+		loaded = append(loaded, schedule)
 	}
+	return loaded, nil
+}
 
-	chainID, chainIDErr := client.ChainID(ctx)
-	if chainIDErr != nil {
-		return chainIDErr
+// Returns the first block after the given one in which the schedule pays a bounty, or nil if it never does.
+func (s Schedule) NextDueBlock(after *big.Int) *big.Int {
+	if s.Divisor.Sign() == 0 || s.Remainder.Cmp(s.Divisor) >= 0 {
+		return nil
+	}
+	next := new(big.Int).Add(after, big.NewInt(1))
+	delta := new(big.Int).Sub(s.Remainder, new(big.Int).Mod(next, s.Divisor))
+	delta.Mod(delta, s.Divisor)
+	return next.Add(next, delta)
+}
+
+// Returns the first block after the given one in which any of the schedules pays a bounty, or nil if none of them
+// ever does.
+func NextDueBlock(schedules []Schedule, after *big.Int) *big.Int {
+	var next *big.Int
+	for _, schedule := range schedules {
+		scheduleNext := schedule.NextDueBlock(after)
+		if scheduleNext != nil && (next == nil || scheduleNext.Cmp(next) < 0) {
+			next = scheduleNext
+		}
+	}
+	return next
+}
+
+// Returns the schedules that pay a bounty for claims made in the given block.
+func DueSchedules(schedules []Schedule, blockNumber *big.Int) []Schedule {
+	var due []Schedule
+	for _, schedule := range schedules {
+		if schedule.IsDue(blockNumber) {
+			due = append(due, schedule)
+		}
+	}
+	return due
+}
+
+// Returns the IDs of the given schedules, in order.
+func scheduleIDsOf(schedules []Schedule) []*big.Int {
+	ids := make([]*big.Int, len(schedules))
+	for i, schedule := range schedules {
+		ids[i] = schedule.ID
+	}
+	return ids
+}
+
+// Returns the Metronome method and arguments that claim the bounties of the given schedules for the claimant: claim for
+// a single schedule, and claimBatch for several of them.
+func claimArguments(schedules []Schedule, claimant common.Address) (string, []interface{}) {
+	if len(schedules) == 1 {
+		return "claim", []interface{}{schedules[0].ID, claimant}
+	}
+	return "claimBatch", []interface{}{scheduleIDsOf(schedules), claimant}
+}
+
+// Claims the bounties of the given schedules for the claimant, in a single claimBatch transaction if there are several
+// of them, like claimArguments.
+func claimSchedules(txOpts *bind.TransactOpts, metronome *Metronome.Metronome, schedules []Schedule, claimant common.Address) (*types.Transaction, error) {
+	if len(schedules) == 1 {
+		return metronome.Claim(txOpts, schedules[0].ID, claimant)
+	}
+	return metronome.ClaimBatch(txOpts, scheduleIDsOf(schedules), claimant)
+}
+
+// Delays before subscribing to new blocks again after the subscription fails, in resilient mode. The delay doubles
+// after each failed attempt, up to the maximum.
+const (
+	ResubscribeDelay    = time.Second
+	MaxResubscribeDelay = time.Minute
+)
+
+// A claim prepared for the given target block.
+type claim struct {
+	Target      *big.Int
+	Schedules   []Schedule
+	Estimate    ClaimEstimate
+	Transaction *types.Transaction
+}
+
+type bot struct {
+	ctx              context.Context
+	metronomeAddress common.Address
+	metronome        *Metronome.Metronome
+	client           *ethclient.Client
+	claimant         signer.Signer
+	txOpts           *bind.TransactOpts
+	scheduleIDs      []*big.Int
+	schedules        []Schedule
+	guard            ProfitGuard
+	bumpAfterBlocks  uint64
+	feeBumpPercent   uint64
+	resilient        bool
+	metrics          *Metrics
+
+	// The last block seen by the bot.
+	lastBlock *big.Int
+	// The nonce of the next claim, or nil if it has to be fetched from the client.
+	nonce *uint64
+	// Claims that have been sent and not mined yet, by increasing nonce.
+	sent []*sentClaim
+}
+
+// Reports an error that happened while running the bot. In resilient mode the error is logged and nil is returned so
+// that the bot keeps running, otherwise the error is returned.
+func (b *bot) fail(err error) error {
+	b.metrics.ObserveError(err)
+	if b.resilient {
+		slog.Error("Robognome error", "error", err)
+		return nil
 	}
+	return err
+}
 
-	txOpts, txOptsErr := bind.NewKeyedTransactorWithChainID(claimant.PrivateKey, chainID)
-	if txOptsErr != nil {
+// Records the latest block header, and the balance of the claimant whenever a new block is seen.
+func (b *bot) observeBlock(header *types.Header) {
+	// Polling the same block again does not count as seeing a block, so that the bot turns unhealthy when the chain
+	// stalls.
+	if b.lastBlock != nil && b.lastBlock.Cmp(header.Number) == 0 {
+		return
+	}
+	b.lastBlock = header.Number
+	b.metrics.ObserveBlock(header.Number)
 
+	balance, balanceErr := b.client.BalanceAt(b.ctx, b.claimant.Address(), header.Number)
+	if balanceErr != nil {
+		b.metrics.ObserveError(wrapRPCError("eth_getBalance", balanceErr))
+		slog.Warn("Could not get balance of claimant", "error", balanceErr)
+		return
+	}
+	b.metrics.ClaimantBalance.Set(weiToFloat(balance))
+}
+
+func (b *bot) refreshSchedules() error {
+	var schedulesErr error
+	b.schedules, schedulesErr = loadSchedules(b.ctx, b.metronome, b.scheduleIDs, b.schedules)
+	if schedulesErr != nil {
+		return fmt.Errorf("failed to load schedules: %w", schedulesErr)
+	}
+	return nil
+}
+
+// Prepares a claim of the bounties due on the target block, given the latest block header. The claim transaction is
+// signed but not sent. Returns nil if there is nothing to claim on the target block or if the claim is not profitable.
+func (b *bot) prepareClaim(target *big.Int, header *types.Header) (*claim, error) {
+	due, payout, dueErr := payingSchedules(b.ctx, b.metronome, DueSchedules(b.schedules, target))
+	if dueErr != nil {
+		return nil, dueErr
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		var gasPriceErr error
+		baseFee, gasPriceErr = b.client.SuggestGasPrice(b.ctx)
+		if gasPriceErr != nil {
+			return nil, fmt.Errorf("failed to get gas price: %w", wrapRPCError("eth_gasPrice", gasPriceErr))
+		}
 	}
 
-	interval := time.Duration(intervalMilliseconds) * time.Millisecond
+	estimate, estimateErr := estimateClaim(b.ctx, b.client, b.metronomeAddress, due, b.claimant.Address(), baseFee, payout)
+	if estimateErr != nil {
+		return nil, estimateErr
+	}
+	if !b.guard.Profitable(estimate) {
+		slog.Info("Skipping unprofitable claim", "block", target, "schedules", len(due), "payout", estimate.Payout, "threshold", b.guard.Threshold(estimate.Cost), "gas", estimate.Gas, "baseFee", estimate.BaseFee)
+		return nil, nil
+	}
+
+	nonce, nonceErr := b.nextNonce()
+	if nonceErr != nil {
+		return nil, nonceErr
+	}
+
+	claimOpts := *b.txOpts
+	claimOpts.GasLimit = estimate.Gas
+	claimOpts.Nonce = new(big.Int).SetUint64(nonce)
+	claimOpts.NoSend = true
+	claimTx, claimTxErr := claimSchedules(&claimOpts, b.metronome, due, b.claimant.Address())
+	if claimTxErr != nil {
+		return nil, fmt.Errorf("could not sign claim transaction: %s", claimTxErr.Error())
+	}
+
+	return &claim{Target: target, Schedules: due, Estimate: estimate, Transaction: claimTx}, nil
+}
+
+// Sends a prepared claim and keeps track of it until it is mined, given the latest block header.
+func (b *bot) sendClaim(c *claim, header *types.Header) error {
+	if sendErr := b.client.SendTransaction(b.ctx, c.Transaction); sendErr != nil {
+		// The nonce may be out of sync with the client, for instance if the claimant sent a transaction of its own.
+		b.nonce = nil
+		return fmt.Errorf("could not submit claim transaction: %w", wrapRPCError("eth_sendRawTransaction", sendErr))
+	}
+	nonce := c.Transaction.Nonce() + 1
+	b.nonce = &nonce
+	b.sent = append(b.sent, &sentClaim{
+		Claim:       c,
+		Transaction: c.Transaction,
+		Versions:    []sentVersion{{Hash: c.Transaction.Hash()}},
+		SentAt:      header.Number,
+	})
+	b.metrics.ClaimsAttempted.Inc()
+	slog.Info("Claim transaction sent", "block", c.Target, "schedules", len(c.Schedules), "transaction", c.Transaction.Hash(), "nonce", c.Transaction.Nonce(), "payout", c.Estimate.Payout, "cost", c.Estimate.Cost)
+	return nil
+}
+
+// Checks for bounties every interval and claims the ones due on the next block.
+func (b *bot) poll(interval time.Duration, interruptHandler chan os.Signal) error {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
-	interruptHandler := make(chan os.Signal, 1)
-	signal.Notify(interruptHandler, os.Interrupt, syscall.SIGTERM)
+	// The last block for which a claim was prepared, whether or not it was profitable.
+	var prepared *big.Int
 
 	for {
 		select {
 		case <-ticker.C:
-			fmt.Println("Checking bounty availability")
-
-			blockNumber, blockNumberErr := client.BlockNumber(ctx)
-			if blockNumberErr != nil {
-				resultErr := fmt.Errorf("failed to retrieve block number from the Ethereum client: %s", blockNumberErr.Error())
-				if resilient {
-					fmt.Fprint(os.Stderr, resultErr.Error())
-					continue
-				} else {
-					return resultErr
+			slog.Debug("Checking bounty availability")
+
+			if schedulesErr := b.refreshSchedules(); schedulesErr != nil {
+				if err := b.fail(schedulesErr); err != nil {
+					return err
+				}
+				continue
+			}
+
+			header, headerErr := b.client.HeaderByNumber(b.ctx, nil)
+			if headerErr != nil {
+				if err := b.fail(fmt.Errorf("failed to retrieve latest block from the Ethereum client: %w", wrapRPCError("eth_getBlockByNumber", headerErr))); err != nil {
+					return err
 				}
+				continue
 			}
-			nextBlockNumber := new(big.Int).SetUint64(blockNumber + 1)
-			fmt.Printf("Next block number: %s\n", nextBlockNumber.String())
-
-			bountyAvailable, bountyAvailableErr := isBountyAvailable(nextBlockNumber, metronome, scheduleID)
-			if bountyAvailableErr != nil {
-				resultErr := fmt.Errorf("failed to check if bounty is available: %s", bountyAvailableErr.Error())
-				if resilient {
-					fmt.Fprint(os.Stderr, resultErr.Error())
-					continue
-				} else {
-					return resultErr
+			b.observeBlock(header)
+			nextBlockNumber := new(big.Int).Add(header.Number, big.NewInt(1))
+			if prepared != nil && prepared.Cmp(nextBlockNumber) >= 0 {
+				continue
+			}
+			slog.Debug("Next block", "block", nextBlockNumber)
+
+			if sentErr := b.checkSentClaims(header); sentErr != nil {
+				if err := b.fail(sentErr); err != nil {
+					return err
+				}
+			}
+
+			c, claimErr := b.prepareClaim(nextBlockNumber, header)
+			if claimErr != nil {
+				if err := b.fail(claimErr); err != nil {
+					return err
 				}
+				continue
+			}
+			if c == nil {
+				prepared = nextBlockNumber
+				continue
 			}
 
-			if bountyAvailable {
-				claimTx, claimTxErr := metronome.Claim(txOpts, scheduleID, claimant.Address)
-				if claimTxErr != nil {
-					resultErr := fmt.Errorf("could not submit claim transaction: %s", claimTxErr.Error())
-					if resilient {
-						fmt.Fprint(os.Stderr, resultErr.Error())
-						continue
-					} else {
-						return resultErr
+			if sendErr := b.sendClaim(c, header); sendErr != nil {
+				if err := b.fail(sendErr); err != nil {
+					return err
+				}
+				continue
+			}
+			prepared = nextBlockNumber
+		case <-interruptHandler:
+			slog.Info("Robognome massacre")
+			return nil
+		}
+	}
+}
+
+// Follows new blocks over a subscription. The claim for the next block in which bounties are due is signed as soon as
+// the block two blocks before it arrives, and broadcast as soon as the block right before it arrives. In resilient
+// mode, the bot subscribes again when the subscription fails or cannot be made, waiting longer after each failure.
+func (b *bot) subscribe(interruptHandler chan os.Signal) error {
+	delay := ResubscribeDelay
+	for {
+		var subscribeErr error
+		headers := make(chan *types.Header)
+		subscription, subscriptionErr := b.client.SubscribeNewHead(b.ctx, headers)
+		if subscriptionErr != nil {
+			subscribeErr = fmt.Errorf("failed to subscribe to new blocks: %w", wrapRPCError("eth_subscribe", subscriptionErr))
+		} else {
+			delay = ResubscribeDelay
+			subscribeErr = b.follow(headers, subscription, interruptHandler)
+			subscription.Unsubscribe()
+			if subscribeErr == nil {
+				return nil
+			}
+		}
+
+		if err := b.fail(subscribeErr); err != nil {
+			return err
+		}
+
+		slog.Info("Resubscribing to new blocks", "delay", delay)
+		select {
+		case <-time.After(delay):
+		case <-interruptHandler:
+			slog.Info("Robognome massacre")
+			return nil
+		}
+		delay = min(2*delay, MaxResubscribeDelay)
+	}
+}
+
+// Handles new blocks until the subscription fails, in which case its error is returned, or until the bot is
+// interrupted.
+func (b *bot) follow(headers chan *types.Header, subscription ethereum.Subscription, interruptHandler chan os.Signal) error {
+	var pending *claim
+	// The last target block for which a claim was prepared, whether or not it was profitable.
+	var prepared *big.Int
+
+	for {
+		select {
+		case header := <-headers:
+			nextBlockNumber := new(big.Int).Add(header.Number, big.NewInt(1))
+
+			if pending != nil && pending.Target.Cmp(nextBlockNumber) < 0 {
+				slog.Warn("Missed claim", "block", pending.Target)
+				pending = nil
+			}
+			if pending != nil && pending.Target.Cmp(nextBlockNumber) == 0 {
+				sendErr := b.sendClaim(pending, header)
+				pending = nil
+				if sendErr != nil {
+					if err := b.fail(sendErr); err != nil {
+						return err
 					}
 				}
-				fmt.Printf("Claim transaction: %s\n", claimTx.Hash().String())
-				_, claimTxReceiptErr := bind.WaitMined(ctx, client, claimTx)
-				if claimTxReceiptErr != nil {
-					resultErr := fmt.Errorf("could not mine claim transaction: %s", claimTxReceiptErr.Error())
-					if resilient {
-						fmt.Fprint(os.Stderr, resultErr.Error())
-						continue
-					} else {
-						return resultErr
+			}
+
+			b.observeBlock(header)
+			if sentErr := b.checkSentClaims(header); sentErr != nil {
+				if err := b.fail(sentErr); err != nil {
+					return err
+				}
+			}
+
+			if schedulesErr := b.refreshSchedules(); schedulesErr != nil {
+				if err := b.fail(schedulesErr); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if pending != nil {
+				continue
+			}
+
+			after := header.Number
+			if prepared != nil && prepared.Cmp(after) > 0 {
+				after = prepared
+			}
+			target := NextDueBlock(b.schedules, after)
+			// Claims are signed one block ahead, so that their fees and payouts are up to date.
+			if target == nil || new(big.Int).Sub(target, nextBlockNumber).Cmp(big.NewInt(1)) > 0 {
+				continue
+			}
+			prepared = target
+
+			c, claimErr := b.prepareClaim(target, header)
+			if claimErr != nil {
+				if err := b.fail(claimErr); err != nil {
+					return err
+				}
+				continue
+			}
+			if c == nil {
+				continue
+			}
+
+			if c.Target.Cmp(nextBlockNumber) == 0 {
+				if sendErr := b.sendClaim(c, header); sendErr != nil {
+					if err := b.fail(sendErr); err != nil {
+						return err
 					}
 				}
-				fmt.Println("Claim transaction confirmed")
+				continue
 			}
+			slog.Info("Claim transaction signed", "block", c.Target, "schedules", len(c.Schedules), "transaction", c.Transaction.Hash())
+			pending = c
+		case subscriptionErr := <-subscription.Err():
+			return fmt.Errorf("subscription to new blocks failed: %w", wrapRPCError("eth_subscribe", subscriptionErr))
 		case <-interruptHandler:
-			fmt.Printf("Robognome massacre")
+			slog.Info("Robognome massacre")
 			return nil
 		}
 	}
 }
+
+// Runs the bot, claiming the bounties of the schedules with the given IDs, or of every schedule of the contract if
+// scheduleIDs is nil. Claims that the guard does not consider profitable are skipped.
+//
+// Claims are sent without waiting for the previous ones to be mined. A claim whose target block has passed without it
+// being mined is cancelled with fees bumped by feeBumpPercent percent, and a cancellation that is still pending
+// bumpAfterBlocks blocks after it was sent is sent again with fees bumped once more.
+//
+// If the client supports subscriptions (over WebSocket or IPC), the bot follows new blocks as they arrive and broadcasts
+// presigned claims right after the block before the one they are due on. Otherwise it polls for new blocks every
+// interval milliseconds.
+//
+// If listenAddress is set, the bot serves its health on /healthz and Prometheus metrics on /metrics at that address.
+func Run(metronomeAddress common.Address, claimant signer.Signer, client *ethclient.Client, intervalMilliseconds uint64, scheduleIDs []*big.Int, guard ProfitGuard, bumpAfterBlocks, feeBumpPercent uint64, resilient bool, listenAddress string) error {
+	ctx := context.Background()
+
+	metronome, metronomeErr := Metronome.NewMetronome(metronomeAddress, client)
+	if metronomeErr != nil {
+		return fmt.Errorf("failed to create Metronome contract binding: %s", metronomeErr.Error())
+	}
+
+	chainID, chainIDErr := client.ChainID(ctx)
+	if chainIDErr != nil {
+		return chainIDErr
+	}
+
+	b := &bot{
+		ctx:              ctx,
+		metronomeAddress: metronomeAddress,
+		metronome:        metronome,
+		client:           client,
+		claimant:         claimant,
+		txOpts:           signer.NewTransactOpts(ctx, claimant, chainID),
+		scheduleIDs:      scheduleIDs,
+		guard:            guard,
+		bumpAfterBlocks:  bumpAfterBlocks,
+		feeBumpPercent:   feeBumpPercent,
+		resilient:        resilient,
+		metrics:          NewMetrics(),
+	}
+
+	if listenAddress != "" {
+		listener, listenErr := net.Listen("tcp", listenAddress)
+		if listenErr != nil {
+			return fmt.Errorf("failed to listen on %s: %s", listenAddress, listenErr.Error())
+		}
+		server := &http.Server{Handler: b.metrics.Handler(), ReadHeaderTimeout: 10 * time.Second}
+		defer server.Close()
+		go func() {
+			if serveErr := server.Serve(listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+				slog.Error("HTTP server stopped", "error", serveErr)
+			}
+		}()
+		slog.Info("Serving health and metrics", "address", listener.Addr().String())
+	}
+
+	interruptHandler := make(chan os.Signal, 1)
+	signal.Notify(interruptHandler, os.Interrupt, syscall.SIGTERM)
+
+	if client.Client().SupportsSubscriptions() {
+		slog.Info("Following new blocks over subscription")
+		return b.subscribe(interruptHandler)
+	}
+
+	slog.Info("RPC does not support subscriptions, polling for new blocks", "interval", time.Duration(intervalMilliseconds)*time.Millisecond)
+	return b.poll(time.Duration(intervalMilliseconds)*time.Millisecond, interruptHandler)
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+
+	"github.com/G7DAO/protocol/bindings/Metronome"
+)
+
+// Deploys a Metronome contract on a simulated chain, with transact options of an account holding 100 ETH.
+func newSimulatedMetronome(t *testing.T) (*simulated.Backend, *Metronome.Metronome, *bind.TransactOpts) {
+	privateKey, privateKeyErr := crypto.GenerateKey()
+	if privateKeyErr != nil {
+		t.Fatal(privateKeyErr)
+	}
+	txOpts, txOptsErr := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1337))
+	if txOptsErr != nil {
+		t.Fatal(txOptsErr)
+	}
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		txOpts.From: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))},
+	})
+	t.Cleanup(func() { backend.Close() })
+
+	_, _, metronome, deployErr := Metronome.DeployMetronome(txOpts, backend.Client())
+	if deployErr != nil {
+		t.Fatal(deployErr)
+	}
+	backend.Commit()
+
+	return backend, metronome, txOpts
+}
+
+// Creates a schedule funded with 10 bounties.
+func createSchedule(t *testing.T, backend *simulated.Backend, metronome *Metronome.Metronome, txOpts *bind.TransactOpts, remainder, divisor, bounty int64) {
+	opts := *txOpts
+	opts.Value = big.NewInt(10 * bounty)
+	if _, createErr := metronome.CreateSchedule(&opts, big.NewInt(remainder), big.NewInt(divisor), big.NewInt(bounty)); createErr != nil {
+		t.Fatal(createErr)
+	}
+	backend.Commit()
+}
+
+func TestDueSchedules(t *testing.T) {
+	schedules := []Schedule{
+		{ID: big.NewInt(0), Remainder: big.NewInt(0), Divisor: big.NewInt(1), Bounty: big.NewInt(100)},
+		{ID: big.NewInt(1), Remainder: big.NewInt(3), Divisor: big.NewInt(4), Bounty: big.NewInt(100)},
+		{ID: big.NewInt(2), Remainder: big.NewInt(1), Divisor: big.NewInt(2), Bounty: big.NewInt(100)},
+		{ID: big.NewInt(3), Remainder: big.NewInt(0), Divisor: big.NewInt(0), Bounty: big.NewInt(100)},
+	}
+
+	testCases := []struct {
+		blockNumber int64
+		expected    []int64
+	}{
+		{blockNumber: 4, expected: []int64{0}},
+		{blockNumber: 5, expected: []int64{0, 2}},
+		{blockNumber: 7, expected: []int64{0, 1, 2}},
+	}
+
+	for _, testCase := range testCases {
+		due := DueSchedules(schedules, big.NewInt(testCase.blockNumber))
+		if len(due) != len(testCase.expected) {
+			t.Fatalf("block %d: expected %d due schedules, got %d", testCase.blockNumber, len(testCase.expected), len(due))
+		}
+		for i, schedule := range due {
+			if schedule.ID.Int64() != testCase.expected[i] {
+				t.Errorf("block %d: expected schedule %d at position %d, got %d", testCase.blockNumber, testCase.expected[i], i, schedule.ID.Int64())
+			}
+		}
+	}
+}
+
+func TestNextDueBlock(t *testing.T) {
+	schedules := []Schedule{
+		{ID: big.NewInt(0), Remainder: big.NewInt(3), Divisor: big.NewInt(10), Bounty: big.NewInt(100)},
+		{ID: big.NewInt(1), Remainder: big.NewInt(5), Divisor: big.NewInt(7), Bounty: big.NewInt(100)},
+		{ID: big.NewInt(2), Remainder: big.NewInt(5), Divisor: big.NewInt(5), Bounty: big.NewInt(100)},
+	}
+
+	testCases := []struct {
+		after    int64
+		expected int64
+	}{
+		{after: 0, expected: 3},
+		{after: 3, expected: 5},
+		{after: 5, expected: 12},
+		{after: 12, expected: 13},
+		{after: 13, expected: 19},
+	}
+
+	for _, testCase := range testCases {
+		next := NextDueBlock(schedules, big.NewInt(testCase.after))
+		if next == nil || next.Int64() != testCase.expected {
+			t.Errorf("after block %d: expected next due block %d, got %v", testCase.after, testCase.expected, next)
+		}
+	}
+
+	if next := NextDueBlock(schedules[2:], big.NewInt(0)); next != nil {
+		t.Errorf("expected no due block for a schedule whose remainder is not below its divisor, got %s", next.String())
+	}
+}
+
+func TestObserveBlockIgnoresSameBlock(t *testing.T) {
+	b := &bot{metrics: NewMetrics(), lastBlock: big.NewInt(1234)}
+
+	b.observeBlock(&types.Header{Number: big.NewInt(1234)})
+	if b.metrics.Healthy() {
+		t.Error("expected the bot to stay unhealthy when it polls the same block again")
+	}
+}
+
+func TestLoadSchedules(t *testing.T) {
+	ctx := context.Background()
+	backend, metronome, txOpts := newSimulatedMetronome(t)
+	createSchedule(t, backend, metronome, txOpts, 0, 1, 100)
+	createSchedule(t, backend, metronome, txOpts, 1, 2, 200)
+
+	// Without schedule IDs, every schedule of the contract is loaded.
+	schedules, loadErr := loadSchedules(ctx, metronome, nil, nil)
+	if loadErr != nil {
+		t.Fatal(loadErr)
+	}
+	if len(schedules) != 2 || schedules[1].ID.Int64() != 1 || schedules[1].Remainder.Int64() != 1 || schedules[1].Divisor.Int64() != 2 || schedules[1].Bounty.Int64() != 200 {
+		t.Fatalf("unexpected schedules %+v", schedules)
+	}
+
+	// Schedules created later are picked up after the ones already loaded.
+	createSchedule(t, backend, metronome, txOpts, 2, 3, 300)
+	schedules, loadErr = loadSchedules(ctx, metronome, nil, schedules)
+	if loadErr != nil {
+		t.Fatal(loadErr)
+	}
+	if len(schedules) != 3 || schedules[2].ID.Int64() != 2 || schedules[2].Bounty.Int64() != 300 {
+		t.Fatalf("unexpected schedules %+v", schedules)
+	}
+
+	// With schedule IDs, only those are loaded, once.
+	selected, selectErr := loadSchedules(ctx, metronome, []*big.Int{big.NewInt(2)}, nil)
+	if selectErr != nil {
+		t.Fatal(selectErr)
+	}
+	if len(selected) != 1 || selected[0].ID.Int64() != 2 || selected[0].Divisor.Int64() != 3 {
+		t.Fatalf("unexpected schedules %+v", selected)
+	}
+	reloaded, reloadErr := loadSchedules(ctx, metronome, []*big.Int{big.NewInt(2)}, selected)
+	if reloadErr != nil {
+		t.Fatal(reloadErr)
+	}
+	if len(reloaded) != 1 || reloaded[0].ID != selected[0].ID {
+		t.Errorf("expected the loaded schedule to be kept, got %+v", reloaded)
+	}
+}
+
+func TestClaimArguments(t *testing.T) {
+	claimant := common.HexToAddress("0xc1")
+	schedules := []Schedule{{ID: big.NewInt(4)}, {ID: big.NewInt(7)}}
+
+	method, arguments := claimArguments(schedules[:1], claimant)
+	if method != "claim" || len(arguments) != 2 || arguments[0].(*big.Int).Int64() != 4 || arguments[1] != claimant {
+		t.Errorf("unexpected claim of a single schedule: %s %v", method, arguments)
+	}
+
+	method, arguments = claimArguments(schedules, claimant)
+	scheduleIDs, ok := arguments[0].([]*big.Int)
+	if method != "claimBatch" || len(arguments) != 2 || !ok || len(scheduleIDs) != 2 || scheduleIDs[0].Int64() != 4 || scheduleIDs[1].Int64() != 7 || arguments[1] != claimant {
+		t.Errorf("unexpected claim of several schedules: %s %v", method, arguments)
+	}
+}
+
+func TestClaimSchedules(t *testing.T) {
+	ctx := context.Background()
+	backend, metronome, txOpts := newSimulatedMetronome(t)
+	// Schedules with a divisor of 1 are due in every block.
+	createSchedule(t, backend, metronome, txOpts, 0, 1, 100)
+	createSchedule(t, backend, metronome, txOpts, 0, 1, 200)
+
+	schedules, loadErr := loadSchedules(ctx, metronome, nil, nil)
+	if loadErr != nil {
+		t.Fatal(loadErr)
+	}
+	claimant := common.HexToAddress("0xc1")
+
+	for _, due := range [][]Schedule{schedules[:1], schedules} {
+		// The simulated chain estimates gas in the latest block, so claims are made in a block after it, in which no
+		// bounty has been claimed yet.
+		backend.Commit()
+
+		transaction, claimErr := claimSchedules(txOpts, metronome, due, claimant)
+		if claimErr != nil {
+			t.Fatal(claimErr)
+		}
+		backend.Commit()
+
+		// The transaction is the claim that the profit guard estimates.
+		calldata, calldataErr := claimCalldata(due, claimant)
+		if calldataErr != nil {
+			t.Fatal(calldataErr)
+		}
+		if !bytes.Equal(transaction.Data(), calldata) {
+			t.Errorf("claim of %d schedules: the transaction data does not match claimCalldata", len(due))
+		}
+
+		receipt, receiptErr := backend.Client().TransactionReceipt(ctx, transaction.Hash())
+		if receiptErr != nil {
+			t.Fatal(receiptErr)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful || len(receipt.Logs) != len(due) {
+			t.Errorf("claim of %d schedules: expected as many bounties claimed, got status %d with %d logs", len(due), receipt.Status, len(receipt.Logs))
+		}
+	}
+}
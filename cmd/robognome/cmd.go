@@ -2,14 +2,17 @@ package main
 
 import (
 	"fmt"
+	"log/slog"
 	"math/big"
 	"os"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 
 	"github.com/G7DAO/protocol/bindings/Metronome"
 	"github.com/G7DAO/protocol/cmd/robognome/version"
+	"github.com/G7DAO/protocol/signer"
 )
 
 func CreateRootCommand() *cobra.Command {
@@ -32,6 +35,9 @@ func CreateRootCommand() *cobra.Command {
 
 	rootCmd.AddCommand(completionCmd, versionCmd, metronomeCmd, runCmd)
 
+	// Every command taking a password can also read it from a file or an environment variable.
+	signer.AddPasswordSourceFlags(rootCmd)
+
 	// By default, cobra Command objects write to stderr. We have to forcibly set them to output to
 	// stdout.
 	rootCmd.SetOut(os.Stdout)
@@ -106,12 +112,13 @@ func CreateVersionCommand() *cobra.Command {
 }
 
 func CreateRunCommand() *cobra.Command {
-	var metronomeAddressRaw, rpc, keyfile, password, scheduleIDRaw string
-	var intervalMilliseconds uint64
+	var metronomeAddressRaw, rpc, keyfile, password, signerURI, scheduleIDRaw, minProfitRaw, listenAddress, logLevelRaw, logFormat string
+	var intervalMilliseconds, profitMarginPercent, bumpAfterBlocks, feeBumpPercent uint64
 	var resilient bool
 
 	var metronomeAddress common.Address
-	var scheduleID *big.Int
+	var scheduleIDs []*big.Int
+	var guard ProfitGuard
 
 	runCmd := &cobra.Command{
 		Use:   "run",
@@ -124,8 +131,8 @@ func CreateRunCommand() *cobra.Command {
 			}
 			metronomeAddress = common.HexToAddress(metronomeAddressRaw)
 
-			if keyfile == "" {
-				return fmt.Errorf("--keystore not specified (this should be a path to an Ethereum account keystore file)")
+			if keyfile == "" && signerURI == "" {
+				return fmt.Errorf("--keyfile or --signer not specified (this should be a path to an Ethereum account keystore file, or a signer URI)")
 			}
 
 			if rpc == "" {
@@ -135,8 +142,43 @@ func CreateRunCommand() *cobra.Command {
 			if scheduleIDRaw == "" {
 				return fmt.Errorf("--schedule argument not specified")
 			}
-			scheduleID = new(big.Int)
-			scheduleID.SetString(scheduleIDRaw, 0)
+			if scheduleIDRaw != "all" {
+				for _, scheduleIDItem := range strings.Split(scheduleIDRaw, ",") {
+					scheduleID, scheduleIDOk := new(big.Int).SetString(strings.TrimSpace(scheduleIDItem), 0)
+					if !scheduleIDOk || scheduleID.Sign() < 0 {
+						return fmt.Errorf("--schedule contains an invalid schedule ID: %s", scheduleIDItem)
+					}
+					scheduleIDs = append(scheduleIDs, scheduleID)
+				}
+			}
+
+			minProfit, minProfitOk := new(big.Int).SetString(minProfitRaw, 0)
+			if !minProfitOk || minProfit.Sign() < 0 {
+				return fmt.Errorf("--min-profit is not a valid amount of wei: %s", minProfitRaw)
+			}
+			guard = ProfitGuard{MinProfit: minProfit, MarginPercent: profitMarginPercent}
+
+			if bumpAfterBlocks == 0 {
+				return fmt.Errorf("--bump-after must be at least 1 block")
+			}
+			// Nodes only accept a replacement transaction if it raises fees by at least 10%.
+			if feeBumpPercent < 10 {
+				return fmt.Errorf("--fee-bump must be at least 10 percent")
+			}
+
+			var logLevel slog.Level
+			if logLevelErr := logLevel.UnmarshalText([]byte(logLevelRaw)); logLevelErr != nil {
+				return fmt.Errorf("--log-level must be one of debug, info, warn or error")
+			}
+			handlerOptions := &slog.HandlerOptions{Level: logLevel}
+			switch logFormat {
+			case "text":
+				slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, handlerOptions)))
+			case "json":
+				slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, handlerOptions)))
+			default:
+				return fmt.Errorf("--log-format must be text or json")
+			}
 
 			return nil
 		},
@@ -146,12 +188,12 @@ func CreateRunCommand() *cobra.Command {
 				return clientErr
 			}
 
-			key, keyErr := Metronome.KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
+			claimant, claimantErr := signer.FromFlags(cmd.Context(), signerURI, keyfile, password)
+			if claimantErr != nil {
+				return claimantErr
 			}
 
-			return Run(metronomeAddress, key, client, intervalMilliseconds, scheduleID, resilient)
+			return Run(metronomeAddress, claimant, client, intervalMilliseconds, scheduleIDs, guard, bumpAfterBlocks, feeBumpPercent, resilient, listenAddress)
 		},
 	}
 
@@ -159,9 +201,17 @@ func CreateRunCommand() *cobra.Command {
 	runCmd.Flags().StringVarP(&metronomeAddressRaw, "contract", "c", "", "Metronome contract address")
 	runCmd.Flags().StringVarP(&keyfile, "keyfile", "k", "", "Path to the keyfile for the claimant account")
 	runCmd.Flags().StringVarP(&password, "password", "p", "", "Password for the claimant account (if not provided, you will be prompted for this)")
-	runCmd.Flags().StringVarP(&scheduleIDRaw, "schedule", "s", "", "Schedule ID of the schedule to monitor")
+	signer.AddSignerFlag(runCmd, &signerURI)
+	runCmd.Flags().StringVarP(&scheduleIDRaw, "schedule", "s", "", "Comma-separated IDs of the schedules to monitor, or \"all\" to monitor every schedule of the contract")
 	runCmd.Flags().Uint64VarP(&intervalMilliseconds, "interval", "i", 100, "Interval in milliseconds between bounty checks")
+	runCmd.Flags().StringVar(&minProfitRaw, "min-profit", "0", "Minimum profit in wei that a claim must make over its gas cost to be sent")
+	runCmd.Flags().Uint64Var(&profitMarginPercent, "profit-margin", 0, "Margin, as a percentage of the gas cost of a claim, that its bounties must exceed the cost by for it to be sent")
+	runCmd.Flags().Uint64Var(&bumpAfterBlocks, "bump-after", 2, "Number of blocks after which the pending cancellation of a missed claim is sent again with higher fees")
+	runCmd.Flags().Uint64Var(&feeBumpPercent, "fee-bump", 20, "Percentage by which fees are raised when a missed claim is cancelled or its cancellation is sent again (at least 10)")
 	runCmd.Flags().BoolVar(&resilient, "resilient", false, "If set, the bot will continue running even if it encounters an error")
+	runCmd.Flags().StringVar(&listenAddress, "listen", "", "Address (e.g. :9090) to serve /healthz and Prometheus /metrics on (if not provided, no HTTP listener is started)")
+	runCmd.Flags().StringVar(&logLevelRaw, "log-level", "info", "Log level: debug, info, warn or error")
+	runCmd.Flags().StringVar(&logFormat, "log-format", "text", "Log format: text or json")
 
 	return runCmd
 }
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// The bot is reported unhealthy if it has not seen a new block for this long.
+const HealthTimeout = time.Minute
+
+// An error returned by the Ethereum client, labelled with the JSON-RPC method that failed so that it can be counted.
+type rpcError struct {
+	Method string
+	Err    error
+}
+
+func (e *rpcError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *rpcError) Unwrap() error {
+	return e.Err
+}
+
+// Wraps an error returned by the Ethereum client for the given JSON-RPC method, or returns nil if err is nil.
+func wrapRPCError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &rpcError{Method: method, Err: err}
+}
+
+// Prometheus metrics of the bot.
+type Metrics struct {
+	registry *prometheus.Registry
+	// Unix time in nanoseconds at which the last block was seen.
+	lastSeen atomic.Int64
+
+	LastBlock       prometheus.Gauge
+	ClaimsAttempted prometheus.Counter
+	ClaimsSucceeded prometheus.Counter
+	ClaimsFailed    prometheus.Counter
+	BountyEarned    prometheus.Counter
+	RPCErrors       *prometheus.CounterVec
+	ClaimantBalance prometheus.Gauge
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		LastBlock: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "robognome_last_block",
+			Help: "Number of the last block seen by the bot",
+		}),
+		ClaimsAttempted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "robognome_claims_attempted_total",
+			Help: "Number of claim transactions sent, not counting replacements",
+		}),
+		ClaimsSucceeded: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "robognome_claims_succeeded_total",
+			Help: "Number of claim transactions mined in their target block",
+		}),
+		ClaimsFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "robognome_claims_failed_total",
+			Help: "Number of claim transactions that reverted, were mined too late, or were cancelled or replaced",
+		}),
+		BountyEarned: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "robognome_bounty_earned_wei_total",
+			Help: "Bounties paid to the claimant, in wei",
+		}),
+		RPCErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "robognome_rpc_errors_total",
+			Help: "Number of failed requests to the Ethereum client, by JSON-RPC method",
+		}, []string{"method"}),
+		ClaimantBalance: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "robognome_claimant_balance_wei",
+			Help: "Native token balance of the claimant, in wei",
+		}),
+	}
+	m.registry.MustRegister(m.LastBlock, m.ClaimsAttempted, m.ClaimsSucceeded, m.ClaimsFailed, m.BountyEarned, m.RPCErrors, m.ClaimantBalance)
+	return m
+}
+
+// Records a new block.
+func (m *Metrics) ObserveBlock(blockNumber *big.Int) {
+	m.LastBlock.Set(weiToFloat(blockNumber))
+	m.lastSeen.Store(time.Now().UnixNano())
+}
+
+// Counts err if it is, or wraps, an error of the Ethereum client.
+func (m *Metrics) ObserveError(err error) {
+	var rpcErr *rpcError
+	if errors.As(err, &rpcErr) {
+		m.RPCErrors.WithLabelValues(rpcErr.Method).Inc()
+	}
+}
+
+// Whether the bot has seen a new block within HealthTimeout.
+func (m *Metrics) Healthy() bool {
+	lastSeen := m.lastSeen.Load()
+	return lastSeen != 0 && time.Since(time.Unix(0, lastSeen)) < HealthTimeout
+}
+
+// Returns an HTTP handler serving the health of the bot on /healthz and its metrics on /metrics.
+func (m *Metrics) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if !m.Healthy() {
+			http.Error(w, "no new block seen recently", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok\n"))
+	})
+	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	return mux
+}
+
+func weiToFloat(amount *big.Int) float64 {
+	value, _ := new(big.Float).SetInt(amount).Float64()
+	return value
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	metrics := NewMetrics()
+	server := httptest.NewServer(metrics.Handler())
+	defer server.Close()
+
+	healthResponse, healthErr := http.Get(server.URL + "/healthz")
+	if healthErr != nil {
+		t.Fatalf("GET /healthz failed: %v", healthErr)
+	}
+	healthResponse.Body.Close()
+	if healthResponse.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected /healthz to be unavailable before any block is seen, got status %d", healthResponse.StatusCode)
+	}
+
+	metrics.ObserveBlock(big.NewInt(1234))
+	metrics.ObserveError(fmt.Errorf("failed to estimate gas of claim: %w", wrapRPCError("eth_estimateGas", errors.New("timeout"))))
+	metrics.ObserveError(errors.New("not an RPC error"))
+	metrics.ClaimsAttempted.Inc()
+
+	healthResponse, healthErr = http.Get(server.URL + "/healthz")
+	if healthErr != nil {
+		t.Fatalf("GET /healthz failed: %v", healthErr)
+	}
+	healthResponse.Body.Close()
+	if healthResponse.StatusCode != http.StatusOK {
+		t.Errorf("expected /healthz to be OK after a block is seen, got status %d", healthResponse.StatusCode)
+	}
+
+	metricsResponse, metricsErr := http.Get(server.URL + "/metrics")
+	if metricsErr != nil {
+		t.Fatalf("GET /metrics failed: %v", metricsErr)
+	}
+	defer metricsResponse.Body.Close()
+	body, bodyErr := io.ReadAll(metricsResponse.Body)
+	if bodyErr != nil {
+		t.Fatalf("could not read /metrics: %v", bodyErr)
+	}
+
+	for _, expected := range []string{
+		"robognome_last_block 1234",
+		"robognome_claims_attempted_total 1",
+		`robognome_rpc_errors_total{method="eth_estimateGas"} 1`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected /metrics to contain %q", expected)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Gas used by a plain transfer, which is what a claim is replaced with to cancel it.
+const CancelGas = uint64(21000)
+
+// A version of a sent transaction. Transactions are replaced by new versions with the same nonce and higher fees.
+type sentVersion struct {
+	Hash   common.Hash
+	Cancel bool
+}
+
+// A claim that the bot has sent and that has not been mined yet.
+type sentClaim struct {
+	Claim *claim
+	// The latest version of the transaction, which is either the claim or its cancellation.
+	Transaction *types.Transaction
+	Versions    []sentVersion
+	Cancelled   bool
+	// The latest block when the latest version was sent.
+	SentAt *big.Int
+}
+
+// Returns the nonce for the next claim, which is kept locally so that claims can be sent without waiting for the
+// previous ones to be mined. The nonce is fetched from the client when it is not known.
+func (b *bot) nextNonce() (uint64, error) {
+	if b.nonce == nil {
+		nonce, nonceErr := b.client.PendingNonceAt(b.ctx, b.claimant.Address())
+		if nonceErr != nil {
+			return 0, fmt.Errorf("failed to get nonce of claimant: %w", wrapRPCError("eth_getTransactionCount", nonceErr))
+		}
+		b.nonce = &nonce
+	}
+	return *b.nonce, nil
+}
+
+// Increases a fee by the fee bump percentage of the bot, rounding up, which is what nodes require of replacement
+// transactions.
+func (b *bot) bumpFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+b.feeBumpPercent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// Replaces the latest version of a sent claim with a version with bumped fees. If cancel is set, the replacement is an
+// empty transfer from the claimant to itself instead of the claim.
+func (b *bot) replace(s *sentClaim, header *types.Header, cancel bool) error {
+	previous := s.Transaction
+	to, data, value, gas := previous.To(), previous.Data(), previous.Value(), previous.Gas()
+	if cancel {
+		claimant := b.claimant.Address()
+		to, data, value, gas = &claimant, nil, new(big.Int), CancelGas
+	}
+
+	var txData types.TxData
+	if previous.Type() == types.DynamicFeeTxType {
+		gasTipCap := b.bumpFee(previous.GasTipCap())
+		gasFeeCap := b.bumpFee(previous.GasFeeCap())
+		if header.BaseFee != nil {
+			// Same fee cap as the one of a new transaction, in case the base fee has risen since the claim was sent.
+			currentGasFeeCap := new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), gasTipCap)
+			if currentGasFeeCap.Cmp(gasFeeCap) > 0 {
+				gasFeeCap = currentGasFeeCap
+			}
+		}
+		txData = &types.DynamicFeeTx{
+			ChainID:   previous.ChainId(),
+			Nonce:     previous.Nonce(),
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       gas,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		}
+	} else {
+		txData = &types.LegacyTx{
+			Nonce:    previous.Nonce(),
+			GasPrice: b.bumpFee(previous.GasPrice()),
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		}
+	}
+
+	replacement, signErr := b.txOpts.Signer(b.claimant.Address(), types.NewTx(txData))
+	if signErr != nil {
+		return fmt.Errorf("could not sign replacement of claim transaction %s: %s", previous.Hash().String(), signErr.Error())
+	}
+	if sendErr := b.client.SendTransaction(b.ctx, replacement); sendErr != nil {
+		return fmt.Errorf("could not submit replacement of claim transaction %s: %w", previous.Hash().String(), wrapRPCError("eth_sendRawTransaction", sendErr))
+	}
+
+	s.Transaction = replacement
+	s.Versions = append(s.Versions, sentVersion{Hash: replacement.Hash(), Cancel: cancel})
+	s.Cancelled = s.Cancelled || cancel
+	s.SentAt = header.Number
+	return nil
+}
+
+// Reports whether the bounties of a claim whose target block has passed went to someone else.
+func (b *bot) reportMissedClaim(s *sentClaim) {
+	for _, schedule := range s.Claim.Schedules {
+		claimed, claimedErr := b.metronome.ClaimedBounties(&bind.CallOpts{Context: b.ctx}, schedule.ID, s.Claim.Target)
+		if claimedErr != nil {
+			b.metrics.ObserveError(wrapRPCError("eth_call", claimedErr))
+			slog.Warn("Could not check whether bounty was claimed", "schedule", schedule.ID, "block", s.Claim.Target, "error", claimedErr)
+		} else if claimed {
+			slog.Info("Bounty was claimed by someone else", "schedule", schedule.ID, "block", s.Claim.Target)
+		} else {
+			slog.Info("Bounty was not claimed", "schedule", schedule.ID, "block", s.Claim.Target)
+		}
+	}
+}
+
+// Reports the outcome of a sent claim whose nonce has been used up.
+func (b *bot) finishClaim(s *sentClaim) {
+	for i := len(s.Versions) - 1; i >= 0; i-- {
+		version := s.Versions[i]
+		receipt, receiptErr := b.client.TransactionReceipt(b.ctx, version.Hash)
+		if receiptErr != nil {
+			if !errors.Is(receiptErr, ethereum.NotFound) {
+				b.metrics.ObserveError(wrapRPCError("eth_getTransactionReceipt", receiptErr))
+			}
+			continue
+		}
+
+		switch {
+		case version.Cancel:
+			b.metrics.ClaimsFailed.Inc()
+			slog.Info("Claim cancelled", "block", s.Claim.Target, "transaction", version.Hash)
+		case receipt.Status != types.ReceiptStatusSuccessful:
+			b.metrics.ClaimsFailed.Inc()
+			slog.Warn("Claim transaction failed", "block", s.Claim.Target, "transaction", version.Hash)
+		case receipt.BlockNumber.Cmp(s.Claim.Target) != 0:
+			b.metrics.ClaimsFailed.Inc()
+			slog.Warn("Claim transaction mined too late and claimed nothing", "block", s.Claim.Target, "transaction", version.Hash, "minedIn", receipt.BlockNumber)
+		default:
+			earned := new(big.Int)
+			for _, log := range receipt.Logs {
+				bountyClaimed, parseErr := b.metronome.ParseBountyClaimed(*log)
+				if parseErr != nil || log.Address != b.metronomeAddress || bountyClaimed.ForAddress != b.claimant.Address() {
+					continue
+				}
+				earned.Add(earned, bountyClaimed.Payment)
+			}
+			b.metrics.ClaimsSucceeded.Inc()
+			b.metrics.BountyEarned.Add(weiToFloat(earned))
+			slog.Info("Claim transaction confirmed", "block", receipt.BlockNumber, "transaction", version.Hash, "earned", earned)
+		}
+		return
+	}
+
+	b.metrics.ClaimsFailed.Inc()
+	slog.Warn("Nonce of claim was used by another transaction", "block", s.Claim.Target, "nonce", s.Transaction.Nonce())
+}
+
+// What to do with a sent claim when a new block arrives.
+type sentClaimAction int
+
+const (
+	// The claim or its cancellation is still pending and is left as it is.
+	waitForClaim sentClaimAction = iota
+	// The nonce of the claim has been used up, so its outcome is reported and it is forgotten.
+	finishSentClaim
+	// The target block of the claim has passed without the claim being mined, so it is replaced with a cancellation.
+	cancelSentClaim
+	// The cancellation of the claim has been pending for too long, so it is sent again with bumped fees.
+	bumpCancellation
+)
+
+// Decides what to do with a sent claim, given the latest block number, the number of transactions of the claimant
+// mined up to that block, and the number of blocks after which a pending cancellation is sent again.
+//
+// Claims are sent right before their target block, and can only claim anything in that block, so a claim is cancelled
+// as soon as its target block has passed rather than sent again with higher fees. Only cancellations are bumped.
+func (s *sentClaim) action(blockNumber *big.Int, confirmedNonce uint64, bumpAfterBlocks uint64) sentClaimAction {
+	switch {
+	case s.Transaction.Nonce() < confirmedNonce:
+		return finishSentClaim
+	case !s.Cancelled:
+		if blockNumber.Cmp(s.Claim.Target) >= 0 {
+			return cancelSentClaim
+		}
+		return waitForClaim
+	case new(big.Int).Sub(blockNumber, s.SentAt).Cmp(new(big.Int).SetUint64(bumpAfterBlocks)) >= 0:
+		return bumpCancellation
+	default:
+		return waitForClaim
+	}
+}
+
+// Checks on the claims that have been sent and not mined yet, given the latest block header, and acts on each of
+// them as decided by sentClaim.action.
+//
+// If the node does not hold the transactions that come before the first sent claim, for instance because it was
+// restarted, the sent claims can never be mined: they are forgotten and the nonce is fetched from the node again.
+func (b *bot) checkSentClaims(header *types.Header) error {
+	if len(b.sent) == 0 {
+		return nil
+	}
+
+	confirmedNonce, nonceErr := b.client.NonceAt(b.ctx, b.claimant.Address(), header.Number)
+	if nonceErr != nil {
+		return fmt.Errorf("failed to get nonce of claimant: %w", wrapRPCError("eth_getTransactionCount", nonceErr))
+	}
+
+	if firstNonce := b.sent[0].Transaction.Nonce(); confirmedNonce < firstNonce {
+		pendingNonce, pendingNonceErr := b.client.PendingNonceAt(b.ctx, b.claimant.Address())
+		if pendingNonceErr != nil {
+			return fmt.Errorf("failed to get nonce of claimant: %w", wrapRPCError("eth_getTransactionCount", pendingNonceErr))
+		}
+		if pendingNonce < firstNonce {
+			slog.Warn("Forgetting sent claims that cannot be mined because of a nonce gap", "claims", len(b.sent), "firstNonce", firstNonce, "pendingNonce", pendingNonce)
+			b.sent = nil
+			b.nonce = nil
+			return nil
+		}
+	}
+
+	var replaceErr error
+	pending := b.sent[:0]
+	for _, s := range b.sent {
+		action := s.action(header.Number, confirmedNonce, b.bumpAfterBlocks)
+		if action == finishSentClaim {
+			b.finishClaim(s)
+			continue
+		}
+		pending = append(pending, s)
+
+		if replaceErr != nil {
+			continue
+		}
+		switch action {
+		case cancelSentClaim:
+			b.reportMissedClaim(s)
+			replaceErr = b.replace(s, header, true)
+			if replaceErr == nil {
+				slog.Info("Cancelling claim", "block", s.Claim.Target, "transaction", s.Transaction.Hash())
+			}
+		case bumpCancellation:
+			replaceErr = b.replace(s, header, true)
+			if replaceErr == nil {
+				slog.Info("Bumped fees of cancellation", "block", s.Claim.Target, "transaction", s.Transaction.Hash())
+			}
+		}
+	}
+	b.sent = pending
+
+	return replaceErr
+}
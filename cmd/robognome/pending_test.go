@@ -0,0 +1,64 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestBumpFee(t *testing.T) {
+	testCases := []struct {
+		feeBumpPercent uint64
+		fee            int64
+		expected       int64
+	}{
+		{feeBumpPercent: 10, fee: 0, expected: 0},
+		{feeBumpPercent: 10, fee: 100, expected: 110},
+		{feeBumpPercent: 10, fee: 101, expected: 112},
+		{feeBumpPercent: 20, fee: 1000000000, expected: 1200000000},
+	}
+
+	for _, testCase := range testCases {
+		b := &bot{feeBumpPercent: testCase.feeBumpPercent}
+		bumped := b.bumpFee(big.NewInt(testCase.fee))
+		if bumped.Int64() != testCase.expected {
+			t.Errorf("bumping %d by %d%%: expected %d, got %s", testCase.fee, testCase.feeBumpPercent, testCase.expected, bumped.String())
+		}
+	}
+}
+
+func TestSentClaimAction(t *testing.T) {
+	newSentClaim := func(nonce uint64, target int64, cancelled bool, sentAt int64) *sentClaim {
+		return &sentClaim{
+			Claim:       &claim{Target: big.NewInt(target)},
+			Transaction: types.NewTx(&types.DynamicFeeTx{Nonce: nonce}),
+			Cancelled:   cancelled,
+			SentAt:      big.NewInt(sentAt),
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		sent           *sentClaim
+		blockNumber    int64
+		confirmedNonce uint64
+		expected       sentClaimAction
+	}{
+		{name: "claim waiting for its target block", sent: newSentClaim(5, 100, false, 99), blockNumber: 99, confirmedNonce: 5, expected: waitForClaim},
+		{name: "claim mined", sent: newSentClaim(5, 100, false, 99), blockNumber: 100, confirmedNonce: 6, expected: finishSentClaim},
+		{name: "claim missed its target block", sent: newSentClaim(5, 100, false, 99), blockNumber: 100, confirmedNonce: 5, expected: cancelSentClaim},
+		{name: "claim long past its target block", sent: newSentClaim(5, 100, false, 90), blockNumber: 120, confirmedNonce: 5, expected: cancelSentClaim},
+		{name: "recent cancellation", sent: newSentClaim(5, 100, true, 100), blockNumber: 101, confirmedNonce: 5, expected: waitForClaim},
+		{name: "stale cancellation", sent: newSentClaim(5, 100, true, 100), blockNumber: 102, confirmedNonce: 5, expected: bumpCancellation},
+		{name: "cancellation mined", sent: newSentClaim(5, 100, true, 100), blockNumber: 102, confirmedNonce: 6, expected: finishSentClaim},
+		{name: "later claim waiting for an earlier one", sent: newSentClaim(7, 103, false, 102), blockNumber: 102, confirmedNonce: 5, expected: waitForClaim},
+	}
+
+	for _, testCase := range testCases {
+		action := testCase.sent.action(big.NewInt(testCase.blockNumber), testCase.confirmedNonce, 2)
+		if action != testCase.expected {
+			t.Errorf("%s: expected action %d, got %d", testCase.name, testCase.expected, action)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/G7DAO/protocol/bindings/Metronome"
+)
+
+// Gas used by a claim for each schedule it pays: the claim is recorded in a new storage slot, the balance of the
+// schedule is updated, the payment is transferred and BountyClaimed is emitted.
+const ClaimPaymentGas = uint64(50000)
+
+// Decides whether a claim is worth sending: the bounties it pays must exceed its gas cost by MarginPercent percent of
+// the cost plus MinProfit wei.
+type ProfitGuard struct {
+	MinProfit     *big.Int
+	MarginPercent uint64
+}
+
+// The outcome of a profitability check, kept for logging.
+type ClaimEstimate struct {
+	Gas     uint64
+	BaseFee *big.Int
+	Cost    *big.Int
+	Payout  *big.Int
+}
+
+// Returns the smallest payout that makes a claim costing cost wei profitable.
+func (g ProfitGuard) Threshold(cost *big.Int) *big.Int {
+	threshold := new(big.Int).Mul(cost, new(big.Int).SetUint64(100+g.MarginPercent))
+	threshold.Div(threshold, big.NewInt(100))
+	if g.MinProfit != nil {
+		threshold.Add(threshold, g.MinProfit)
+	}
+	return threshold
+}
+
+func (g ProfitGuard) Profitable(estimate ClaimEstimate) bool {
+	return estimate.Payout.Cmp(g.Threshold(estimate.Cost)) > 0
+}
+
+// Returns the calldata of a claim of the bounties of the given schedules, matching claimSchedules.
+func claimCalldata(schedules []Schedule, claimant common.Address) ([]byte, error) {
+	metronomeABI, metronomeABIErr := Metronome.MetronomeMetaData.GetAbi()
+	if metronomeABIErr != nil {
+		return nil, metronomeABIErr
+	}
+
+	method, arguments := claimArguments(schedules, claimant)
+	return metronomeABI.Pack(method, arguments...)
+}
+
+// Returns the schedules whose bounty pays out anything, along with the total they pay. A schedule pays the smaller of
+// its bounty and its balance, so a schedule that has run out of funds pays nothing and is left out.
+func payingSchedules(ctx context.Context, metronome *Metronome.Metronome, schedules []Schedule) ([]Schedule, *big.Int, error) {
+	var paying []Schedule
+	payout := new(big.Int)
+	for _, schedule := range schedules {
+		balance, balanceErr := metronome.ScheduleBalances(&bind.CallOpts{Context: ctx}, schedule.ID)
+		if balanceErr != nil {
+			return nil, nil, fmt.Errorf("failed to get balance of schedule %s: %w", schedule.ID.String(), wrapRPCError("eth_call", balanceErr))
+		}
+
+		schedulePayout := schedule.Bounty
+		if balance.Cmp(schedulePayout) < 0 {
+			schedulePayout = balance
+		}
+		if schedulePayout.Sign() == 0 {
+			continue
+		}
+		paying = append(paying, schedule)
+		payout.Add(payout, schedulePayout)
+	}
+	return paying, payout, nil
+}
+
+// Estimates the gas used by a claim of the bounties of the given schedules and its cost at the given base fee. Gas is
+// estimated on the latest block, in which the schedules are not due yet, so ClaimPaymentGas is added for each of them.
+// The estimate is an upper bound of the gas used.
+func estimateClaim(ctx context.Context, client *ethclient.Client, metronomeAddress common.Address, schedules []Schedule, claimant common.Address, baseFee *big.Int, payout *big.Int) (ClaimEstimate, error) {
+	calldata, calldataErr := claimCalldata(schedules, claimant)
+	if calldataErr != nil {
+		return ClaimEstimate{}, calldataErr
+	}
+
+	gas, gasErr := client.EstimateGas(ctx, ethereum.CallMsg{From: claimant, To: &metronomeAddress, Data: calldata})
+	if gasErr != nil {
+		return ClaimEstimate{}, fmt.Errorf("failed to estimate gas of claim: %w", wrapRPCError("eth_estimateGas", gasErr))
+	}
+
+	gas += ClaimPaymentGas * uint64(len(schedules))
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(gas), baseFee)
+	return ClaimEstimate{Gas: gas, BaseFee: baseFee, Cost: cost, Payout: payout}, nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestProfitGuard(t *testing.T) {
+	testCases := []struct {
+		guard      ProfitGuard
+		cost       int64
+		payout     int64
+		profitable bool
+	}{
+		{guard: ProfitGuard{}, cost: 100, payout: 101, profitable: true},
+		{guard: ProfitGuard{}, cost: 100, payout: 100, profitable: false},
+		{guard: ProfitGuard{MarginPercent: 50}, cost: 100, payout: 150, profitable: false},
+		{guard: ProfitGuard{MarginPercent: 50}, cost: 100, payout: 151, profitable: true},
+		{guard: ProfitGuard{MinProfit: big.NewInt(10), MarginPercent: 50}, cost: 100, payout: 160, profitable: false},
+		{guard: ProfitGuard{MinProfit: big.NewInt(10), MarginPercent: 50}, cost: 100, payout: 161, profitable: true},
+	}
+
+	for i, testCase := range testCases {
+		estimate := ClaimEstimate{Cost: big.NewInt(testCase.cost), Payout: big.NewInt(testCase.payout)}
+		if testCase.guard.Profitable(estimate) != testCase.profitable {
+			t.Errorf("test case %d: expected profitable to be %t", i, testCase.profitable)
+		}
+	}
+}
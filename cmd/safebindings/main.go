@@ -0,0 +1,259 @@
+// safebindings rewrites the --safe mode of the command line tools generated by seer so that they propose through the
+// safe package: the same client as the bridge commands, with retries, an API key, simulation and offline bundles. The
+// commands that send transactions also get the --signer flag, so that they sign with any signer of the signer package.
+// seer templates its own Safe proposal code into every binding generated with --cli, so the Makefile runs this after
+// generating the bindings:
+//
+//	go run ./cmd/safebindings bindings
+//
+// Bindings that have already been rewritten are left as they are.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	safeImport   = "github.com/G7DAO/protocol/safe"
+	signerImport = "github.com/G7DAO/protocol/signer"
+)
+
+// Declarations of the generated Safe proposal code that the safe package replaces.
+var generatedDecls = []string{
+	"// SafeTransactionData represents the data for a Safe transaction\ntype SafeTransactionData struct {",
+	"func CreateSafeProposal(client *ethclient.Client, key *keystore.Key, safeAddress common.Address, to common.Address, data []byte, value *big.Int, safeApi string, safeOperationType SafeOperationType, safeNonce *big.Int) error {",
+	"func CalculateSafeTxHash(safeAddress common.Address, txData SafeTransactionData, chainID *big.Int) (common.Hash, error) {",
+}
+
+const safeProposalCode = `func init() {
+	// Decode the custom errors of the contract in the revert reasons of Safe proposals that fail in simulation.
+	safe.RegisterRevertErrorABI(%s)
+}
+
+// Returns the client that the --safe mode of the commands proposes through. safeApi is the endpoint that proposals are
+// posted to: the value of --safe-api, or the Safe client gateway of the chain by default.
+func NewSafeClient(safeApi string, safeApiKey string) *safe.Client {
+	client := safe.NewClient("")
+	client.ProposeURL = safeApi
+	client.APIKey = safeApiKey
+	client.Origin.Name = %q
+	return client
+}
+
+// Proposes a Safe transaction making the given call through safeClient or, if safeOut is set, writes it to a bundle
+// file for the other owners to sign offline. Unless safeForce is set, the transaction is simulated first and not
+// proposed if it reverts. The commands pass the context of their --timeout.
+func CreateSafeProposal(ctx context.Context, client *ethclient.Client, sender signer.Signer, safeAddress common.Address, to common.Address, data []byte, value *big.Int, safeClient *safe.Client, safeOut string, safeForce bool, safeOperationType SafeOperationType, safeNonce *big.Int) error {
+	return safe.CreateProposal(ctx, client, sender, safeClient, safeOut, safeForce, safeAddress, to, data, value, safe.OperationType(safeOperationType), safeNonce)
+}
+`
+
+// Textual replacements in the generated commands, applied in order. Each one must match in every binding that has Safe
+// proposal code.
+var replacements = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{
+		regexp.MustCompile(`(?m)^(\tvar safeAddress, safeApi)((?:, \w+)*) string$`),
+		"${1}, safeApiKey, safeOut${2} string\n\tvar safeForce bool",
+	},
+	{
+		regexp.MustCompile(`(?m)^(\tcmd\.Flags\(\)\.StringVar\(&safeApi, "safe-api", .*\))$`),
+		"${1}\n" +
+			"\tcmd.Flags().StringVar(&safeApiKey, \"safe-api-key\", \"\", \"API key sent to the Safe API, required by https://api.safe.global (optional)\")\n" +
+			"\tcmd.Flags().StringVar(&safeOut, \"safe-out\", \"\", \"Write the signed Safe transaction to this bundle file instead of proposing it to the Safe Transaction Service\")\n" +
+			"\tcmd.Flags().BoolVar(&safeForce, \"force\", false, \"Propose the Safe transaction even if it reverts in simulation\")",
+	},
+	{
+		regexp.MustCompile(`(func DeployWithSafe\(.*, )safeApi string(, .*\) error \{)`),
+		"${1}safeClient *safe.Client, safeOut string, safeForce bool${2}",
+	},
+	{
+		regexp.MustCompile(`(return CreateSafeProposal\(client, key, safeAddress, factoryAddress, safeCreateCallTxData, value, )safeApi(, .*\))`),
+		"${1}safeClient, safeOut, safeForce${2}",
+	},
+	{
+		regexp.MustCompile(`((?:CreateSafeProposal|DeployWithSafe)\(client, key, .*, )safeApi(, .*\))`),
+		"${1}NewSafeClient(safeApi, safeApiKey), safeOut, safeForce${2}",
+	},
+	{
+		regexp.MustCompile(`(?m)^(\tvar keyfile, )`),
+		"${1}signerRaw, ",
+	},
+	{
+		regexp.MustCompile(`(?m)^(\tcmd\.Flags\(\)\.StringVar\(&keyfile, "keyfile", .*\))$`),
+		"${1}\n\tsigner.AddSignerFlag(cmd, &signerRaw)",
+	},
+	{
+		regexp.MustCompile(`if keyfile == "" \{\n(\t+)return fmt\.Errorf\("--keystore not specified \(this should be a path to an Ethereum account keystore file\)"\)`),
+		"if keyfile == \"\" && signerRaw == \"\" {\n${1}return fmt.Errorf(\"--keyfile or --signer not specified (this should be a path to an Ethereum account keystore file, or a signer URI)\")",
+	},
+	{
+		regexp.MustCompile(`(?m)^(\t+)key, keyErr := KeyFromFile\(keyfile, password\)\n\t+if keyErr != nil \{\n\t+return keyErr\n\t+\}$`),
+		"${1}sender, senderErr := signer.FromFlags(cmd.Context(), signerRaw, keyfile, password)\n${1}if senderErr != nil {\n${1}\treturn senderErr\n${1}}",
+	},
+	{
+		regexp.MustCompile(`(?m)^(\t+)transactionOpts, transactionOptsErr := bind\.NewKeyedTransactorWithChainID\(key\.PrivateKey, chainID\)\n\t+if transactionOptsErr != nil \{\n\t+return transactionOptsErr\n\t+\}$`),
+		"${1}ctx, cancel := NewChainContext(timeout)\n${1}defer cancel()\n${1}transactionOpts := signer.NewTransactOpts(ctx, sender, chainID)",
+	},
+	{
+		regexp.MustCompile(`(func DeployWithSafe\()client \*ethclient\.Client, key \*keystore\.Key(, )`),
+		"${1}ctx context.Context, client *ethclient.Client, sender signer.Signer${2}",
+	},
+	{
+		regexp.MustCompile(`((?:CreateSafeProposal|DeployWithSafe)\()client, key(, )`),
+		"${1}ctx, client, sender${2}",
+	},
+}
+
+// Removes the top level declaration that starts with the given text, up to its closing brace.
+func removeDecl(source string, start string) (string, error) {
+	begin := strings.Index(source, start)
+	if begin < 0 {
+		return "", fmt.Errorf("could not find %q", strings.SplitN(start, "\n", 2)[0])
+	}
+	length := strings.Index(source[begin:], "\n}\n")
+	if length < 0 {
+		return "", fmt.Errorf("could not find the end of %q", strings.SplitN(start, "\n", 2)[0])
+	}
+	return source[:begin] + source[begin+length+len("\n}\n"):], nil
+}
+
+// Adds the given imports to the import block and removes the imports that the file no longer uses.
+func fixImports(source string, add []string) (string, error) {
+	// Add the imports to the group of the seer bindings, to keep them apart from the standard library.
+	seerImport := "\t\"github.com/G7DAO/seer/bindings/CreateCall\"\n"
+	begin := strings.Index(source, seerImport)
+	if begin < 0 {
+		return "", errors.New("could not find the import of the CreateCall binding")
+	}
+	for _, path := range add {
+		source = source[:begin] + "\t" + strconv.Quote(path) + "\n" + source[begin:]
+	}
+
+	fileSet := token.NewFileSet()
+	file, parseErr := parser.ParseFile(fileSet, "", source, parser.ParseComments)
+	if parseErr != nil {
+		return "", parseErr
+	}
+
+	used := map[string]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	lines := strings.Split(source, "\n")
+	for i := len(file.Imports) - 1; i >= 0; i-- {
+		spec := file.Imports[i]
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == "_" || name == "." || used[name] {
+			continue
+		}
+		line := fileSet.Position(spec.Pos()).Line - 1
+		lines = append(lines[:line], lines[line+1:]...)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// Rewrites a binding generated with --cli. Returns false if it has no generated Safe proposal code.
+func rewrite(path string) (bool, error) {
+	contents, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return false, readErr
+	}
+	source := string(contents)
+	if !strings.Contains(source, generatedDecls[1]) {
+		return false, nil
+	}
+
+	packageName := regexp.MustCompile(`(?m)^package (\w+)$`).FindStringSubmatch(source)
+	if packageName == nil {
+		return false, errors.New("could not find the package name")
+	}
+
+	contractABI := regexp.MustCompile(`(?m)^var (\w+ABI) = \w+MetaData\.ABI$`).FindStringSubmatch(source)
+	if contractABI == nil {
+		return false, errors.New("could not find the ABI of the contract")
+	}
+
+	for _, decl := range generatedDecls {
+		var removeErr error
+		source, removeErr = removeDecl(source, decl)
+		if removeErr != nil {
+			return false, removeErr
+		}
+	}
+	source = strings.TrimRight(source, "\n") + "\n\n" + fmt.Sprintf(safeProposalCode, contractABI[1], "game7 "+packageName[1])
+
+	for _, r := range replacements {
+		if !r.pattern.MatchString(source) {
+			return false, fmt.Errorf("could not find %s", r.pattern.String())
+		}
+		source = r.pattern.ReplaceAllString(source, r.replacement)
+	}
+
+	source, importsErr := fixImports(source, []string{safeImport, signerImport})
+	if importsErr != nil {
+		return false, importsErr
+	}
+
+	formatted, formatErr := format.Source([]byte(source))
+	if formatErr != nil {
+		return false, formatErr
+	}
+	if bytes.Equal(formatted, contents) {
+		return false, nil
+	}
+
+	return true, os.WriteFile(path, formatted, 0644)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: safebindings <bindings directory or file>...")
+		os.Exit(2)
+	}
+
+	for _, root := range os.Args[1:] {
+		walkErr := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || filepath.Ext(path) != ".go" {
+				return err
+			}
+			rewritten, rewriteErr := rewrite(path)
+			if rewriteErr != nil {
+				return fmt.Errorf("%s: %w", path, rewriteErr)
+			}
+			if rewritten {
+				fmt.Println("Rewrote the Safe proposals of", path)
+			}
+			return nil
+		})
+		if walkErr != nil {
+			fmt.Fprintln(os.Stderr, walkErr.Error())
+			os.Exit(1)
+		}
+	}
+}
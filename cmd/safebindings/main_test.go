@@ -0,0 +1,186 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The parts of a binding generated by seer with --cli that safebindings rewrites.
+const generatedBinding = `// Code generated - DO NOT EDIT.
+
+package Counter
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net/http"
+	"time"
+
+	"encoding/json"
+	"fmt"
+
+	"github.com/G7DAO/seer/bindings/CreateCall"
+	"github.com/G7DAO/seer/bindings/GnosisSafe"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var CounterMetaData = &bind.MetaData{ABI: "[]"}
+
+var CounterABI = CounterMetaData.ABI
+
+func CreateIncrementCommand() *cobra.Command {
+	var keyfile, password, rpc string
+	var timeout uint
+	var safeAddress, safeApi string
+	var safeOperationType uint8
+	var safeNonce *big.Int
+
+	cmd := &cobra.Command{
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if rpc != "" {
+				if keyfile == "" {
+					return fmt.Errorf("--keystore not specified (this should be a path to an Ethereum account keystore file)")
+				}
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, clientErr := ethclient.Dial(rpc)
+			if clientErr != nil {
+				return clientErr
+			}
+
+			key, keyErr := KeyFromFile(keyfile, password)
+			if keyErr != nil {
+				return keyErr
+			}
+
+			chainID := big.NewInt(1)
+			transactionOpts, transactionOptsErr := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
+			if transactionOptsErr != nil {
+				return transactionOptsErr
+			}
+
+			if safeAddress != "" {
+				return CreateSafeProposal(client, key, common.HexToAddress(safeAddress), common.Address{}, nil, transactionOpts.Value, safeApi, SafeOperationType(safeOperationType), safeNonce)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&keyfile, "keyfile", "", "Path to the keystore file to use for the transaction")
+	cmd.Flags().StringVar(&safeAddress, "safe", "", "Address of the Safe contract")
+	cmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
+	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
+
+	return cmd
+}
+
+func KeyFromFile(keystoreFile string, password string) (*keystore.Key, error) {
+	return keystore.DecryptKey(nil, password)
+}
+
+func NewChainContext(timeout uint) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+}
+
+type SafeOperationType uint8
+
+// SafeTransactionData represents the data for a Safe transaction
+type SafeTransactionData struct {
+	To string
+}
+
+func DeployWithSafe(client *ethclient.Client, key *keystore.Key, safeAddress common.Address, factoryAddress common.Address, value *big.Int, safeApi string, deployBytecode []byte, safeOperationType SafeOperationType, salt [32]byte, safeNonce *big.Int) error {
+	safeCreateCallTxData := append([]byte{}, deployBytecode...)
+	_ = CreateCall.CreateCallMetaData
+	return CreateSafeProposal(client, key, safeAddress, factoryAddress, safeCreateCallTxData, value, safeApi, SafeOperationType(safeOperationType), safeNonce)
+}
+
+func CreateSafeProposal(client *ethclient.Client, key *keystore.Key, safeAddress common.Address, to common.Address, data []byte, value *big.Int, safeApi string, safeOperationType SafeOperationType, safeNonce *big.Int) error {
+	_, _ = GnosisSafe.NewGnosisSafe(safeAddress, client)
+	body, _ := json.Marshal(SafeTransactionData{To: to.Hex()})
+	request, _ := http.NewRequestWithContext(context.Background(), "POST", safeApi, bytes.NewBuffer(body))
+	_, err := http.DefaultClient.Do(request)
+	return fmt.Errorf("unexpected status code: %v", err)
+}
+
+func CalculateSafeTxHash(safeAddress common.Address, txData SafeTransactionData, chainID *big.Int) (common.Hash, error) {
+	return common.Hash{}, nil
+}
+`
+
+func TestRewrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Counter.go")
+	if writeErr := os.WriteFile(path, []byte(generatedBinding), 0644); writeErr != nil {
+		t.Fatal(writeErr)
+	}
+
+	rewritten, rewriteErr := rewrite(path)
+	if rewriteErr != nil {
+		t.Fatal(rewriteErr)
+	}
+	if !rewritten {
+		t.Fatal("Expected the binding to be rewritten")
+	}
+
+	contents, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatal(readErr)
+	}
+	source := string(contents)
+
+	if _, parseErr := parser.ParseFile(token.NewFileSet(), path, contents, 0); parseErr != nil {
+		t.Fatalf("Rewritten binding does not parse: %v", parseErr)
+	}
+
+	expected := []string{
+		"\t\"github.com/G7DAO/protocol/safe\"\n",
+		"\t\"github.com/G7DAO/protocol/signer\"\n",
+		"\tvar keyfile, signerRaw, password, rpc string\n",
+		"\tvar safeAddress, safeApi, safeApiKey, safeOut string\n\tvar safeForce bool\n",
+		"\tsigner.AddSignerFlag(cmd, &signerRaw)\n",
+		`if keyfile == "" && signerRaw == "" {`,
+		"sender, senderErr := signer.FromFlags(cmd.Context(), signerRaw, keyfile, password)",
+		"ctx, cancel := NewChainContext(timeout)\n\t\t\tdefer cancel()\n\t\t\ttransactionOpts := signer.NewTransactOpts(ctx, sender, chainID)\n",
+		`cmd.Flags().StringVar(&safeApiKey, "safe-api-key", `,
+		`cmd.Flags().StringVar(&safeOut, "safe-out", `,
+		`cmd.Flags().BoolVar(&safeForce, "force", `,
+		"CreateSafeProposal(ctx, client, sender, common.HexToAddress(safeAddress), common.Address{}, nil, transactionOpts.Value, NewSafeClient(safeApi, safeApiKey), safeOut, safeForce, SafeOperationType(safeOperationType), safeNonce)",
+		"func DeployWithSafe(ctx context.Context, client *ethclient.Client, sender signer.Signer, ",
+		"value *big.Int, safeClient *safe.Client, safeOut string, safeForce bool, deployBytecode []byte,",
+		"return CreateSafeProposal(ctx, client, sender, safeAddress, factoryAddress, safeCreateCallTxData, value, safeClient, safeOut, safeForce, SafeOperationType(safeOperationType), safeNonce)",
+		"safe.RegisterRevertErrorABI(CounterABI)",
+		`client.Origin.Name = "game7 Counter"`,
+		"return safe.CreateProposal(ctx, client, sender, safeClient, safeOut, safeForce,",
+	}
+	for _, e := range expected {
+		if !strings.Contains(source, e) {
+			t.Errorf("Rewritten binding does not contain %q", e)
+		}
+	}
+
+	unexpected := []string{"context.Background(), client", "NewKeyedTransactorWithChainID", "KeyFromFile(keyfile", "key.PrivateKey", "SafeTransactionData", "CalculateSafeTxHash", "GnosisSafe", `"net/http"`, `"bytes"`, `"encoding/json"`}
+	for _, u := range unexpected {
+		if strings.Contains(source, u) {
+			t.Errorf("Rewritten binding still contains %q", u)
+		}
+	}
+
+	rewritten, rewriteErr = rewrite(path)
+	if rewriteErr != nil {
+		t.Fatal(rewriteErr)
+	}
+	if rewritten {
+		t.Error("Expected a rewritten binding to be left as it is")
+	}
+}
@@ -0,0 +1,29 @@
+// Package command holds the pieces that the game7 commands share, such as their --timeout flag.
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Default number of seconds a command may run for, including waiting for its transactions to be mined
+var DEFAULT_TIMEOUT = uint(600)
+
+// Adds the --timeout flag to a command.
+func AddTimeoutFlag(cmd *cobra.Command, timeout *uint, defaultTimeout uint) {
+	cmd.Flags().UintVar(timeout, "timeout", defaultTimeout, "Timeout (in seconds) for the whole command, including waiting for transactions to be mined (0 disables the timeout)")
+}
+
+// Returns the context that a command runs with: the command's context with the --timeout deadline applied.
+func Context(cmd *cobra.Command, timeout uint) (context.Context, context.CancelFunc) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+}
@@ -0,0 +1,34 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestContext(t *testing.T) {
+	var timeout uint
+	cmd := &cobra.Command{Use: "test"}
+	AddTimeoutFlag(cmd, &timeout, DEFAULT_TIMEOUT)
+
+	if parseErr := cmd.Flags().Parse([]string{"--timeout", "30"}); parseErr != nil {
+		t.Fatal(parseErr)
+	}
+	ctx, cancel := Context(cmd, timeout)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > 30*time.Second || time.Until(deadline) < 29*time.Second {
+		t.Errorf("Expected a deadline in 30 seconds, got %v", deadline)
+	}
+
+	// A timeout of 0 leaves the command without a deadline.
+	noTimeoutCtx, noTimeoutCancel := Context(cmd, 0)
+	if _, ok := noTimeoutCtx.Deadline(); ok {
+		t.Error("Expected no deadline with a timeout of 0")
+	}
+	noTimeoutCancel()
+	if noTimeoutCtx.Err() == nil {
+		t.Error("Expected the context to be canceled")
+	}
+}
@@ -0,0 +1,239 @@
+// Package networks is a registry of the chains and bridge contracts that the Game7 tooling works with. It mirrors
+// the BridgeNetworkConfig registry of the game7-bridge-sdk TypeScript package (packages/game7-bridge-sdk/src/networks.ts),
+// and uses the same JSON field names so that custom deployments can be described in the same format.
+package networks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrUnknownNetwork = errors.New("unknown network")
+
+const (
+	MAINNET_CHAIN_ID uint64 = 2187
+	TESTNET_CHAIN_ID uint64 = 13746
+)
+
+type EthBridge struct {
+	Bridge         common.Address `json:"bridge"`
+	Inbox          common.Address `json:"inbox"`
+	Outbox         common.Address `json:"outbox"`
+	Rollup         common.Address `json:"rollup"`
+	SequencerInbox common.Address `json:"sequencerInbox"`
+	DepositTimeout uint64         `json:"depositTimeout,omitempty"`
+	// Outboxes of the chain before the Nitro upgrade, mapped to the first outbox batch that each one handles
+	ClassicOutboxes map[common.Address]uint64 `json:"classicOutboxes,omitempty"`
+}
+
+type TokenBridge struct {
+	ParentCustomGateway common.Address `json:"parentCustomGateway"`
+	ParentErc20Gateway  common.Address `json:"parentErc20Gateway"`
+	ParentGatewayRouter common.Address `json:"parentGatewayRouter"`
+	ParentMultiCall     common.Address `json:"parentMultiCall"`
+	ParentProxyAdmin    common.Address `json:"parentProxyAdmin"`
+	ParentWeth          common.Address `json:"parentWeth"`
+	ParentWethGateway   common.Address `json:"parentWethGateway"`
+	ChildCustomGateway  common.Address `json:"childCustomGateway"`
+	ChildErc20Gateway   common.Address `json:"childErc20Gateway"`
+	ChildGatewayRouter  common.Address `json:"childGatewayRouter"`
+	ChildMultiCall      common.Address `json:"childMultiCall"`
+	ChildProxyAdmin     common.Address `json:"childProxyAdmin"`
+	ChildWeth           common.Address `json:"childWeth"`
+	ChildWethGateway    common.Address `json:"childWethGateway"`
+	DepositTimeout      uint64         `json:"depositTimeout,omitempty"`
+}
+
+type Teleporter struct {
+	L1Teleporter       common.Address `json:"l1Teleporter"`
+	L2ForwarderFactory common.Address `json:"l2ForwarderFactory"`
+}
+
+type UsdcAddresses struct {
+	Bridged                common.Address `json:"bridged"`
+	SettlementLayer        common.Address `json:"settlementLayer"`
+	RollupGateway          common.Address `json:"rollupGateway"`
+	SettlementLayerGateway common.Address `json:"settlementLayerGateway"`
+}
+
+type NativeCurrency struct {
+	Decimals uint8  `json:"decimals"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+}
+
+// BridgeNetworkConfig describes a chain and, for Arbitrum chains, the contracts bridging it to its parent chain.
+// Optional sections are nil when the chain does not have them (for example, Ethereum has no EthBridge).
+type BridgeNetworkConfig struct {
+	ChainID                  uint64          `json:"chainId"`
+	Name                     string          `json:"name"`
+	ParentChainID            uint64          `json:"parentChainId,omitempty"`
+	ConfirmPeriodBlocks      uint64          `json:"confirmPeriodBlocks,omitempty"`
+	ArbSys                   *common.Address `json:"arbSys,omitempty"`
+	EthBridge                *EthBridge      `json:"ethBridge,omitempty"`
+	TokenBridge              *TokenBridge    `json:"tokenBridge,omitempty"`
+	Teleporter               *Teleporter     `json:"teleporter,omitempty"`
+	IsCustom                 bool            `json:"isCustom"`
+	IsTestnet                bool            `json:"isTestnet,omitempty"`
+	IsArbitrum               bool            `json:"isArbitrum,omitempty"`
+	ExplorerUrl              string          `json:"explorerUrl,omitempty"`
+	Rpcs                     []string        `json:"rpcs"`
+	BlockTime                uint64          `json:"blockTime,omitempty"`
+	RetryableLifetimeSeconds uint64          `json:"retryableLifetimeSeconds,omitempty"`
+	NitroGenesisBlock        uint64          `json:"nitroGenesisBlock,omitempty"`
+	NitroGenesisL1Block      uint64          `json:"nitroGenesisL1Block,omitempty"`
+	DepositTimeout           uint64          `json:"depositTimeout,omitempty"`
+	NativeCurrency           *NativeCurrency `json:"nativeCurrency,omitempty"`
+	NativeToken              *common.Address `json:"nativeToken,omitempty"` // Address of the chain's native token on its parent chain
+	UsdcAddresses            *UsdcAddresses  `json:"usdcAddresses,omitempty"`
+}
+
+// Returns the first RPC URL of the network, or an empty string if it has none.
+func (n *BridgeNetworkConfig) Rpc() string {
+	if len(n.Rpcs) == 0 {
+		return ""
+	}
+	return n.Rpcs[0]
+}
+
+// Returns a copy of the network that shares no pointers, maps or slices with it.
+func (n BridgeNetworkConfig) clone() BridgeNetworkConfig {
+	if n.ArbSys != nil {
+		arbSys := *n.ArbSys
+		n.ArbSys = &arbSys
+	}
+	if n.EthBridge != nil {
+		ethBridge := *n.EthBridge
+		if ethBridge.ClassicOutboxes != nil {
+			ethBridge.ClassicOutboxes = map[common.Address]uint64{}
+			for outbox, batch := range n.EthBridge.ClassicOutboxes {
+				ethBridge.ClassicOutboxes[outbox] = batch
+			}
+		}
+		n.EthBridge = &ethBridge
+	}
+	if n.TokenBridge != nil {
+		tokenBridge := *n.TokenBridge
+		n.TokenBridge = &tokenBridge
+	}
+	if n.Teleporter != nil {
+		teleporter := *n.Teleporter
+		n.Teleporter = &teleporter
+	}
+	if n.Rpcs != nil {
+		n.Rpcs = append([]string{}, n.Rpcs...)
+	}
+	if n.NativeCurrency != nil {
+		nativeCurrency := *n.NativeCurrency
+		n.NativeCurrency = &nativeCurrency
+	}
+	if n.NativeToken != nil {
+		nativeToken := *n.NativeToken
+		n.NativeToken = &nativeToken
+	}
+	if n.UsdcAddresses != nil {
+		usdcAddresses := *n.UsdcAddresses
+		n.UsdcAddresses = &usdcAddresses
+	}
+	return n
+}
+
+// Registry maps chain IDs to network configurations.
+type Registry map[uint64]BridgeNetworkConfig
+
+// Returns the configuration of the network with the given chain ID.
+func (r Registry) Get(chainID uint64) (*BridgeNetworkConfig, error) {
+	network, ok := r[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: chain ID %d", ErrUnknownNetwork, chainID)
+	}
+	return &network, nil
+}
+
+// Returns the configuration of a network given as "mainnet", "testnet" or a chain ID.
+func (r Registry) Resolve(network string) (*BridgeNetworkConfig, error) {
+	switch strings.ToLower(network) {
+	case "mainnet":
+		return r.Get(MAINNET_CHAIN_ID)
+	case "testnet":
+		return r.Get(TESTNET_CHAIN_ID)
+	}
+
+	chainID, chainIDErr := strconv.ParseUint(network, 10, 64)
+	if chainIDErr != nil {
+		return nil, fmt.Errorf("%w: %s (expected mainnet, testnet or a chain ID)", ErrUnknownNetwork, network)
+	}
+	return r.Get(chainID)
+}
+
+// Returns the configuration of the parent chain of the given network.
+func (r Registry) Parent(network *BridgeNetworkConfig) (*BridgeNetworkConfig, error) {
+	if network.ParentChainID == 0 {
+		return nil, fmt.Errorf("network %s (%d) has no parent chain", network.Name, network.ChainID)
+	}
+	return r.Get(network.ParentChainID)
+}
+
+// Returns the networks that settle to the given network, ordered by chain ID.
+func (r Registry) Children(network *BridgeNetworkConfig) []BridgeNetworkConfig {
+	children := []BridgeNetworkConfig{}
+	for _, candidate := range r {
+		if candidate.ParentChainID == network.ChainID {
+			children = append(children, candidate)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].ChainID < children[j].ChainID })
+	return children
+}
+
+// Returns a copy of the built-in registry. Changes to the copy, including to the contract addresses of its networks, do
+// not affect Networks.
+func DefaultRegistry() Registry {
+	registry := Registry{}
+	for chainID, network := range Networks {
+		registry[chainID] = network.clone()
+	}
+	return registry
+}
+
+// Returns the built-in registry, with the networks defined in the given JSON file added or replaced. The file maps
+// chain IDs to network configurations, in the same format as the networks object of the TypeScript SDK. An empty path
+// returns the built-in registry.
+func LoadRegistry(networksFile string) (Registry, error) {
+	registry := DefaultRegistry()
+	if networksFile == "" {
+		return registry, nil
+	}
+
+	contents, readErr := os.ReadFile(networksFile)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	var overrides map[string]BridgeNetworkConfig
+	if unmarshalErr := json.Unmarshal(contents, &overrides); unmarshalErr != nil {
+		return nil, fmt.Errorf("could not parse networks file %s: %w", networksFile, unmarshalErr)
+	}
+
+	for chainIDRaw, network := range overrides {
+		chainID, chainIDErr := strconv.ParseUint(chainIDRaw, 10, 64)
+		if chainIDErr != nil {
+			return nil, fmt.Errorf("invalid chain ID %q in networks file %s", chainIDRaw, networksFile)
+		}
+		if network.ChainID == 0 {
+			network.ChainID = chainID
+		} else if network.ChainID != chainID {
+			return nil, fmt.Errorf("network %q in networks file %s has chainId %d", chainIDRaw, networksFile, network.ChainID)
+		}
+		registry[chainID] = network
+	}
+
+	return registry, nil
+}
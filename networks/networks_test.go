@@ -0,0 +1,157 @@
+package networks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestResolve(t *testing.T) {
+	registry := DefaultRegistry()
+
+	cases := []struct {
+		network string
+		chainID uint64
+	}{
+		{"mainnet", MAINNET_CHAIN_ID},
+		{"Testnet", TESTNET_CHAIN_ID},
+		{"42161", 42161},
+		{"421614", 421614},
+	}
+	for _, c := range cases {
+		network, resolveErr := registry.Resolve(c.network)
+		if resolveErr != nil {
+			t.Fatalf("Resolve(%q) returned an error: %v", c.network, resolveErr)
+		}
+		if network.ChainID != c.chainID {
+			t.Errorf("Resolve(%q) returned chain ID %d, expected %d", c.network, network.ChainID, c.chainID)
+		}
+	}
+
+	for _, unknown := range []string{"devnet", "12345", "-1", ""} {
+		if _, resolveErr := registry.Resolve(unknown); !errors.Is(resolveErr, ErrUnknownNetwork) {
+			t.Errorf("Resolve(%q) returned %v, expected ErrUnknownNetwork", unknown, resolveErr)
+		}
+	}
+}
+
+func TestParentAndChildren(t *testing.T) {
+	registry := DefaultRegistry()
+
+	game7, _ := registry.Get(MAINNET_CHAIN_ID)
+	parent, parentErr := registry.Parent(game7)
+	if parentErr != nil {
+		t.Fatalf("Parent returned an error: %v", parentErr)
+	}
+	if parent.ChainID != 42161 {
+		t.Errorf("Parent of Game7 is %d, expected 42161", parent.ChainID)
+	}
+
+	ethereum, _ := registry.Get(1)
+	if _, parentErr := registry.Parent(ethereum); parentErr == nil {
+		t.Error("Parent of Ethereum did not return an error")
+	}
+
+	// Add a second L3 on Arbitrum One with a lower chain ID, to check that children come out in a stable order.
+	registry[1000] = BridgeNetworkConfig{ChainID: 1000, Name: "Other L3", ParentChainID: 42161}
+	for i := 0; i < 10; i++ {
+		children := registry.Children(parent)
+		if len(children) != 2 || children[0].ChainID != 1000 || children[1].ChainID != MAINNET_CHAIN_ID {
+			t.Fatalf("Children of Arbitrum One are %v, expected chain IDs 1000 and %d", children, MAINNET_CHAIN_ID)
+		}
+	}
+
+	if children := registry.Children(game7); len(children) != 0 {
+		t.Errorf("Game7 has %d children, expected none", len(children))
+	}
+}
+
+func writeNetworksFile(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "networks.json")
+	if writeErr := os.WriteFile(path, []byte(contents), 0644); writeErr != nil {
+		t.Fatalf("Could not write networks file: %v", writeErr)
+	}
+	return path
+}
+
+func TestLoadRegistry(t *testing.T) {
+	registry, loadErr := LoadRegistry("")
+	if loadErr != nil {
+		t.Fatalf("LoadRegistry with no file returned an error: %v", loadErr)
+	}
+	if len(registry) != len(Networks) {
+		t.Errorf("LoadRegistry with no file returned %d networks, expected %d", len(registry), len(Networks))
+	}
+
+	path := writeNetworksFile(t, `{
+		"2187": {"name": "Game7 Override", "parentChainId": 42161, "rpcs": ["http://localhost:8547"]},
+		"31337": {"chainId": 31337, "name": "Local", "parentChainId": 1, "rpcs": ["http://localhost:8545"],
+			"ethBridge": {"inbox": "0x00000000000000000000000000000000000000aa"}}
+	}`)
+	registry, loadErr = LoadRegistry(path)
+	if loadErr != nil {
+		t.Fatalf("LoadRegistry returned an error: %v", loadErr)
+	}
+
+	game7, _ := registry.Resolve("mainnet")
+	if game7.Name != "Game7 Override" || game7.Rpc() != "http://localhost:8547" || game7.ChainID != MAINNET_CHAIN_ID {
+		t.Errorf("Game7 was not replaced by the networks file: %+v", game7)
+	}
+
+	local, resolveErr := registry.Resolve("31337")
+	if resolveErr != nil {
+		t.Fatalf("Network added by the networks file was not found: %v", resolveErr)
+	}
+	if local.EthBridge == nil || local.EthBridge.Inbox != common.HexToAddress("0xaa") {
+		t.Errorf("Network added by the networks file has the wrong bridge: %+v", local.EthBridge)
+	}
+
+	// The networks file must not modify the built-in registry.
+	if Networks[MAINNET_CHAIN_ID].Name != "Game7" {
+		t.Errorf("Built-in registry was modified by the networks file")
+	}
+
+	invalid := []string{
+		`{"2187": {"chainId": 13746}}`,
+		`{"mainnet": {"name": "Game7"}}`,
+		`not json`,
+	}
+	for _, contents := range invalid {
+		if _, loadErr := LoadRegistry(writeNetworksFile(t, contents)); loadErr == nil {
+			t.Errorf("LoadRegistry accepted %s", contents)
+		}
+	}
+
+	if _, loadErr := LoadRegistry(filepath.Join(t.TempDir(), "missing.json")); loadErr == nil {
+		t.Error("LoadRegistry accepted a missing file")
+	}
+}
+
+func TestDefaultRegistryIsACopy(t *testing.T) {
+	registry := DefaultRegistry()
+
+	game7 := registry[MAINNET_CHAIN_ID]
+	game7.EthBridge.Inbox = common.HexToAddress("0xaa")
+	game7.TokenBridge.ParentGatewayRouter = common.HexToAddress("0xaa")
+	*game7.ArbSys = common.HexToAddress("0xaa")
+	*game7.NativeToken = common.HexToAddress("0xaa")
+	game7.UsdcAddresses.Bridged = common.HexToAddress("0xaa")
+	game7.Rpcs[0] = "http://localhost:8547"
+
+	arbitrum := registry[42161]
+	arbitrum.Teleporter.L1Teleporter = common.HexToAddress("0xaa")
+	arbitrum.EthBridge.ClassicOutboxes[common.HexToAddress("0xaa")] = 1
+
+	builtIn := Networks[MAINNET_CHAIN_ID]
+	if builtIn.EthBridge.Inbox == game7.EthBridge.Inbox || builtIn.TokenBridge.ParentGatewayRouter == game7.TokenBridge.ParentGatewayRouter ||
+		*builtIn.ArbSys == *game7.ArbSys || *builtIn.NativeToken == *game7.NativeToken ||
+		builtIn.UsdcAddresses.Bridged == game7.UsdcAddresses.Bridged || builtIn.Rpc() == game7.Rpc() {
+		t.Errorf("Built-in Game7 network was modified through the default registry: %+v", builtIn)
+	}
+	if Networks[42161].Teleporter.L1Teleporter == arbitrum.Teleporter.L1Teleporter || len(Networks[42161].EthBridge.ClassicOutboxes) != 2 {
+		t.Errorf("Built-in Arbitrum One network was modified through the default registry: %+v", Networks[42161])
+	}
+}
@@ -0,0 +1,229 @@
+package networks
+
+import "github.com/ethereum/go-ethereum/common"
+
+// Source: https://github.com/OffchainLabs/nitro-contracts/blob/main/src/precompiles/ArbSys.sol#L10
+var arbSysAddress = common.HexToAddress("0x0000000000000000000000000000000000000064")
+
+const sevenDaysInSeconds uint64 = 7 * 24 * 60 * 60
+
+var ethNativeCurrency = &NativeCurrency{Decimals: 18, Name: "ETH", Symbol: "ETH"}
+
+func address(hex string) *common.Address {
+	a := common.HexToAddress(hex)
+	return &a
+}
+
+var mainnetTokenBridge = &TokenBridge{
+	ParentGatewayRouter: common.HexToAddress("0x72Ce9c846789fdB6fC1f34aC4AD25Dd9ef7031ef"),
+	ChildGatewayRouter:  common.HexToAddress("0x5288c571Fd7aD117beA99bF60FE0846C4E84F933"),
+	ParentErc20Gateway:  common.HexToAddress("0xa3A7B6F88361F48403514059F1F16C8E78d60EeC"),
+	ChildErc20Gateway:   common.HexToAddress("0x09e9222E96E7B4AE2a407B98d48e330053351EEe"),
+	ParentCustomGateway: common.HexToAddress("0xcEe284F754E854890e311e3280b767F80797180d"),
+	ChildCustomGateway:  common.HexToAddress("0x096760F208390250649E3e8763348E783AEF5562"),
+	ParentWethGateway:   common.HexToAddress("0xd92023E9d9911199a6711321D1277285e6d4e2db"),
+	ChildWethGateway:    common.HexToAddress("0x6c411aD3E74De3E7Bd422b94A27770f5B86C623B"),
+	ChildWeth:           common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1"),
+	ParentWeth:          common.HexToAddress("0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"),
+	ParentProxyAdmin:    common.HexToAddress("0x9aD46fac0Cf7f790E5be05A0F15223935A0c0aDa"),
+	ChildProxyAdmin:     common.HexToAddress("0xd570aCE65C43af47101fC6250FD6fC63D1c22a86"),
+	ParentMultiCall:     common.HexToAddress("0x5ba1e12693dc8f9c48aad8770482f4739beed696"),
+	ChildMultiCall:      common.HexToAddress("0x842eC2c7D803033Edf55E478F461FC547Bc54EB2"),
+}
+
+var mainnetEthBridge = &EthBridge{
+	Bridge:         common.HexToAddress("0x8315177aB297bA92A06054cE80a67Ed4DBd7ed3a"),
+	Inbox:          common.HexToAddress("0x4Dbd4fc535Ac27206064B68FfCf827b0A60BAB3f"),
+	SequencerInbox: common.HexToAddress("0x1c479675ad559DC151F6Ec7ed3FbF8ceE79582B6"),
+	Outbox:         common.HexToAddress("0x0B9857ae2D4A3DBe74ffE1d7DF045bb7F96E4840"),
+	Rollup:         common.HexToAddress("0x5eF0D09d1E6204141B4d37530808eD19f60FBa35"),
+	ClassicOutboxes: map[common.Address]uint64{
+		common.HexToAddress("0x667e23ABd27E623c11d4CC00ca3EC4d0bD63337a"): 0,
+		common.HexToAddress("0x760723CD2e632826c38Fef8CD438A4CC7E7E1A40"): 30,
+	},
+}
+
+// Networks is the built-in registry. Keep it in sync with packages/game7-bridge-sdk/src/networks.ts.
+var Networks = Registry{
+	421614: {
+		ChainID:             421614,
+		ConfirmPeriodBlocks: 20,
+		EthBridge: &EthBridge{
+			Bridge:         common.HexToAddress("0x38f918D0E9F1b721EDaA41302E399fa1B79333a9"),
+			Inbox:          common.HexToAddress("0xaAe29B0366299461418F5324a79Afc425BE5ae21"),
+			Outbox:         common.HexToAddress("0x65f07C7D521164a4d5DaC6eB8Fac8DA067A3B78F"),
+			Rollup:         common.HexToAddress("0xd80810638dbDF9081b72C1B33c65375e807281C8"),
+			SequencerInbox: common.HexToAddress("0x6c97864CE4bEf387dE0b3310A44230f7E3F1be0D"),
+			DepositTimeout: 10 * 60,
+		},
+		ArbSys:         &arbSysAddress,
+		IsCustom:       false,
+		IsTestnet:      true,
+		Name:           "Arbitrum Sepolia",
+		ExplorerUrl:    "https://sepolia.arbiscan.io",
+		Rpcs:           []string{"https://sepolia-rollup.arbitrum.io/rpc"},
+		NativeCurrency: ethNativeCurrency,
+		ParentChainID:  11155111,
+		TokenBridge: &TokenBridge{
+			ParentCustomGateway: common.HexToAddress("0xba2F7B6eAe1F9d174199C5E4867b563E0eaC40F3"),
+			ParentErc20Gateway:  common.HexToAddress("0x902b3E5f8F19571859F4AB1003B960a5dF693aFF"),
+			ParentGatewayRouter: common.HexToAddress("0xcE18836b233C83325Cc8848CA4487e94C6288264"),
+			ParentMultiCall:     common.HexToAddress("0xded9AD2E65F3c4315745dD915Dbe0A4Df61b2320"),
+			ParentProxyAdmin:    common.HexToAddress("0xDBFC2FfB44A5D841aB42b0882711ed6e5A9244b0"),
+			ParentWeth:          common.HexToAddress("0x7b79995e5f793A07Bc00c21412e50Ecae098E7f9"),
+			ParentWethGateway:   common.HexToAddress("0xA8aD8d7e13cbf556eE75CB0324c13535d8100e1E"),
+			ChildCustomGateway:  common.HexToAddress("0x8Ca1e1AC0f260BC4dA7Dd60aCA6CA66208E642C5"),
+			ChildErc20Gateway:   common.HexToAddress("0x6e244cD02BBB8a6dbd7F626f05B2ef82151Ab502"),
+			ChildGatewayRouter:  common.HexToAddress("0x9fDD1C4E4AA24EEc1d913FABea925594a20d43C7"),
+			ChildMultiCall:      common.HexToAddress("0xA115146782b7143fAdB3065D86eACB54c169d092"),
+			ChildProxyAdmin:     common.HexToAddress("0x715D99480b77A8d9D603638e593a539E21345FdF"),
+			ChildWeth:           common.HexToAddress("0x980B62Da83eFf3D4576C647993b0c1D7faf17c73"),
+			ChildWethGateway:    common.HexToAddress("0xCFB1f08A4852699a979909e22c30263ca249556D"),
+			DepositTimeout:      10 * 60,
+		},
+		Teleporter: &Teleporter{
+			L1Teleporter:       common.HexToAddress("0x9E86BbF020594D7FFe05bF32EEDE5b973579A968"),
+			L2ForwarderFactory: common.HexToAddress("0x88feBaFBb4E36A4E7E8874E4c9Fd73A9D59C2E7c"),
+		},
+	},
+	42161: {
+		ChainID:       42161,
+		Name:          "Arbitrum One",
+		ParentChainID: 1,
+		TokenBridge:   mainnetTokenBridge,
+		EthBridge:     mainnetEthBridge,
+		Teleporter: &Teleporter{
+			L1Teleporter:       common.HexToAddress("0xCBd9c6e310D6AaDeF9F025f716284162F0158992"),
+			L2ForwarderFactory: common.HexToAddress("0x791d2AbC6c3A459E13B9AdF54Fb5e97B7Af38f87"),
+		},
+		ArbSys:              &arbSysAddress,
+		ConfirmPeriodBlocks: 45818,
+		IsCustom:            false,
+		IsTestnet:           false,
+		ExplorerUrl:         "https://arbiscan.io",
+		Rpcs:                []string{"https://arb1.arbitrum.io/rpc"},
+		NativeCurrency:      ethNativeCurrency,
+	},
+	11155111: {
+		ChainID:        11155111,
+		Name:           "Sepolia",
+		ExplorerUrl:    "https://sepolia.etherscan.io",
+		Rpcs:           []string{"https://ethereum-sepolia-rpc.publicnode.com"},
+		BlockTime:      12,
+		IsCustom:       false,
+		IsArbitrum:     false,
+		NativeCurrency: ethNativeCurrency,
+	},
+	1: {
+		ChainID:        1,
+		Name:           "ethereum",
+		ExplorerUrl:    "https://etherscan.io",
+		Rpcs:           []string{"https://ethereum-rpc.publicnode.com"},
+		BlockTime:      12,
+		IsCustom:       false,
+		IsArbitrum:     false,
+		NativeCurrency: ethNativeCurrency,
+	},
+	13746: {
+		ChainID:             13746,
+		ConfirmPeriodBlocks: 20,
+		EthBridge: &EthBridge{
+			Bridge:         common.HexToAddress("0xC7EEB897bA9bc3fA071C3871e7F4Cf1Ae7570f16"),
+			Inbox:          common.HexToAddress("0xE6470bb72291c39073AEd67a30ff93B69c1f47De"),
+			Outbox:         common.HexToAddress("0x64105c6C3D494469D5F21323F0E917563489d9f5"),
+			Rollup:         common.HexToAddress("0x6cf5bFffc54cDd13B4747e8DF2C72ce8A95043c0"),
+			SequencerInbox: common.HexToAddress("0xAe2caC32b0eF386Ab683459648eDFC78F7FF8F1e"),
+		},
+		ArbSys:      &arbSysAddress,
+		ExplorerUrl: "https://testnet.game7.io",
+		Rpcs:        []string{"https://testnet-rpc.game7.io"},
+		IsArbitrum:  true,
+		IsCustom:    true,
+		Name:        "Game7 Testnet",
+		NativeCurrency: &NativeCurrency{
+			Decimals: 18,
+			Name:     "Testnet Game7 Token",
+			Symbol:   "TG7T",
+		},
+		ParentChainID:            421614,
+		RetryableLifetimeSeconds: sevenDaysInSeconds,
+		NativeToken:              address("0x10adBf84548F923577Be12146eAc104C899D1E75"),
+		TokenBridge: &TokenBridge{
+			ParentCustomGateway: common.HexToAddress("0x81aCB22000A2A81D26E7e1ed5a8f51930A31598E"),
+			ParentErc20Gateway:  common.HexToAddress("0x4A24f98D6fB62Ce8eA8f6C2D5AF9c8BF1c853fD7"),
+			ParentGatewayRouter: common.HexToAddress("0x73EeAEEC11473534a2249c851e4b245E61Da8732"),
+			ParentMultiCall:     common.HexToAddress("0xce1CAd780c529e66e3aa6D952a1ED9A6447791c1"),
+			ParentProxyAdmin:    common.HexToAddress("0x8767Ea2Ce21ac4e624F8a36948BD5EA23A3288D9"),
+			ParentWeth:          common.HexToAddress("0x0000000000000000000000000000000000000000"),
+			ParentWethGateway:   common.HexToAddress("0x0000000000000000000000000000000000000000"),
+			ChildCustomGateway:  common.HexToAddress("0xe6c5Ab297E022A592a3fF26984cc6352C7cD0f92"),
+			ChildErc20Gateway:   common.HexToAddress("0x9b43912709756DcFd34A64D4362b579928fDcC26"),
+			ChildGatewayRouter:  common.HexToAddress("0xDA379C01a484fB9F0875730430a418eB8AAFdca2"),
+			ChildMultiCall:      common.HexToAddress("0x27c4a2f1B1685F0AD1ea2227F56606066Aa95Bd0"),
+			ChildProxyAdmin:     common.HexToAddress("0x07424574dbF6508D1c79755ab8f1ba3883cc38f3"),
+			ChildWeth:           common.HexToAddress("0x0000000000000000000000000000000000000000"),
+			ChildWethGateway:    common.HexToAddress("0x0000000000000000000000000000000000000000"),
+			DepositTimeout:      2 * 60,
+		},
+		UsdcAddresses: &UsdcAddresses{
+			Bridged:                common.HexToAddress("0xf2B58E3519C5b977a254993A4A6EaD581A8989A0"),
+			SettlementLayer:        common.HexToAddress("0x75faf114eafb1BDbe2F0316DF893fd58CE46AA4d"),
+			RollupGateway:          common.HexToAddress("0xd37E4B6b75F3502FAFDeeA4Bb27843E01603edCe"),
+			SettlementLayerGateway: common.HexToAddress("0x183c02988d4E92bE5a5DB2805f357f4d39bAf086"),
+		},
+		NitroGenesisBlock:   0,
+		NitroGenesisL1Block: 0,
+		DepositTimeout:      900000,
+	},
+	2187: {
+		ChainID:             2187,
+		ConfirmPeriodBlocks: 20,
+		EthBridge: &EthBridge{
+			Bridge:         common.HexToAddress("0x20aD3d835e152F25Bf8c7B6fbC31adD32393559e"),
+			Inbox:          common.HexToAddress("0xB1146A7eb098ECF46e8AAf695f4A960A963948d6"),
+			Outbox:         common.HexToAddress("0xfbe537816d181888fAbE52338a5D921eE131E9Db"),
+			Rollup:         common.HexToAddress("0x60DAdF13101C66F14C958E9141498b0C0eaE0773"),
+			SequencerInbox: common.HexToAddress("0x4cFe930c5B2F03Cf81B44D2e62297beb79222B68"),
+		},
+		ArbSys:      &arbSysAddress,
+		ExplorerUrl: "https://mainnet.game7.io",
+		Rpcs:        []string{"https://mainnet-rpc.game7.io"},
+		IsArbitrum:  true,
+		IsCustom:    true,
+		Name:        "Game7",
+		NativeCurrency: &NativeCurrency{
+			Decimals: 18,
+			Name:     "Game7 Token",
+			Symbol:   "G7",
+		},
+		ParentChainID:            42161,
+		RetryableLifetimeSeconds: sevenDaysInSeconds,
+		NativeToken:              address("0xF18e4466F26B4cA55bbAb890b314a54976E45B17"),
+		TokenBridge: &TokenBridge{
+			ParentCustomGateway: common.HexToAddress("0xd7258a4BE508Da8E95F89c13B8b7469951e9Df2B"),
+			ParentErc20Gateway:  common.HexToAddress("0xe41363751bd1C305384375F428585C20e3dF516A"),
+			ParentGatewayRouter: common.HexToAddress("0x8098247EE48ee54ADD4Feda2F93b3bA0d014d4c7"),
+			ParentMultiCall:     common.HexToAddress("0x90B02D9F861017844F30dFbdF725b6aa84E63822"),
+			ParentProxyAdmin:    common.HexToAddress("0x8767Ea2Ce21ac4e624F8a36948BD5EA23A3288D9"),
+			ParentWeth:          common.HexToAddress("0x0000000000000000000000000000000000000000"),
+			ParentWethGateway:   common.HexToAddress("0x0000000000000000000000000000000000000000"),
+			ChildCustomGateway:  common.HexToAddress("0x65dcAB2e219b2F895854A7fba95b56eb02eE933f"),
+			ChildErc20Gateway:   common.HexToAddress("0x36921bAAD215c5f3c5dffa89B1C2A5CF4BDAdC77"),
+			ChildGatewayRouter:  common.HexToAddress("0x7Ca9c81d2AdD8bff46CEE9813d52bD84d94901DD"),
+			ChildMultiCall:      common.HexToAddress("0x1422d8aC9b5E102E6EbA56F0949a2377AB3D8CE9"),
+			ChildProxyAdmin:     common.HexToAddress("0xC900F8976Ad0B945bc552cE4459F2ec1Baf4f1Ff"),
+			ChildWeth:           common.HexToAddress("0x0000000000000000000000000000000000000000"),
+			ChildWethGateway:    common.HexToAddress("0x0000000000000000000000000000000000000000"),
+			DepositTimeout:      2 * 60,
+		},
+		UsdcAddresses: &UsdcAddresses{
+			Bridged:                common.HexToAddress("0x401eCb1D350407f13ba348573E5630B83638E30D"),
+			SettlementLayer:        common.HexToAddress("0xaf88d065e77c8cC2239327C5EDb3A432268e5831"),
+			RollupGateway:          common.HexToAddress("0xF70ae1Af7D49dA0f7D66Bb55469caC9da336181b"),
+			SettlementLayerGateway: common.HexToAddress("0x404922a9B29b4a5205a6074AbA31A7392BD28944"),
+		},
+		NitroGenesisBlock:   0,
+		NitroGenesisL1Block: 0,
+		DepositTimeout:      900000,
+	},
+}
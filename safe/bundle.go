@@ -0,0 +1,82 @@
+package safe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Bundle is a Safe transaction together with the owner signatures collected for it, stored in a file so that it
+// can be signed and executed without a Safe Transaction Service: by air-gapped signers, or on devnets.
+type Bundle struct {
+	Safe        common.Address    `json:"safe"`
+	ChainID     *big.Int          `json:"chainId"`
+	Transaction TransactionData   `json:"transaction"`
+	SafeTxHash  common.Hash       `json:"safeTxHash"`
+	Signatures  []BundleSignature `json:"signatures"`
+}
+
+// BundleSignature is an owner's signature of the SafeTxHash of a bundle.
+type BundleSignature struct {
+	Owner     common.Address `json:"owner"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+// Reads a Safe bundle from a file and checks that its SafeTxHash matches its transaction.
+func ReadBundle(bundleFile string) (*Bundle, error) {
+	bundleRaw, readErr := os.ReadFile(bundleFile)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	var bundle Bundle
+	if unmarshalErr := json.Unmarshal(bundleRaw, &bundle); unmarshalErr != nil {
+		return nil, fmt.Errorf("could not parse Safe bundle %s: %w", bundleFile, unmarshalErr)
+	}
+	if bundle.ChainID == nil || bundle.Transaction.Nonce == nil {
+		return nil, fmt.Errorf("Safe bundle %s has no chain ID or nonce", bundleFile)
+	}
+
+	safeTxHash, hashErr := CalculateSafeTxHash(bundle.Safe, bundle.Transaction, bundle.ChainID)
+	if hashErr != nil {
+		return nil, hashErr
+	}
+	if safeTxHash != bundle.SafeTxHash {
+		return nil, fmt.Errorf("transaction of Safe bundle %s hashes to %s, not to %s", bundleFile, safeTxHash.Hex(), bundle.SafeTxHash.Hex())
+	}
+
+	return &bundle, nil
+}
+
+// Writes the bundle to a file.
+func (b *Bundle) Write(bundleFile string) error {
+	bundleJSON, marshalErr := json.MarshalIndent(b, "", "  ")
+	if marshalErr != nil {
+		return marshalErr
+	}
+	return os.WriteFile(bundleFile, append(bundleJSON, '\n'), 0644)
+}
+
+// Signs the SafeTxHash of the bundle and adds the signature, replacing any earlier signature by the same owner. No
+// network access is needed, so this works on air-gapped machines.
+func (b *Bundle) Sign(ctx context.Context, sender signer.Signer) error {
+	signature, signErr := SignSafeTxHash(ctx, sender, b.SafeTxHash)
+	if signErr != nil {
+		return fmt.Errorf("failed to sign SafeTxHash: %v", signErr)
+	}
+
+	for i, existing := range b.Signatures {
+		if existing.Owner == sender.Address() {
+			b.Signatures[i].Signature = signature
+			return nil
+		}
+	}
+	b.Signatures = append(b.Signatures, BundleSignature{Owner: sender.Address(), Signature: signature})
+	return nil
+}
@@ -0,0 +1,83 @@
+package safe
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestBundle(t *testing.T) {
+	ctx := context.Background()
+	bundleFile := filepath.Join(t.TempDir(), "bundle.json")
+	safeAddress := common.HexToAddress("0x5afe")
+	chainID := big.NewInt(13746)
+
+	txData := TransactionData{
+		To:             common.HexToAddress("0x1").Hex(),
+		Value:          "1",
+		Operation:      Call,
+		GasPrice:       "0",
+		GasToken:       NativeTokenAddress,
+		RefundReceiver: NativeTokenAddress,
+		Nonce:          big.NewInt(0),
+	}
+	safeTxHash, hashErr := CalculateSafeTxHash(safeAddress, txData, chainID)
+	if hashErr != nil {
+		t.Fatal(hashErr)
+	}
+
+	bundle := &Bundle{Safe: safeAddress, ChainID: chainID, Transaction: txData, SafeTxHash: safeTxHash}
+	if writeErr := bundle.Write(bundleFile); writeErr != nil {
+		t.Fatal(writeErr)
+	}
+
+	for i := 0; i < 2; i++ {
+		privateKey, privateKeyErr := crypto.GenerateKey()
+		if privateKeyErr != nil {
+			t.Fatal(privateKeyErr)
+		}
+		owner := signer.NewLocalSigner(privateKey)
+
+		readBundle, readErr := ReadBundle(bundleFile)
+		if readErr != nil {
+			t.Fatal(readErr)
+		}
+		// Signing twice with the same owner replaces its signature.
+		for j := 0; j < 2; j++ {
+			if signErr := readBundle.Sign(ctx, owner); signErr != nil {
+				t.Fatal(signErr)
+			}
+		}
+		if writeErr := readBundle.Write(bundleFile); writeErr != nil {
+			t.Fatal(writeErr)
+		}
+	}
+
+	signedBundle, readErr := ReadBundle(bundleFile)
+	if readErr != nil {
+		t.Fatal(readErr)
+	}
+	if len(signedBundle.Signatures) != 2 {
+		t.Fatalf("Expected 2 signatures, got %d", len(signedBundle.Signatures))
+	}
+
+	// A bundle whose transaction was changed after it was hashed is rejected.
+	bundleRaw, _ := os.ReadFile(bundleFile)
+	tampered := strings.Replace(string(bundleRaw), `"value": "1"`, `"value": "1000"`, 1)
+	if tampered == string(bundleRaw) {
+		t.Fatal("Could not tamper with the bundle")
+	}
+	if writeErr := os.WriteFile(bundleFile, []byte(tampered), 0644); writeErr != nil {
+		t.Fatal(writeErr)
+	}
+	if _, tamperedErr := ReadBundle(bundleFile); tamperedErr == nil {
+		t.Error("Expected an error for a bundle whose transaction does not match its SafeTxHash")
+	}
+}
@@ -0,0 +1,353 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Origin is the metadata recorded with a proposal to tell the owners where it comes from. The Safe web app shows its
+// name next to the transaction.
+type Origin struct {
+	URL  string `json:"url,omitempty"`
+	Name string `json:"name"`
+}
+
+var DefaultOrigin = Origin{URL: "https://github.com/G7DAO/protocol", Name: "game7"}
+
+// Client is a client for the Safe Transaction Service API, which collects the proposed transactions of Safes and the
+// confirmations of their owners.
+type Client struct {
+	// Base URL of the service, for example https://safe-transaction-mainnet.safe.global.
+	URL string
+	// URL that proposals are posted to. If empty, they are posted to the multisig transactions of the Safe on the
+	// service at URL. GatewayProposeURL returns the endpoint of the Safe client gateway, which works without URL.
+	ProposeURL string
+	// Sent as a bearer token with every request if set, as required by the Safe hosted API (https://api.safe.global).
+	APIKey string
+	// Recorded with the proposals made by the client.
+	Origin Origin
+	// Number of times a request is retried after a network error, a 429 or a 5xx response, waiting RetryDelay, doubled
+	// on each attempt, in between.
+	Retries    int
+	RetryDelay time.Duration
+	HTTPClient *http.Client
+}
+
+// APIError is the error returned when the service answers a request with a status code other than 2xx. It holds the
+// body of the response, in which the service explains what it rejected.
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s %s: unexpected status code: %d", e.Method, e.URL, e.StatusCode)
+	}
+	return fmt.Sprintf("%s %s: unexpected status code: %d (%s)", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// Whether the request may succeed if it is sent again.
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// Number that the transaction service encodes either as a JSON number or as a decimal string, depending on the field
+// and the version of the service.
+type serviceNumber struct {
+	big.Int
+}
+
+func (n *serviceNumber) UnmarshalJSON(data []byte) error {
+	raw := strings.Trim(string(data), "\"")
+	if raw == "null" || raw == "" {
+		n.SetInt64(0)
+		return nil
+	}
+	if _, ok := n.SetString(raw, 10); !ok {
+		return fmt.Errorf("invalid number %s", data)
+	}
+	return nil
+}
+
+// ServiceConfirmation is an owner's signature of a Safe transaction, as stored by the transaction service.
+type ServiceConfirmation struct {
+	Owner         common.Address `json:"owner"`
+	Signature     hexutil.Bytes  `json:"signature"`
+	SignatureType string         `json:"signatureType"`
+}
+
+// ServiceTransaction is a multisig transaction of a Safe, as stored by the transaction service.
+type ServiceTransaction struct {
+	Safe                  common.Address        `json:"safe"`
+	To                    common.Address        `json:"to"`
+	Value                 serviceNumber         `json:"value"`
+	Data                  *hexutil.Bytes        `json:"data"`
+	Operation             uint8                 `json:"operation"`
+	SafeTxGas             serviceNumber         `json:"safeTxGas"`
+	BaseGas               serviceNumber         `json:"baseGas"`
+	GasPrice              serviceNumber         `json:"gasPrice"`
+	GasToken              common.Address        `json:"gasToken"`
+	RefundReceiver        common.Address        `json:"refundReceiver"`
+	Nonce                 serviceNumber         `json:"nonce"`
+	SafeTxHash            common.Hash           `json:"safeTxHash"`
+	IsExecuted            bool                  `json:"isExecuted"`
+	ConfirmationsRequired int                   `json:"confirmationsRequired"`
+	Confirmations         []ServiceConfirmation `json:"confirmations"`
+}
+
+type serviceTransactionPage struct {
+	Next    *string              `json:"next"`
+	Results []ServiceTransaction `json:"results"`
+}
+
+// Body of a proposal. The transaction service and the client gateway name the SafeTxHash differently, so it is sent
+// under both names.
+type proposal struct {
+	To                      string `json:"to"`
+	Value                   string `json:"value"`
+	Data                    string `json:"data"`
+	Operation               int    `json:"operation"`
+	SafeTxGas               string `json:"safeTxGas"`
+	BaseGas                 string `json:"baseGas"`
+	GasPrice                string `json:"gasPrice"`
+	GasToken                string `json:"gasToken"`
+	RefundReceiver          string `json:"refundReceiver"`
+	Nonce                   string `json:"nonce"`
+	SafeTxHash              string `json:"safeTxHash"`
+	ContractTransactionHash string `json:"contractTransactionHash"`
+	Sender                  string `json:"sender"`
+	Signature               string `json:"signature"`
+	Origin                  string `json:"origin"`
+}
+
+// Returns a client for the transaction service at serviceURL, which retries failed requests 3 times.
+func NewClient(serviceURL string) *Client {
+	return &Client{
+		URL:        strings.TrimSuffix(serviceURL, "/"),
+		Origin:     DefaultOrigin,
+		Retries:    3,
+		RetryDelay: time.Second,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// Base URL of the Safe client gateway, which the commands go through when no transaction service is given.
+var GatewayURL = "https://safe-client.safe.global"
+
+// Returns the endpoint of the Safe client gateway that proposes transactions to a Safe on the given chain, which is
+// used when no transaction service is given.
+func GatewayProposeURL(chainID *big.Int, safeAddress common.Address) string {
+	return GatewayURL + "/v1/chains/" + chainID.String() + "/transactions/" + safeAddress.Hex() + "/propose"
+}
+
+// Returns the base URL of the Safe Transaction Service of the given chain, as listed in the chain configuration of the
+// Safe client gateway. It is used to read transactions and confirm them when no transaction service is given.
+func (c *Client) GatewayTransactionService(ctx context.Context, chainID *big.Int) (string, error) {
+	var chain struct {
+		TransactionService string `json:"transactionService"`
+	}
+	if requestErr := c.request(ctx, http.MethodGet, GatewayURL+"/v1/chains/"+chainID.String(), nil, &chain); requestErr != nil {
+		return "", requestErr
+	}
+	if chain.TransactionService == "" {
+		return "", fmt.Errorf("the Safe client gateway lists no transaction service for chain %s", chainID.String())
+	}
+	return strings.TrimSuffix(chain.TransactionService, "/"), nil
+}
+
+// Returns the transaction in the form used to compute its SafeTxHash and to execute it.
+func (t *ServiceTransaction) TransactionData() TransactionData {
+	data := ""
+	if t.Data != nil {
+		data = common.Bytes2Hex(*t.Data)
+	}
+
+	return TransactionData{
+		To:             t.To.Hex(),
+		Value:          t.Value.String(),
+		Data:           data,
+		Operation:      OperationType(t.Operation),
+		SafeTxGas:      t.SafeTxGas.Uint64(),
+		BaseGas:        t.BaseGas.Uint64(),
+		GasPrice:       t.GasPrice.String(),
+		GasToken:       t.GasToken.Hex(),
+		RefundReceiver: t.RefundReceiver.Hex(),
+		Nonce:          new(big.Int).Set(&t.Nonce.Int),
+	}
+}
+
+// Returns the confirmations of the transaction, in the form taken by EncodeSignatures.
+func (t *ServiceTransaction) OwnerConfirmations() []Confirmation {
+	confirmations := make([]Confirmation, len(t.Confirmations))
+	for i, confirmation := range t.Confirmations {
+		confirmations[i] = Confirmation{Owner: confirmation.Owner, Signature: confirmation.Signature}
+	}
+	return confirmations
+}
+
+// Proposes a transaction to a Safe, with the signature of the proposer, an owner or delegate of the Safe.
+func (c *Client) Propose(ctx context.Context, safeAddress common.Address, txData TransactionData, safeTxHash common.Hash, sender common.Address, signature []byte) error {
+	origin, originErr := json.Marshal(c.Origin)
+	if originErr != nil {
+		return fmt.Errorf("failed to marshal origin: %v", originErr)
+	}
+
+	body := proposal{
+		To:                      txData.To,
+		Value:                   txData.Value,
+		Data:                    "0x" + txData.Data,
+		Operation:               int(txData.Operation),
+		SafeTxGas:               fmt.Sprintf("%d", txData.SafeTxGas),
+		BaseGas:                 fmt.Sprintf("%d", txData.BaseGas),
+		GasPrice:                txData.GasPrice,
+		GasToken:                txData.GasToken,
+		RefundReceiver:          txData.RefundReceiver,
+		Nonce:                   txData.Nonce.String(),
+		SafeTxHash:              safeTxHash.Hex(),
+		ContractTransactionHash: safeTxHash.Hex(),
+		Sender:                  sender.Hex(),
+		Signature:               hexutil.Encode(signature),
+		Origin:                  string(origin),
+	}
+
+	proposeURL := c.ProposeURL
+	if proposeURL == "" {
+		if c.URL == "" {
+			return errors.New("no Safe API to propose the transaction to")
+		}
+		proposeURL = fmt.Sprintf("%s/api/v1/safes/%s/multisig-transactions/", c.URL, safeAddress.Hex())
+	}
+
+	return c.request(ctx, http.MethodPost, proposeURL, body, nil)
+}
+
+// Returns the transactions of a Safe that have not been executed and have a nonce of at least fromNonce, ordered by
+// nonce. Transactions sharing a nonce are alternatives, of which at most one can be executed.
+func (c *Client) PendingTransactions(ctx context.Context, safeAddress common.Address, fromNonce *big.Int) ([]ServiceTransaction, error) {
+	query := url.Values{}
+	query.Set("executed", "false")
+	query.Set("ordering", "nonce")
+	if fromNonce != nil {
+		query.Set("nonce__gte", fromNonce.String())
+	}
+	next := fmt.Sprintf("%s/api/v1/safes/%s/multisig-transactions/?%s", c.URL, safeAddress.Hex(), query.Encode())
+
+	var transactions []ServiceTransaction
+	for next != "" {
+		var page serviceTransactionPage
+		if requestErr := c.request(ctx, http.MethodGet, next, nil, &page); requestErr != nil {
+			return nil, requestErr
+		}
+		transactions = append(transactions, page.Results...)
+
+		next = ""
+		if page.Next != nil {
+			next = *page.Next
+		}
+	}
+
+	return transactions, nil
+}
+
+// Returns the transaction with the given SafeTxHash, with the confirmations collected so far.
+func (c *Client) Transaction(ctx context.Context, safeTxHash common.Hash) (*ServiceTransaction, error) {
+	var transaction ServiceTransaction
+	requestErr := c.request(ctx, http.MethodGet, fmt.Sprintf("%s/api/v1/multisig-transactions/%s/", c.URL, safeTxHash.Hex()), nil, &transaction)
+	if requestErr != nil {
+		return nil, requestErr
+	}
+	return &transaction, nil
+}
+
+// Submits an owner's signature of the transaction with the given SafeTxHash.
+func (c *Client) Confirm(ctx context.Context, safeTxHash common.Hash, signature []byte) error {
+	body := map[string]string{"signature": hexutil.Encode(signature)}
+	return c.request(ctx, http.MethodPost, fmt.Sprintf("%s/api/v1/multisig-transactions/%s/confirmations/", c.URL, safeTxHash.Hex()), body, nil)
+}
+
+// Sends a request, retrying it while it fails with a network error or a temporary APIError, and decodes the JSON
+// response into result.
+func (c *Client) request(ctx context.Context, method string, requestURL string, body interface{}, result interface{}) error {
+	var jsonBody []byte
+	if body != nil {
+		var marshalErr error
+		jsonBody, marshalErr = json.Marshal(body)
+		if marshalErr != nil {
+			return fmt.Errorf("failed to marshal request body: %v", marshalErr)
+		}
+	}
+
+	delay := c.RetryDelay
+	for attempt := 0; ; attempt++ {
+		requestErr := c.send(ctx, method, requestURL, jsonBody, result)
+
+		var apiErr *APIError
+		var networkErr *url.Error
+		retryable := (errors.As(requestErr, &apiErr) && apiErr.Temporary()) || errors.As(requestErr, &networkErr)
+		if !retryable || attempt >= c.Retries || ctx.Err() != nil {
+			return requestErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return requestErr
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (c *Client) send(ctx context.Context, method string, requestURL string, jsonBody []byte, result interface{}) error {
+	var requestBody io.Reader
+	if jsonBody != nil {
+		requestBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, requestBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	if jsonBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &APIError{Method: method, URL: requestURL, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(responseBody))}
+	}
+
+	if result != nil {
+		if decodeErr := json.NewDecoder(resp.Body).Decode(result); decodeErr != nil {
+			return fmt.Errorf("failed to decode response: %v", decodeErr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,525 @@
+package safe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/G7DAO/protocol/bindings/GnosisSafe"
+	"github.com/G7DAO/protocol/command"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+func CreateSafeCommand() *cobra.Command {
+	safeCmd := &cobra.Command{
+		Use:   "safe",
+		Short: "Co-sign and execute Safe multisig transactions",
+		Long:  `Co-sign and execute Safe multisig transactions proposed by the --safe mode of the bridge commands, either through a Safe Transaction Service or offline through bundle files`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	listPendingCmd := CreateListPendingCommand()
+	confirmCmd := CreateConfirmCommand()
+	signCmd := CreateSignCommand()
+	executeCmd := CreateExecuteCommand()
+	batchCmd := CreateBatchCommand()
+
+	safeCmd.AddCommand(listPendingCmd, confirmCmd, signCmd, executeCmd, batchCmd)
+
+	return safeCmd
+}
+
+// Flags shared by the safe commands, which all act on a Safe through an RPC endpoint and a transaction service.
+type safeFlags struct {
+	rpc, safeAddressRaw, safeApi, safeApiKey string
+	timeout                                  uint
+	safeAddress                              common.Address
+}
+
+func (f *safeFlags) add(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.rpc, "rpc", "", "RPC URL of the chain the Safe is deployed on")
+	cmd.Flags().StringVar(&f.safeAddressRaw, "safe", "", "Address of the Safe contract")
+	cmd.Flags().StringVar(&f.safeApi, "safe-api", "", "Base URL of the Safe Transaction Service for the chain (for example https://safe-transaction-mainnet.safe.global), by default found through the Safe client gateway")
+	cmd.Flags().StringVar(&f.safeApiKey, "safe-api-key", "", "API key for the Safe Transaction Service, required by https://api.safe.global")
+	command.AddTimeoutFlag(cmd, &f.timeout, command.DEFAULT_TIMEOUT)
+}
+
+func (f *safeFlags) validate() error {
+	if f.rpc == "" {
+		return errors.New("rpc is required")
+	}
+
+	if !common.IsHexAddress(f.safeAddressRaw) {
+		return errors.New("--safe is not a valid Ethereum address")
+	}
+	f.safeAddress = common.HexToAddress(f.safeAddressRaw)
+
+	return nil
+}
+
+// Returns a client for the transaction service given by the flags.
+func (f *safeFlags) client() *Client {
+	client := NewClient(f.safeApi)
+	client.APIKey = f.safeApiKey
+	return client
+}
+
+// Returns a client for the transaction service given by the flags or, without --safe-api, for the transaction service
+// that the Safe client gateway lists for the chain at the RPC.
+func (f *safeFlags) serviceClient(ctx context.Context, chainClient *ethclient.Client) (*Client, error) {
+	client := f.client()
+	if f.safeApi != "" {
+		return client, nil
+	}
+
+	chainID, chainIDErr := chainClient.ChainID(ctx)
+	if chainIDErr != nil {
+		return nil, chainIDErr
+	}
+	serviceURL, serviceErr := client.GatewayTransactionService(ctx, chainID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	client.URL = serviceURL
+	fmt.Println("--safe-api not specified, using the transaction service of the chain (", serviceURL, ")")
+
+	return client, nil
+}
+
+func parseSafeTxHash(raw string) (common.Hash, error) {
+	hashBytes := common.FromHex(raw)
+	if len(hashBytes) != common.HashLength {
+		return common.Hash{}, errors.New("invalid SafeTxHash")
+	}
+	return common.BytesToHash(hashBytes), nil
+}
+
+func CreateListPendingCommand() *cobra.Command {
+	var flags safeFlags
+
+	listPendingCmd := &cobra.Command{
+		Use:   "list-pending",
+		Short: "List the Safe transactions waiting to be executed",
+		Long:  `List the transactions proposed to the Safe that have not been executed yet and can still be, with their confirmations`,
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return flags.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, flags.timeout)
+			defer cancel()
+
+			client, clientErr := ethclient.DialContext(ctx, flags.rpc)
+			if clientErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), clientErr.Error())
+				return clientErr
+			}
+
+			safeInstance, safeErr := GnosisSafe.NewGnosisSafe(flags.safeAddress, client)
+			if safeErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), safeErr.Error())
+				return safeErr
+			}
+			nonce, nonceErr := safeInstance.Nonce(&bind.CallOpts{Context: ctx})
+			if nonceErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), nonceErr.Error())
+				return nonceErr
+			}
+			threshold, thresholdErr := safeInstance.GetThreshold(&bind.CallOpts{Context: ctx})
+			if thresholdErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), thresholdErr.Error())
+				return thresholdErr
+			}
+
+			safeClient, safeClientErr := flags.serviceClient(ctx, client)
+			if safeClientErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), safeClientErr.Error())
+				return safeClientErr
+			}
+
+			transactions, transactionsErr := safeClient.PendingTransactions(ctx, flags.safeAddress, nonce)
+			if transactionsErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), transactionsErr.Error())
+				return transactionsErr
+			}
+
+			if len(transactions) == 0 {
+				fmt.Println("No pending transactions (next nonce:", nonce.String()+")")
+				return nil
+			}
+
+			for _, transaction := range transactions {
+				fmt.Printf("Nonce %s: %s\n", transaction.Nonce.String(), transaction.SafeTxHash.Hex())
+				fmt.Printf("  %s to %s, value %s\n", OperationType(transaction.Operation).String(), transaction.To.Hex(), transaction.Value.String())
+				fmt.Printf("  Confirmations: %d of %s\n", len(transaction.Confirmations), threshold.String())
+				for _, confirmation := range transaction.Confirmations {
+					fmt.Println("   ", confirmation.Owner.Hex())
+				}
+			}
+
+			return nil
+		},
+	}
+
+	flags.add(listPendingCmd)
+
+	return listPendingCmd
+}
+
+func CreateConfirmCommand() *cobra.Command {
+	var flags safeFlags
+	var keyFile, password, signerRaw string
+	var safeTxHash common.Hash
+
+	confirmCmd := &cobra.Command{
+		Use:   "confirm <safeTxHash>",
+		Short: "Confirm a Safe transaction as an owner",
+		Long:  `Sign a transaction proposed to the Safe as one of its owners and submit the signature to the Safe Transaction Service. The transaction is fetched from the service and its SafeTxHash recomputed before signing.`,
+		Args:  cobra.ExactArgs(1),
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var hashErr error
+			safeTxHash, hashErr = parseSafeTxHash(args[0])
+			if hashErr != nil {
+				return hashErr
+			}
+
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
+
+			return flags.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, flags.timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
+			client, clientErr := ethclient.DialContext(ctx, flags.rpc)
+			if clientErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), clientErr.Error())
+				return clientErr
+			}
+
+			safeClient, safeClientErr := flags.serviceClient(ctx, client)
+			if safeClientErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), safeClientErr.Error())
+				return safeClientErr
+			}
+
+			confirmErr := ConfirmTransaction(ctx, client, safeClient, sender, flags.safeAddress, safeTxHash)
+			if confirmErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), confirmErr.Error())
+				return confirmErr
+			}
+
+			fmt.Println("Confirmed", safeTxHash.Hex(), "as", sender.Address().Hex())
+
+			return nil
+		},
+	}
+
+	confirmCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile of the owner to confirm with")
+	confirmCmd.Flags().StringVar(&password, "password", "", "Password to decrypt keyfile with")
+	signer.AddSignerFlag(confirmCmd, &signerRaw)
+	flags.add(confirmCmd)
+
+	return confirmCmd
+}
+
+func CreateSignCommand() *cobra.Command {
+	var keyFile, password, signerRaw string
+
+	signCmd := &cobra.Command{
+		Use:   "sign <bundle.json>",
+		Short: "Add an owner signature to a Safe bundle",
+		Long:  `Sign the Safe transaction in a bundle file, as written by the --safe-out flag of the bridge commands, and add the signature to the bundle. This needs no network access, so it can be done on an air-gapped machine.`,
+		Args:  cobra.ExactArgs(1),
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bundle, bundleErr := ReadBundle(args[0])
+			if bundleErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), bundleErr.Error())
+				return bundleErr
+			}
+
+			sender, senderErr := signer.FromFlags(cmd.Context(), signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
+			if signErr := bundle.Sign(cmd.Context(), sender); signErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), signErr.Error())
+				return signErr
+			}
+			if writeErr := bundle.Write(args[0]); writeErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), writeErr.Error())
+				return writeErr
+			}
+
+			fmt.Println("Signed", bundle.SafeTxHash.Hex(), "as", sender.Address().Hex())
+			fmt.Println("Signatures:", len(bundle.Signatures))
+
+			return nil
+		},
+	}
+
+	signCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile of the owner to sign with")
+	signCmd.Flags().StringVar(&password, "password", "", "Password to decrypt keyfile with")
+	signer.AddSignerFlag(signCmd, &signerRaw)
+
+	return signCmd
+}
+
+func CreateExecuteCommand() *cobra.Command {
+	var flags safeFlags
+	var keyFile, password, signerRaw string
+	var safeTxHash common.Hash
+	var bundle *Bundle
+
+	executeCmd := &cobra.Command{
+		Use:   "execute <safeTxHash | bundle.json>",
+		Short: "Execute a confirmed Safe transaction",
+		Long: `Execute a Safe transaction once its owners have confirmed it, by calling execTransaction on the Safe.
+
+The transaction is either given by its SafeTxHash, in which case it and its confirmations are fetched from the Safe Transaction Service, or by a bundle file holding it and its signatures (see "safe sign"), in which case no transaction service is needed and --safe and --safe-api can be left out. Without --safe-api, the transaction service of the chain is found through the Safe client gateway.
+
+The confirmations are checked against the current owners and sorted by owner address. If the executing account is an owner that has not confirmed the transaction, its approval counts towards the threshold too.`,
+		Args: cobra.ExactArgs(1),
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
+
+			var hashErr error
+			safeTxHash, hashErr = parseSafeTxHash(args[0])
+			if hashErr == nil {
+				return flags.validate()
+			}
+
+			var bundleErr error
+			bundle, bundleErr = ReadBundle(args[0])
+			if bundleErr != nil {
+				return fmt.Errorf("%s is neither a SafeTxHash nor a readable Safe bundle: %w", args[0], bundleErr)
+			}
+			safeTxHash = bundle.SafeTxHash
+
+			if flags.rpc == "" {
+				return errors.New("rpc is required")
+			}
+			if flags.safeAddressRaw != "" && common.HexToAddress(flags.safeAddressRaw) != bundle.Safe {
+				return fmt.Errorf("bundle is for Safe %s, not for %s", bundle.Safe.Hex(), flags.safeAddressRaw)
+			}
+			flags.safeAddress = bundle.Safe
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, flags.timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
+			client, clientErr := ethclient.DialContext(ctx, flags.rpc)
+			if clientErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), clientErr.Error())
+				return clientErr
+			}
+			chainID, chainIDErr := client.ChainID(ctx)
+			if chainIDErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), chainIDErr.Error())
+				return chainIDErr
+			}
+
+			var txData TransactionData
+			var confirmations []Confirmation
+			if bundle != nil {
+				if bundle.ChainID.Cmp(chainID) != 0 {
+					chainErr := fmt.Errorf("bundle is for chain %s, but the RPC serves chain %s", bundle.ChainID.String(), chainID.String())
+					fmt.Fprintln(cmd.ErrOrStderr(), chainErr.Error())
+					return chainErr
+				}
+				txData = bundle.Transaction
+				confirmations = BundleConfirmations(bundle)
+			} else {
+				safeClient, safeClientErr := flags.serviceClient(ctx, client)
+				if safeClientErr != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), safeClientErr.Error())
+					return safeClientErr
+				}
+
+				transaction, transactionErr := safeClient.Transaction(ctx, safeTxHash)
+				if transactionErr != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), transactionErr.Error())
+					return transactionErr
+				}
+				if transaction.IsExecuted {
+					executedErr := fmt.Errorf("Safe transaction %s has already been executed", safeTxHash.Hex())
+					fmt.Fprintln(cmd.ErrOrStderr(), executedErr.Error())
+					return executedErr
+				}
+				txData = transaction.TransactionData()
+				confirmations = transaction.OwnerConfirmations()
+			}
+
+			if verifyErr := VerifySafeTxHash(flags.safeAddress, txData, chainID, safeTxHash); verifyErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), verifyErr.Error())
+				return verifyErr
+			}
+
+			receipt, executeErr := ExecuteTransaction(ctx, client, sender, flags.safeAddress, txData, confirmations)
+			if executeErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), executeErr.Error())
+				return executeErr
+			}
+
+			fmt.Println("Executed", safeTxHash.Hex(), "in block", receipt.BlockNumber.String())
+
+			return nil
+		},
+	}
+
+	executeCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile to send execTransaction with")
+	executeCmd.Flags().StringVar(&password, "password", "", "Password to decrypt keyfile with")
+	signer.AddSignerFlag(executeCmd, &signerRaw)
+	flags.add(executeCmd)
+
+	return executeCmd
+}
+
+func CreateBatchCommand() *cobra.Command {
+	var flags safeFlags
+	var keyFile, password, signerRaw, safeOut, safeNonceRaw, multiSendRaw string
+	var force bool
+	var multiSendAddress common.Address
+	var safeNonce *big.Int
+	var calls []MultiSendCall
+
+	batchCmd := &cobra.Command{
+		Use:   "batch <calls.yaml>",
+		Short: "Propose several calls as a single Safe transaction",
+		Long: `Propose a list of calls as a single Safe transaction, which delegate calls MultiSendCallOnly to execute them in order. If any of the calls reverts, the whole transaction reverts.
+
+The calls are read from a YAML or JSON file holding a list of objects with a "to" address, an optional "value" in wei and optional hex "data". The output of the --calldata flag of the contract commands can be used as data as is:
+
+  - to: 0x...
+    data: 095ea7b3...
+  - to: 0x...
+    value: 1000000000000000000
+
+Before it is proposed, the transaction is simulated with eth_call, and the command aborts with the revert reason if it reverts, unless --force is given.
+
+The transaction is proposed to the Safe Transaction Service at --safe-api, or to the Safe client gateway if --safe-api is not given. With --safe-out, it is written to a bundle file instead (see "safe sign").`,
+		Args: cobra.ExactArgs(1),
+
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var callsErr error
+			calls, callsErr = ReadMultiSendCalls(args[0])
+			if callsErr != nil {
+				return callsErr
+			}
+
+			if keyFile == "" && signerRaw == "" {
+				return errors.New("keyfile or signer is required")
+			}
+
+			if flags.rpc == "" {
+				return errors.New("rpc is required")
+			}
+
+			if !common.IsHexAddress(flags.safeAddressRaw) {
+				return errors.New("--safe is not a valid Ethereum address")
+			}
+			flags.safeAddress = common.HexToAddress(flags.safeAddressRaw)
+
+			if !common.IsHexAddress(multiSendRaw) {
+				return errors.New("--safe-multisend is not a valid Ethereum address")
+			}
+			multiSendAddress = common.HexToAddress(multiSendRaw)
+
+			if safeNonceRaw != "" {
+				safeNonce = new(big.Int)
+				if _, ok := safeNonce.SetString(safeNonceRaw, 0); !ok {
+					return errors.New("--safe-nonce is not a valid big integer")
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := command.Context(cmd, flags.timeout)
+			defer cancel()
+
+			sender, senderErr := signer.FromFlags(ctx, signerRaw, keyFile, password)
+			if senderErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), senderErr.Error())
+				return senderErr
+			}
+
+			client, clientErr := ethclient.DialContext(ctx, flags.rpc)
+			if clientErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), clientErr.Error())
+				return clientErr
+			}
+
+			safeClient := flags.client()
+			if flags.safeApi == "" && safeOut == "" {
+				chainID, chainIDErr := client.ChainID(ctx)
+				if chainIDErr != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), chainIDErr.Error())
+					return chainIDErr
+				}
+				safeClient.ProposeURL = GatewayProposeURL(chainID, flags.safeAddress)
+				fmt.Println("--safe-api not specified, proposing through the Safe client gateway (", safeClient.ProposeURL, ")")
+			}
+
+			for i, call := range calls {
+				fmt.Printf("Call %d: %s, value %s, %d bytes of data\n", i, call.To.Hex(), call.Value.String(), len(call.Data))
+			}
+
+			proposeErr := CreateBatchProposal(ctx, client, sender, safeClient, safeOut, force, flags.safeAddress, multiSendAddress, calls, safeNonce)
+			if proposeErr != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), proposeErr.Error())
+				return proposeErr
+			}
+
+			return nil
+		},
+	}
+
+	batchCmd.Flags().StringVar(&keyFile, "keyfile", "", "Keyfile of the owner proposing the transaction")
+	batchCmd.Flags().StringVar(&password, "password", "", "Password to decrypt keyfile with")
+	signer.AddSignerFlag(batchCmd, &signerRaw)
+	batchCmd.Flags().StringVar(&safeOut, "safe-out", "", "Write the signed Safe transaction to this bundle file instead of proposing it")
+	batchCmd.Flags().BoolVar(&force, "force", false, "Propose the transaction even if it reverts in simulation")
+	batchCmd.Flags().StringVar(&safeNonceRaw, "safe-nonce", "", "Safe nonce (defaults to the next nonce of the Safe)")
+	batchCmd.Flags().StringVar(&multiSendRaw, "safe-multisend", MultiSendCallOnlyAddress.Hex(), "Address of the MultiSendCallOnly contract to delegate call")
+	flags.add(batchCmd)
+
+	return batchCmd
+}
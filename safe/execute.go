@@ -0,0 +1,256 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/G7DAO/protocol/bindings/GnosisSafe"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var ErrThresholdNotMet = errors.New("not enough owner confirmations to execute the Safe transaction")
+
+// Confirmation is an owner's approval of a Safe transaction: a 65 byte signature in the format checked by the Safe.
+type Confirmation struct {
+	Owner     common.Address
+	Signature []byte
+}
+
+// Returns the confirmation of an owner that sends execTransaction itself, which the Safe accepts without a signature.
+// Source: https://docs.safe.global/advanced/smart-account-signatures#pre-validated-signatures
+func ApprovedHashConfirmation(owner common.Address) Confirmation {
+	signature := make([]byte, 65)
+	copy(signature[12:32], owner.Bytes())
+	signature[64] = 1
+	return Confirmation{Owner: owner, Signature: signature}
+}
+
+// Returns the owner that made a signature of safeTxHash, following the signature types of the Safe: V of 27 or 28 for
+// ECDSA signatures of the hash, V of 31 or 32 for eth_sign signatures, and V of 1 for pre-validated signatures, whose R
+// holds the owner. Contract signatures (V of 0) are not supported.
+func SignatureOwner(safeTxHash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != 65 {
+		return common.Address{}, fmt.Errorf("signature must be 65 bytes long, not %d", len(signature))
+	}
+
+	v := signature[64]
+	switch {
+	case v == 1:
+		return common.BytesToAddress(signature[12:32]), nil
+	case v > 30:
+		ecdsaSignature := common.CopyBytes(signature)
+		ecdsaSignature[64] -= 4
+		return signer.RecoverMessageSigner(safeTxHash.Bytes(), ecdsaSignature)
+	case v >= 27:
+		return signer.RecoverHashSigner(safeTxHash, signature)
+	default:
+		return common.Address{}, fmt.Errorf("unsupported signature type (V = %d)", v)
+	}
+}
+
+// Returns the signatures collected in a Safe bundle, in the form taken by EncodeSignatures.
+func BundleConfirmations(bundle *Bundle) []Confirmation {
+	confirmations := make([]Confirmation, len(bundle.Signatures))
+	for i, signature := range bundle.Signatures {
+		confirmations[i] = Confirmation{Owner: signature.Owner, Signature: signature.Signature}
+	}
+	return confirmations
+}
+
+// Concatenates confirmations into the signatures argument of execTransaction, which the Safe requires to be sorted by
+// owner address.
+func EncodeSignatures(confirmations []Confirmation) []byte {
+	sorted := make([]Confirmation, len(confirmations))
+	copy(sorted, confirmations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Owner.Bytes(), sorted[j].Owner.Bytes()) < 0
+	})
+
+	signatures := make([]byte, 0, 65*len(sorted))
+	for _, confirmation := range sorted {
+		signatures = append(signatures, confirmation.Signature...)
+	}
+	return signatures
+}
+
+// Returns threshold valid confirmations of safeTxHash by distinct owners. Confirmations that do not verify or that are
+// not made by a current owner are left out. If the executor is an owner that has not confirmed the transaction, its
+// pre-validated confirmation is counted too. Fails with ErrThresholdNotMet if there are fewer than threshold of them.
+func SelectConfirmations(safeTxHash common.Hash, owners []common.Address, threshold uint64, confirmations []Confirmation, executor common.Address) ([]Confirmation, error) {
+	isOwner := make(map[common.Address]bool, len(owners))
+	for _, owner := range owners {
+		isOwner[owner] = true
+	}
+
+	selected := []Confirmation{}
+	confirmed := map[common.Address]bool{}
+	for _, confirmation := range confirmations {
+		owner, ownerErr := SignatureOwner(safeTxHash, confirmation.Signature)
+		if ownerErr != nil || owner != confirmation.Owner || !isOwner[owner] || confirmed[owner] {
+			continue
+		}
+		// A pre-validated signature only holds if the owner sends the transaction or approved the hash on chain, which
+		// the Safe checks when executing.
+		confirmed[owner] = true
+		selected = append(selected, confirmation)
+	}
+
+	if isOwner[executor] && !confirmed[executor] {
+		selected = append(selected, ApprovedHashConfirmation(executor))
+	}
+
+	if uint64(len(selected)) < threshold {
+		return nil, fmt.Errorf("%w: %d of %d", ErrThresholdNotMet, len(selected), threshold)
+	}
+
+	// The Safe only checks the first threshold signatures, in owner order.
+	sort.Slice(selected, func(i, j int) bool {
+		return bytes.Compare(selected[i].Owner.Bytes(), selected[j].Owner.Bytes()) < 0
+	})
+	return selected[:threshold], nil
+}
+
+// Checks that a transaction is the one that hashes to safeTxHash for the given Safe and chain, so that a transaction
+// service cannot get owners to approve something other than what they are shown.
+func VerifySafeTxHash(safeAddress common.Address, txData TransactionData, chainID *big.Int, safeTxHash common.Hash) error {
+	computedHash, hashErr := CalculateSafeTxHash(safeAddress, txData, chainID)
+	if hashErr != nil {
+		return hashErr
+	}
+	if computedHash != safeTxHash {
+		return fmt.Errorf("transaction hashes to %s, not to %s", computedHash.Hex(), safeTxHash.Hex())
+	}
+	return nil
+}
+
+// Signs the transaction with the given SafeTxHash as an owner and submits the signature to the transaction service.
+// The transaction is fetched from the service and its hash checked before signing.
+func ConfirmTransaction(ctx context.Context, client Backend, service *Client, sender signer.Signer, safeAddress common.Address, safeTxHash common.Hash) error {
+	chainID, chainIDErr := client.ChainID(ctx)
+	if chainIDErr != nil {
+		return fmt.Errorf("failed to get chain ID: %v", chainIDErr)
+	}
+
+	transaction, transactionErr := service.Transaction(ctx, safeTxHash)
+	if transactionErr != nil {
+		return fmt.Errorf("failed to fetch Safe transaction %s: %w", safeTxHash.Hex(), transactionErr)
+	}
+	if transaction.Safe != safeAddress {
+		return fmt.Errorf("Safe transaction %s belongs to Safe %s, not to %s", safeTxHash.Hex(), transaction.Safe.Hex(), safeAddress.Hex())
+	}
+	if verifyErr := VerifySafeTxHash(safeAddress, transaction.TransactionData(), chainID, safeTxHash); verifyErr != nil {
+		return verifyErr
+	}
+
+	safeInstance, safeErr := GnosisSafe.NewGnosisSafe(safeAddress, client)
+	if safeErr != nil {
+		return fmt.Errorf("failed to create GnosisSafe instance: %v", safeErr)
+	}
+	isOwner, isOwnerErr := safeInstance.IsOwner(&bind.CallOpts{Context: ctx}, sender.Address())
+	if isOwnerErr != nil {
+		return fmt.Errorf("failed to check owners of Safe: %v", isOwnerErr)
+	}
+	if !isOwner {
+		return fmt.Errorf("%s is not an owner of Safe %s", sender.Address().Hex(), safeAddress.Hex())
+	}
+
+	signature, signErr := SignSafeTxHash(ctx, sender, safeTxHash)
+	if signErr != nil {
+		return fmt.Errorf("failed to sign SafeTxHash: %v", signErr)
+	}
+
+	return service.Confirm(ctx, safeTxHash, signature)
+}
+
+// Executes a Safe transaction with the given owner confirmations by calling execTransaction on the Safe, and waits for
+// it to be mined. The transaction must be the next one of the Safe and have enough confirmations for its threshold.
+func ExecuteTransaction(ctx context.Context, client Backend, sender signer.Signer, safeAddress common.Address, txData TransactionData, confirmations []Confirmation) (*types.Receipt, error) {
+	chainID, chainIDErr := client.ChainID(ctx)
+	if chainIDErr != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %v", chainIDErr)
+	}
+
+	safeTxHash, hashErr := CalculateSafeTxHash(safeAddress, txData, chainID)
+	if hashErr != nil {
+		return nil, fmt.Errorf("failed to calculate SafeTxHash: %v", hashErr)
+	}
+
+	safeInstance, safeErr := GnosisSafe.NewGnosisSafe(safeAddress, client)
+	if safeErr != nil {
+		return nil, fmt.Errorf("failed to create GnosisSafe instance: %v", safeErr)
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	nonce, nonceErr := safeInstance.Nonce(callOpts)
+	if nonceErr != nil {
+		return nil, fmt.Errorf("failed to fetch nonce from Safe contract: %v", nonceErr)
+	}
+	if nonce.Cmp(txData.Nonce) != 0 {
+		return nil, fmt.Errorf("Safe transaction has nonce %s, but the next nonce of the Safe is %s", txData.Nonce.String(), nonce.String())
+	}
+
+	owners, ownersErr := safeInstance.GetOwners(callOpts)
+	if ownersErr != nil {
+		return nil, fmt.Errorf("failed to fetch owners of Safe: %v", ownersErr)
+	}
+	threshold, thresholdErr := safeInstance.GetThreshold(callOpts)
+	if thresholdErr != nil {
+		return nil, fmt.Errorf("failed to fetch threshold of Safe: %v", thresholdErr)
+	}
+
+	selected, selectErr := SelectConfirmations(safeTxHash, owners, threshold.Uint64(), confirmations, sender.Address())
+	if selectErr != nil {
+		return nil, selectErr
+	}
+
+	value, valueOk := new(big.Int).SetString(txData.Value, 10)
+	gasPrice, gasPriceOk := new(big.Int).SetString(txData.GasPrice, 10)
+	if !valueOk || !gasPriceOk {
+		return nil, fmt.Errorf("invalid value or gas price in Safe transaction")
+	}
+
+	transaction, transactionErr := safeInstance.ExecTransaction(
+		signer.NewTransactOpts(ctx, sender, chainID),
+		common.HexToAddress(txData.To),
+		value,
+		common.FromHex(txData.Data),
+		uint8(txData.Operation),
+		new(big.Int).SetUint64(txData.SafeTxGas),
+		new(big.Int).SetUint64(txData.BaseGas),
+		gasPrice,
+		common.HexToAddress(txData.GasToken),
+		common.HexToAddress(txData.RefundReceiver),
+		EncodeSignatures(selected),
+	)
+	if transactionErr != nil {
+		return nil, fmt.Errorf("failed to send execTransaction: %v", transactionErr)
+	}
+	fmt.Println("Transaction sent:", transaction.Hash().Hex())
+
+	receipt, receiptErr := bind.WaitMined(ctx, client, transaction)
+	if receiptErr != nil {
+		return nil, fmt.Errorf("failed to wait for execTransaction %s: %w", transaction.Hash().Hex(), receiptErr)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("execTransaction %s reverted", transaction.Hash().Hex())
+	}
+
+	// The Safe does not revert when the inner call of a transaction with a safeTxGas fails, it emits ExecutionFailure.
+	for _, log := range receipt.Logs {
+		if log.Address != safeAddress {
+			continue
+		}
+		if _, failureErr := safeInstance.ParseExecutionFailure(*log); failureErr == nil {
+			return receipt, fmt.Errorf("Safe transaction %s failed in execTransaction %s", safeTxHash.Hex(), transaction.Hash().Hex())
+		}
+	}
+
+	return receipt, nil
+}
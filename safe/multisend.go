@@ -0,0 +1,134 @@
+package safe
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/G7DAO/protocol/bindings/MultiSendCallOnly"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"gopkg.in/yaml.v2"
+)
+
+// Source: https://github.com/safe-global/safe-deployments/blob/main/src/assets/v1.3.0/multi_send_call_only.json
+var MultiSendCallOnlyAddress = common.HexToAddress("0x40A2aCCbd92BCA938b02010E17A5b8929b49130D")
+
+// A call batched through MultiSendCallOnly
+type MultiSendCall struct {
+	To    common.Address
+	Value *big.Int
+	Data  []byte
+}
+
+// A call as written in a batch file. All fields are read as strings, so that unquoted addresses and hex calldata are
+// kept as written instead of being read as numbers.
+type multiSendCallEntry struct {
+	To    string `yaml:"to"`
+	Value string `yaml:"value"`
+	Data  string `yaml:"data"`
+}
+
+// Encodes calls in the packed format expected by MultiSendCallOnly: for each call, the operation (always Call), the
+// target address, the value and the length of the data as uint256, followed by the data.
+// Source: https://github.com/safe-global/safe-smart-account/blob/v1.3.0/contracts/libraries/MultiSendCallOnly.sol
+func EncodeMultiSendTransactions(calls []MultiSendCall) []byte {
+	var transactions []byte
+	for _, call := range calls {
+		value := call.Value
+		if value == nil {
+			value = big.NewInt(0)
+		}
+
+		dataLength := make([]byte, 32)
+		binary.BigEndian.PutUint64(dataLength[24:], uint64(len(call.Data)))
+
+		transactions = append(transactions, byte(Call))
+		transactions = append(transactions, call.To.Bytes()...)
+		transactions = append(transactions, math.U256Bytes(big.NewInt(0).Set(value))...)
+		transactions = append(transactions, dataLength...)
+		transactions = append(transactions, call.Data...)
+	}
+	return transactions
+}
+
+// Returns the calldata of a MultiSendCallOnly.multiSend call executing the given calls in order. A Safe must
+// DelegateCall MultiSendCallOnly with this calldata, with a value of 0: the calls send their values from the Safe's own
+// balance.
+func GetMultiSendCalldata(calls []MultiSendCall) ([]byte, error) {
+	multiSendAbi, multiSendAbiErr := abi.JSON(strings.NewReader(MultiSendCallOnly.MultiSendCallOnlyABI))
+	if multiSendAbiErr != nil {
+		return nil, multiSendAbiErr
+	}
+
+	// function multiSend(bytes memory transactions) public payable
+	return multiSendAbi.Pack("multiSend", EncodeMultiSendTransactions(calls))
+}
+
+// Parses the calls of a batch from YAML or JSON: a list of objects with a "to" address, an optional "value" in wei
+// (decimal or 0x-prefixed hex) and optional hex "data", with or without the 0x prefix so that the output of the
+// --calldata flag of the contract commands can be pasted as is.
+func ParseMultiSendCalls(raw []byte) ([]MultiSendCall, error) {
+	var entries []multiSendCallEntry
+	if unmarshalErr := yaml.UnmarshalStrict(raw, &entries); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("batch has no calls")
+	}
+
+	calls := make([]MultiSendCall, len(entries))
+	for i, entry := range entries {
+		if !common.IsHexAddress(entry.To) {
+			return nil, fmt.Errorf("call %d: to is not a valid Ethereum address", i)
+		}
+		calls[i].To = common.HexToAddress(entry.To)
+
+		calls[i].Value = big.NewInt(0)
+		if entry.Value != "" {
+			if _, ok := calls[i].Value.SetString(entry.Value, 0); !ok || calls[i].Value.Sign() < 0 {
+				return nil, fmt.Errorf("call %d: value is not a valid amount of wei", i)
+			}
+		}
+
+		data, dataErr := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(entry.Data), "0x"))
+		if dataErr != nil {
+			return nil, fmt.Errorf("call %d: data is not valid hex: %w", i, dataErr)
+		}
+		calls[i].Data = data
+	}
+
+	return calls, nil
+}
+
+// Reads the calls of a batch from a YAML or JSON file, in the format taken by ParseMultiSendCalls.
+func ReadMultiSendCalls(callsFile string) ([]MultiSendCall, error) {
+	raw, readErr := os.ReadFile(callsFile)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	calls, parseErr := ParseMultiSendCalls(raw)
+	if parseErr != nil {
+		return nil, fmt.Errorf("could not read calls from %s: %w", callsFile, parseErr)
+	}
+	return calls, nil
+}
+
+// Creates a single Safe proposal that executes the given calls in order by delegate calling MultiSendCallOnly at
+// multiSendAddress. If any of the calls reverts, the whole Safe transaction reverts.
+func CreateBatchProposal(ctx context.Context, client Backend, sender signer.Signer, safeClient *Client, safeOut string, force bool, safeAddress common.Address, multiSendAddress common.Address, calls []MultiSendCall, nonce *big.Int) error {
+	multiSendData, multiSendDataErr := GetMultiSendCalldata(calls)
+	if multiSendDataErr != nil {
+		return multiSendDataErr
+	}
+
+	return CreateProposal(ctx, client, sender, safeClient, safeOut, force, safeAddress, multiSendAddress, multiSendData, big.NewInt(0), DelegateCall, nonce)
+}
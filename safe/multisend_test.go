@@ -0,0 +1,52 @@
+package safe
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseMultiSendCalls(t *testing.T) {
+	token := common.HexToAddress("0x5E5Fb9E1C4AC1f6bd9a3F8D0D0D8A1e1A6F2D2a1")
+	recipient := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	// Unquoted addresses and calldata must not be read as numbers.
+	yamlCalls := []byte(`
+- to: ` + token.Hex() + `
+  data: 095ea7b3
+- to: ` + recipient.Hex() + `
+  value: 1000000000000000000
+  data: 0x
+`)
+	jsonCalls := []byte(`[{"to": "` + token.Hex() + `", "data": "0x095ea7b3"}, {"to": "` + recipient.Hex() + `", "value": "0xde0b6b3a7640000"}]`)
+
+	for name, raw := range map[string][]byte{"yaml": yamlCalls, "json": jsonCalls} {
+		calls, parseErr := ParseMultiSendCalls(raw)
+		if parseErr != nil {
+			t.Fatalf("%s: %v", name, parseErr)
+		}
+		if len(calls) != 2 {
+			t.Fatalf("%s: expected 2 calls, got %d", name, len(calls))
+		}
+		if calls[0].To != token || !bytes.Equal(calls[0].Data, []byte{0x09, 0x5e, 0xa7, 0xb3}) || calls[0].Value.Sign() != 0 {
+			t.Errorf("%s: unexpected first call %+v", name, calls[0])
+		}
+		if calls[1].To != recipient || len(calls[1].Data) != 0 || calls[1].Value.Cmp(big.NewInt(1e18)) != 0 {
+			t.Errorf("%s: unexpected second call %+v", name, calls[1])
+		}
+	}
+
+	// Each call is the operation, the target, the value and the data length as uint256, and the data.
+	encoded := EncodeMultiSendTransactions([]MultiSendCall{{To: token, Data: []byte{0x09, 0x5e, 0xa7, 0xb3}}})
+	if len(encoded) != 1+20+32+32+4 || encoded[0] != byte(Call) || !bytes.Equal(encoded[1:21], token.Bytes()) || encoded[84] != 4 {
+		t.Errorf("Unexpected encoding %x", encoded)
+	}
+
+	for _, invalid := range []string{`[]`, `- to: 0x1234`, `- {to: "` + token.Hex() + `", data: 0xzz}`, `- {to: "` + token.Hex() + `", value: -1}`, `- {to: "` + token.Hex() + `", gas: 1}`} {
+		if _, invalidErr := ParseMultiSendCalls([]byte(invalid)); invalidErr == nil {
+			t.Errorf("Expected an error for %s", invalid)
+		}
+	}
+}
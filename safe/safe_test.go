@@ -0,0 +1,296 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+func newTestOwners(t *testing.T, count int) []*signer.LocalSigner {
+	owners := make([]*signer.LocalSigner, count)
+	for i := range owners {
+		privateKey, privateKeyErr := crypto.GenerateKey()
+		if privateKeyErr != nil {
+			t.Fatal(privateKeyErr)
+		}
+		owners[i] = signer.NewLocalSigner(privateKey)
+	}
+	return owners
+}
+
+func TestSelectConfirmations(t *testing.T) {
+	ctx := context.Background()
+	safeTxHash := crypto.Keccak256Hash([]byte("safe transaction"))
+	owners := newTestOwners(t, 3)
+	outsider := newTestOwners(t, 1)[0]
+
+	ownerAddresses := []common.Address{}
+	confirmations := []Confirmation{}
+	for _, owner := range append(owners, outsider) {
+		ownerAddresses = append(ownerAddresses, owner.Address())
+		signature, signErr := SignSafeTxHash(ctx, owner, safeTxHash)
+		if signErr != nil {
+			t.Fatal(signErr)
+		}
+		confirmations = append(confirmations, Confirmation{Owner: owner.Address(), Signature: signature})
+	}
+	ownerAddresses = ownerAddresses[:3]
+
+	// Only the two confirmations of owners with the lowest addresses are needed, in owner order.
+	selected, selectErr := SelectConfirmations(safeTxHash, ownerAddresses, 2, confirmations, outsider.Address())
+	if selectErr != nil {
+		t.Fatal(selectErr)
+	}
+	if len(selected) != 2 || bytes.Compare(selected[0].Owner.Bytes(), selected[1].Owner.Bytes()) >= 0 {
+		t.Fatalf("Expected two confirmations sorted by owner, got %+v", selected)
+	}
+	for _, confirmation := range selected {
+		if confirmation.Owner == outsider.Address() {
+			t.Errorf("Confirmation of %s, which is not an owner, was selected", outsider.Address().Hex())
+		}
+	}
+	if signatures := EncodeSignatures(selected); len(signatures) != 130 {
+		t.Errorf("Expected 130 bytes of signatures, got %d", len(signatures))
+	}
+
+	// A single confirmation is not enough, unless the executor is an owner that can approve by sending the transaction.
+	_, thresholdErr := SelectConfirmations(safeTxHash, ownerAddresses, 2, confirmations[:1], outsider.Address())
+	if !errors.Is(thresholdErr, ErrThresholdNotMet) {
+		t.Errorf("Expected ErrThresholdNotMet, got %v", thresholdErr)
+	}
+	selected, selectErr = SelectConfirmations(safeTxHash, ownerAddresses, 2, confirmations[:1], owners[1].Address())
+	if selectErr != nil {
+		t.Fatal(selectErr)
+	}
+	for _, confirmation := range selected {
+		if confirmation.Owner == owners[1].Address() && confirmation.Signature[64] != 1 {
+			t.Errorf("Expected a pre-validated signature for the executor, got V = %d", confirmation.Signature[64])
+		}
+	}
+
+	// Signatures that do not match the claimed owner are rejected.
+	forged := []Confirmation{{Owner: owners[0].Address(), Signature: confirmations[1].Signature}, confirmations[2]}
+	if _, forgedErr := SelectConfirmations(safeTxHash, ownerAddresses, 2, forged, outsider.Address()); !errors.Is(forgedErr, ErrThresholdNotMet) {
+		t.Errorf("Expected ErrThresholdNotMet with a forged confirmation, got %v", forgedErr)
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	chainID := big.NewInt(13746)
+	safeAddress := common.HexToAddress("0x5afe")
+	owner := newTestOwners(t, 1)[0]
+
+	txData := TransactionData{
+		To:             common.HexToAddress("0x1").Hex(),
+		Value:          "1000",
+		Data:           "a9059cbb",
+		Operation:      Call,
+		GasPrice:       "0",
+		GasToken:       NativeTokenAddress,
+		RefundReceiver: NativeTokenAddress,
+		Nonce:          big.NewInt(4),
+	}
+	safeTxHash, hashErr := CalculateSafeTxHash(safeAddress, txData, chainID)
+	if hashErr != nil {
+		t.Fatal(hashErr)
+	}
+
+	var confirmed []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/multisig-transactions/"+safeTxHash.Hex()+"/":
+			w.Write([]byte(`{"safe": "` + safeAddress.Hex() + `", "to": "` + txData.To + `", "value": "1000", "data": "0xa9059cbb",
+				"operation": 0, "safeTxGas": 0, "baseGas": "0", "gasPrice": "0", "gasToken": "` + NativeTokenAddress + `",
+				"refundReceiver": "` + NativeTokenAddress + `", "nonce": 4, "safeTxHash": "` + safeTxHash.Hex() + `",
+				"isExecuted": false, "confirmations": []}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/multisig-transactions/"+safeTxHash.Hex()+"/confirmations/":
+			var body struct {
+				Signature string `json:"signature"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			confirmed = common.FromHex(body.Signature)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.Error(w, `{"detail": "Not found."}`, http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	service := NewClient(server.URL + "/")
+
+	transaction, transactionErr := service.Transaction(ctx, safeTxHash)
+	if transactionErr != nil {
+		t.Fatal(transactionErr)
+	}
+	if verifyErr := VerifySafeTxHash(safeAddress, transaction.TransactionData(), chainID, safeTxHash); verifyErr != nil {
+		t.Fatal(verifyErr)
+	}
+
+	signature, signErr := SignSafeTxHash(ctx, owner, safeTxHash)
+	if signErr != nil {
+		t.Fatal(signErr)
+	}
+	if confirmErr := service.Confirm(ctx, safeTxHash, signature); confirmErr != nil {
+		t.Fatal(confirmErr)
+	}
+	signatureOwner, ownerErr := SignatureOwner(safeTxHash, confirmed)
+	if ownerErr != nil {
+		t.Fatal(ownerErr)
+	}
+	if signatureOwner != owner.Address() {
+		t.Errorf("Expected confirmation by %s, got %s", owner.Address().Hex(), signatureOwner.Hex())
+	}
+
+	if _, missingErr := service.Transaction(ctx, common.Hash{}); missingErr == nil {
+		t.Error("Expected an error for an unknown transaction")
+	}
+}
+
+func TestClientPropose(t *testing.T) {
+	ctx := context.Background()
+	safeAddress := common.HexToAddress("0x5afe")
+	owner := newTestOwners(t, 1)[0]
+	txData := TransactionData{
+		To:             common.HexToAddress("0x1").Hex(),
+		Value:          "0",
+		Operation:      Call,
+		GasPrice:       "0",
+		GasToken:       NativeTokenAddress,
+		RefundReceiver: NativeTokenAddress,
+		Nonce:          big.NewInt(7),
+	}
+	safeTxHash := crypto.Keccak256Hash([]byte("safe transaction"))
+
+	attempts := 0
+	var proposed map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			http.Error(w, `{"detail": "Invalid API key"}`, http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/api/v1/safes/"+safeAddress.Hex()+"/multisig-transactions/" {
+			http.Error(w, `{"detail": "Not found."}`, http.StatusNotFound)
+			return
+		}
+		// The service fails once before accepting the proposal.
+		attempts++
+		if attempts == 1 {
+			http.Error(w, "Bad Gateway", http.StatusBadGateway)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&proposed); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.URL)
+	client.RetryDelay = time.Millisecond
+	client.Origin = Origin{Name: "test"}
+
+	// Without the API key, the request is rejected and the error carries the explanation of the service.
+	proposeErr := client.Propose(ctx, safeAddress, txData, safeTxHash, owner.Address(), make([]byte, 65))
+	var apiErr *APIError
+	if !errors.As(proposeErr, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || !strings.Contains(apiErr.Body, "Invalid API key") {
+		t.Fatalf("Expected an APIError with the response body, got %v", proposeErr)
+	}
+
+	client.APIKey = "test-key"
+	if proposeErr := client.Propose(ctx, safeAddress, txData, safeTxHash, owner.Address(), make([]byte, 65)); proposeErr != nil {
+		t.Fatal(proposeErr)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected the proposal to be retried once, got %d attempts", attempts)
+	}
+	if proposed["contractTransactionHash"] != safeTxHash.Hex() || proposed["nonce"] != "7" || proposed["origin"] != `{"name":"test"}` {
+		t.Errorf("Unexpected proposal %v", proposed)
+	}
+}
+
+func TestClientGatewayTransactionService(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/chains/1":
+			w.Write([]byte(`{"chainId": "1", "chainName": "Ethereum", "transactionService": "https://safe-transaction-mainnet.safe.global/"}`))
+		case "/v1/chains/13746":
+			w.Write([]byte(`{"chainId": "13746", "transactionService": ""}`))
+		default:
+			http.Error(w, `{"detail": "Not found."}`, http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	gatewayURL := GatewayURL
+	GatewayURL = server.URL
+	t.Cleanup(func() { GatewayURL = gatewayURL })
+
+	client := NewClient("")
+	client.RetryDelay = time.Millisecond
+
+	serviceURL, serviceErr := client.GatewayTransactionService(ctx, big.NewInt(1))
+	if serviceErr != nil {
+		t.Fatal(serviceErr)
+	}
+	if serviceURL != "https://safe-transaction-mainnet.safe.global" {
+		t.Errorf("Unexpected transaction service %s", serviceURL)
+	}
+
+	if _, serviceErr := client.GatewayTransactionService(ctx, big.NewInt(13746)); serviceErr == nil {
+		t.Error("Expected an error for a chain without a transaction service")
+	}
+	if _, serviceErr := client.GatewayTransactionService(ctx, big.NewInt(2)); serviceErr == nil {
+		t.Error("Expected an error for a chain unknown to the gateway")
+	}
+}
+
+func TestSimulateTransaction(t *testing.T) {
+	ctx := context.Background()
+	safeAddress := common.HexToAddress("0x5afe")
+	target := common.HexToAddress("0x7a26e7")
+
+	// The target reverts with the Staker custom error LockupNotExpired(42), which the Staker binding registers.
+	RegisterRevertErrorABI(`[{"type":"error","name":"LockupNotExpired","inputs":[{"name":"expiresAt","type":"uint256"}]}]`)
+	selector := crypto.Keccak256([]byte("LockupNotExpired(uint256)"))[:4]
+	code := append([]byte{0x7f}, common.RightPadBytes(selector, 32)...)
+	code = append(code, 0x60, 0x00, 0x52, 0x60, 0x2a, 0x60, 0x04, 0x52, 0x60, 0x24, 0x60, 0x00, 0xfd)
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		safeAddress: {Balance: big.NewInt(1000)},
+		target:      {Code: code},
+	})
+	t.Cleanup(func() { backend.Close() })
+
+	txData := TransactionData{To: common.HexToAddress("0x1").Hex(), Value: "1000", Operation: Call, Nonce: big.NewInt(0)}
+	if simulateErr := SimulateTransaction(ctx, backend.Client(), safeAddress, txData); simulateErr != nil {
+		t.Fatalf("Expected a transfer within the balance of the Safe to succeed, got %v", simulateErr)
+	}
+
+	txData.To = target.Hex()
+	simulateErr := SimulateTransaction(ctx, backend.Client(), safeAddress, txData)
+	if !errors.Is(simulateErr, ErrSimulationFailed) || !strings.Contains(simulateErr.Error(), "LockupNotExpired(42)") {
+		t.Errorf("Expected a decoded LockupNotExpired revert, got %v", simulateErr)
+	}
+
+	revertData, _ := (abi.Arguments{{Type: abi.Type{T: abi.StringTy}}}).Pack("nope")
+	if reason := DecodeRevert(append(crypto.Keccak256([]byte("Error(string)"))[:4], revertData...)); reason != "execution reverted: nope" {
+		t.Errorf("Unexpected revert reason %q", reason)
+	}
+}
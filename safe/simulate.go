@@ -0,0 +1,133 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/G7DAO/protocol/bindings/ArbRetryableTx"
+	"github.com/G7DAO/protocol/bindings/ArbSys"
+	"github.com/G7DAO/protocol/bindings/ArbitrumOutbox"
+	"github.com/G7DAO/protocol/bindings/ERC20Inbox"
+	"github.com/G7DAO/protocol/bindings/L1Teleporter"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var ErrSimulationFailed = errors.New("Safe transaction reverts in simulation")
+
+// ABIs of the contracts that Safe transactions are proposed to, whose custom errors are decoded in revert reasons.
+// The bindings generated with a command line tool add their own ABI with RegisterRevertErrorABI.
+var RevertErrorABIs = []string{
+	ERC20Inbox.ERC20InboxABI,
+	L1Teleporter.L1TeleporterABI,
+	ArbitrumOutbox.OutboxABI,
+	ArbRetryableTx.ArbRetryableTxABI,
+	ArbSys.ArbSysABI,
+}
+
+// Adds the ABI of a contract whose custom errors are decoded in revert reasons. It is meant to be called from init
+// functions, as it is not safe to call concurrently with DecodeRevert.
+func RegisterRevertErrorABI(abiJSON string) {
+	RevertErrorABIs = append(RevertErrorABIs, abiJSON)
+}
+
+// Simulates a Safe transaction with eth_call on the latest block, as if the Safe had the signatures to execute it.
+// A Call is simulated as a call from the Safe to its target. A DelegateCall is simulated as a call from the Safe to
+// itself with the code of its target, which needs an RPC that supports state overrides, such as geth or Nitro. If the
+// transaction reverts, the error wraps ErrSimulationFailed and holds the decoded revert reason.
+//
+// Transactions queued before this one are not taken into account.
+func SimulateTransaction(ctx context.Context, client Backend, safeAddress common.Address, txData TransactionData) error {
+	to := common.HexToAddress(txData.To)
+	data := common.FromHex(txData.Data)
+	value, valueOk := new(big.Int).SetString(txData.Value, 10)
+	if !valueOk {
+		return fmt.Errorf("invalid value in Safe transaction: %s", txData.Value)
+	}
+
+	var callErr error
+	switch txData.Operation {
+	case Call:
+		_, callErr = client.CallContract(ctx, ethereum.CallMsg{From: safeAddress, To: &to, Value: value, Data: data}, nil)
+	case DelegateCall:
+		rpcClient, rpcClientOk := client.(interface{ Client() *rpc.Client })
+		if !rpcClientOk {
+			return errors.New("cannot simulate a DelegateCall without an RPC client")
+		}
+
+		code, codeErr := client.CodeAt(ctx, to, nil)
+		if codeErr != nil {
+			return fmt.Errorf("failed to fetch code of %s: %v", to.Hex(), codeErr)
+		}
+		if len(code) == 0 {
+			return fmt.Errorf("%w: DelegateCall target %s has no code", ErrSimulationFailed, to.Hex())
+		}
+
+		overrides := map[common.Address]gethclient.OverrideAccount{safeAddress: {Code: code}}
+		_, callErr = gethclient.New(rpcClient.Client()).CallContract(ctx, ethereum.CallMsg{From: safeAddress, To: &safeAddress, Data: data}, nil, &overrides)
+	default:
+		return fmt.Errorf("cannot simulate a %s operation", txData.Operation.String())
+	}
+
+	if callErr == nil {
+		return nil
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(callErr, &dataErr) {
+		if revertData, revertDataOk := dataErr.ErrorData().(string); revertDataOk {
+			return fmt.Errorf("%w: %s", ErrSimulationFailed, DecodeRevert(common.FromHex(revertData)))
+		}
+	}
+	if strings.Contains(callErr.Error(), "revert") {
+		return fmt.Errorf("%w: %v", ErrSimulationFailed, callErr)
+	}
+	return fmt.Errorf("failed to simulate Safe transaction: %v", callErr)
+}
+
+// Returns a readable revert reason for the given revert data: the message of Error(string), the code of
+// Panic(uint256), or the name and arguments of a custom error of one of RevertErrorABIs. Unknown data is returned as
+// hex.
+func DecodeRevert(revertData []byte) string {
+	if len(revertData) == 0 {
+		return "execution reverted without a reason"
+	}
+
+	if reason, unpackErr := abi.UnpackRevert(revertData); unpackErr == nil {
+		return "execution reverted: " + reason
+	}
+
+	if len(revertData) >= 4 {
+		for _, abiJSON := range RevertErrorABIs {
+			contractAbi, contractAbiErr := abi.JSON(strings.NewReader(abiJSON))
+			if contractAbiErr != nil {
+				continue
+			}
+
+			for _, abiError := range contractAbi.Errors {
+				if !bytes.Equal(abiError.ID[:4], revertData[:4]) {
+					continue
+				}
+
+				args, unpackErr := abiError.Inputs.Unpack(revertData[4:])
+				if unpackErr != nil {
+					continue
+				}
+				formattedArgs := make([]string, len(args))
+				for i, arg := range args {
+					formattedArgs[i] = fmt.Sprintf("%v", arg)
+				}
+				return fmt.Sprintf("execution reverted: %s(%s)", abiError.Name, strings.Join(formattedArgs, ", "))
+			}
+		}
+	}
+
+	return "execution reverted: 0x" + common.Bytes2Hex(revertData)
+}
@@ -0,0 +1,225 @@
+package safe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/G7DAO/protocol/bindings/GnosisSafe"
+	"github.com/G7DAO/protocol/signer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// OperationType represents the type of operation for a Safe transaction
+type OperationType uint8
+
+const (
+	Call         OperationType = 0
+	DelegateCall OperationType = 1
+)
+
+// String returns the string representation of the OperationType
+func (o OperationType) String() string {
+	switch o {
+	case Call:
+		return "Call"
+	case DelegateCall:
+		return "DelegateCall"
+	default:
+		return "Unknown"
+	}
+}
+
+// TransactionData represents the data for a Safe transaction
+type TransactionData struct {
+	To             string        `json:"to"`
+	Value          string        `json:"value"`
+	Data           string        `json:"data"`
+	Operation      OperationType `json:"operation"`
+	SafeTxGas      uint64        `json:"safeTxGas"`
+	BaseGas        uint64        `json:"baseGas"`
+	GasPrice       string        `json:"gasPrice"`
+	GasToken       string        `json:"gasToken"`
+	RefundReceiver string        `json:"refundReceiver"`
+	Nonce          *big.Int      `json:"nonce"`
+}
+
+const (
+	NativeTokenAddress = "0x0000000000000000000000000000000000000000"
+)
+
+// Backend is the access to the chain of a Safe needed to propose and execute its transactions.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ethereum.ChainIDReader
+}
+
+// Returns the EIP-712 typed data of a Safe transaction, which owners sign to approve it.
+func TransactionTypedData(safeAddress common.Address, txData TransactionData, chainID *big.Int) apitypes.TypedData {
+	domainSeparator := apitypes.TypedDataDomain{
+		ChainId:           (*math.HexOrDecimal256)(chainID),
+		VerifyingContract: safeAddress.Hex(),
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"SafeTx": []apitypes.Type{
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		Domain:      domainSeparator,
+		PrimaryType: "SafeTx",
+		Message: apitypes.TypedDataMessage{
+			"to":             txData.To,
+			"value":          txData.Value,
+			"data":           "0x" + txData.Data,
+			"operation":      fmt.Sprintf("%d", txData.Operation),
+			"safeTxGas":      fmt.Sprintf("%d", txData.SafeTxGas),
+			"baseGas":        fmt.Sprintf("%d", txData.BaseGas),
+			"gasPrice":       txData.GasPrice,
+			"gasToken":       txData.GasToken,
+			"refundReceiver": txData.RefundReceiver,
+			"nonce":          fmt.Sprintf("%d", txData.Nonce),
+		},
+	}
+}
+
+func CalculateSafeTxHash(safeAddress common.Address, txData TransactionData, chainID *big.Int) (common.Hash, error) {
+	return signer.HashTypedData(TransactionTypedData(safeAddress, txData, chainID))
+}
+
+// Signs a SafeTxHash for submission to the Safe Transaction Service. Signers that cannot sign raw hashes (such as remote
+// signers) sign it as an EIP-191 message instead, which the Safe accepts as an eth_sign signature: V is shifted by 4.
+// Source: https://docs.safe.global/advanced/smart-account-signatures#eth_sign-signature
+func SignSafeTxHash(ctx context.Context, sender signer.Signer, safeTxHash common.Hash) ([]byte, error) {
+	signature, err := sender.SignHash(ctx, safeTxHash)
+	if errors.Is(err, signer.ErrHashSigningUnsupported) {
+		signature, err = sender.SignMessage(ctx, safeTxHash.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		signature[64] += 4
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Adjust V value for Ethereum's replay protection
+	signature[64] += 27
+
+	return signature, nil
+}
+
+// Returns the nonce that the next transaction executed by the Safe takes.
+func NextNonce(ctx context.Context, client Backend, safeAddress common.Address) (*big.Int, error) {
+	safeInstance, safeErr := GnosisSafe.NewGnosisSafe(safeAddress, client)
+	if safeErr != nil {
+		return nil, fmt.Errorf("failed to create GnosisSafe instance: %v", safeErr)
+	}
+
+	nonce, nonceErr := safeInstance.Nonce(&bind.CallOpts{Context: ctx})
+	if nonceErr != nil {
+		return nil, fmt.Errorf("failed to fetch nonce from Safe contract: %v", nonceErr)
+	}
+	return nonce, nil
+}
+
+// Returns a Safe transaction making the given call, with no gas refund. If nonce is nil, the next nonce of the Safe is
+// used.
+func NewTransaction(ctx context.Context, client Backend, safeAddress common.Address, to common.Address, data []byte, value *big.Int, operation OperationType, nonce *big.Int) (TransactionData, error) {
+	if nonce == nil {
+		var nonceErr error
+		nonce, nonceErr = NextNonce(ctx, client, safeAddress)
+		if nonceErr != nil {
+			return TransactionData{}, nonceErr
+		}
+	}
+
+	return TransactionData{
+		To:             to.Hex(),
+		Value:          value.String(),
+		Data:           common.Bytes2Hex(data),
+		Operation:      operation,
+		SafeTxGas:      0,
+		BaseGas:        0,
+		GasPrice:       "0",
+		GasToken:       NativeTokenAddress,
+		RefundReceiver: NativeTokenAddress,
+		Nonce:          nonce,
+	}, nil
+}
+
+// Signs a Safe transaction making the given call as the proposer and proposes it through safeClient or, if safeOut is
+// set, writes it to a bundle file for the other owners to sign offline. If nonce is nil, the next nonce of the Safe is
+// used. Unless force is set, the transaction is first simulated and not proposed if it reverts.
+func CreateProposal(ctx context.Context, client Backend, sender signer.Signer, safeClient *Client, safeOut string, force bool, safeAddress common.Address, to common.Address, data []byte, value *big.Int, operation OperationType, nonce *big.Int) error {
+	chainID, chainIDErr := client.ChainID(ctx)
+	if chainIDErr != nil {
+		return fmt.Errorf("failed to get chain ID: %v", chainIDErr)
+	}
+
+	txData, txDataErr := NewTransaction(ctx, client, safeAddress, to, data, value, operation, nonce)
+	if txDataErr != nil {
+		return txDataErr
+	}
+
+	if !force {
+		if simulateErr := SimulateTransaction(ctx, client, safeAddress, txData); simulateErr != nil {
+			return simulateErr
+		}
+	}
+
+	safeTxHash, hashErr := CalculateSafeTxHash(safeAddress, txData, chainID)
+	if hashErr != nil {
+		return fmt.Errorf("failed to calculate SafeTxHash: %v", hashErr)
+	}
+
+	signature, signErr := SignSafeTxHash(ctx, sender, safeTxHash)
+	if signErr != nil {
+		return fmt.Errorf("failed to sign SafeTxHash: %v", signErr)
+	}
+
+	if safeOut != "" {
+		bundle := &Bundle{
+			Safe:        safeAddress,
+			ChainID:     chainID,
+			Transaction: txData,
+			SafeTxHash:  safeTxHash,
+			Signatures:  []BundleSignature{{Owner: sender.Address(), Signature: signature}},
+		}
+		if writeErr := bundle.Write(safeOut); writeErr != nil {
+			return fmt.Errorf("failed to write Safe bundle: %v", writeErr)
+		}
+
+		fmt.Println("Safe transaction", safeTxHash.Hex(), "written to", safeOut)
+		return nil
+	}
+
+	if safeClient == nil {
+		return errors.New("no Safe API to propose the transaction to")
+	}
+	if proposeErr := safeClient.Propose(ctx, safeAddress, txData, safeTxHash, sender.Address(), signature); proposeErr != nil {
+		return proposeErr
+	}
+
+	fmt.Println("Safe proposal created successfully")
+	return nil
+}
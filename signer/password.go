@@ -0,0 +1,117 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Returns the password stored in the given file, without its trailing newline. Files that every user on the system can
+// read are refused, since the password would not be any safer than if it were passed on the command line.
+func ReadPasswordFile(passwordFile string) (string, error) {
+	info, statErr := os.Stat(passwordFile)
+	if statErr != nil {
+		return "", statErr
+	}
+	if info.Mode().Perm()&0o004 != 0 {
+		return "", fmt.Errorf("password file %s is readable by all users, restrict its permissions (for example with chmod 600)", passwordFile)
+	}
+
+	passwordRaw, readErr := os.ReadFile(passwordFile)
+	if readErr != nil {
+		return "", readErr
+	}
+
+	return trimNewline(string(passwordRaw)), nil
+}
+
+// Returns the password stored in the given environment variable, without its trailing newline.
+func ReadPasswordEnv(variable string) (string, error) {
+	password, ok := os.LookupEnv(variable)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", variable)
+	}
+
+	return trimNewline(password), nil
+}
+
+// Returns the password given by exactly one of a literal password, a password file and an environment variable, or
+// the empty string if none of them is given (in which case callers prompt for the password).
+func ResolvePassword(password string, passwordFile string, passwordEnv string) (string, error) {
+	given := 0
+	for _, source := range []string{password, passwordFile, passwordEnv} {
+		if source != "" {
+			given++
+		}
+	}
+	if given > 1 {
+		return "", errors.New("only one of a password, a password file and a password environment variable may be given")
+	}
+
+	if passwordFile != "" {
+		return ReadPasswordFile(passwordFile)
+	}
+	if passwordEnv != "" {
+		return ReadPasswordEnv(passwordEnv)
+	}
+	return password, nil
+}
+
+// Adds --<name>-file and --<name>-env flags next to every string flag of the command tree whose name ends in
+// "password", such as --password or --new-password. Before a command runs, the password read from the file or
+// environment variable is set as the value of the password flag, so commands only ever look at the password flag.
+// This also covers the generated contract binding commands, which cannot be edited directly.
+func AddPasswordSourceFlags(cmd *cobra.Command) {
+	var passwordFlags []*pflag.Flag
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if strings.HasSuffix(flag.Name, "password") && flag.Value.Type() == "string" {
+			passwordFlags = append(passwordFlags, flag)
+		}
+	})
+
+	if len(passwordFlags) > 0 {
+		passwordFiles := make([]string, len(passwordFlags))
+		passwordEnvs := make([]string, len(passwordFlags))
+		for i, flag := range passwordFlags {
+			cmd.Flags().StringVar(&passwordFiles[i], flag.Name+"-file", "", fmt.Sprintf("File holding the value for --%s (must not be readable by all users)", flag.Name))
+			cmd.Flags().StringVar(&passwordEnvs[i], flag.Name+"-env", "", fmt.Sprintf("Environment variable holding the value for --%s", flag.Name))
+		}
+
+		preRunE, preRun := cmd.PreRunE, cmd.PreRun
+		cmd.PreRun = nil
+		cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+			for i, flag := range passwordFlags {
+				password, passwordErr := ResolvePassword(flag.Value.String(), passwordFiles[i], passwordEnvs[i])
+				if passwordErr != nil {
+					return fmt.Errorf("--%s: %w", flag.Name, passwordErr)
+				}
+				if setErr := cmd.Flags().Set(flag.Name, password); setErr != nil {
+					return setErr
+				}
+			}
+
+			if preRunE != nil {
+				return preRunE(cmd, args)
+			}
+			if preRun != nil {
+				preRun(cmd, args)
+			}
+			return nil
+		}
+	}
+
+	for _, subcommand := range cmd.Commands() {
+		AddPasswordSourceFlags(subcommand)
+	}
+}
+
+func trimNewline(password string) string {
+	if trimmed, ok := strings.CutSuffix(password, "\n"); ok {
+		return strings.TrimSuffix(trimmed, "\r")
+	}
+	return password
+}
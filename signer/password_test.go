@@ -0,0 +1,68 @@
+package signer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestReadPasswordFile(t *testing.T) {
+	dir := t.TempDir()
+
+	passwordFile := filepath.Join(dir, "password")
+	if writeErr := os.WriteFile(passwordFile, []byte("hunter2\n\n"), 0600); writeErr != nil {
+		t.Fatal(writeErr)
+	}
+	password, passwordErr := ReadPasswordFile(passwordFile)
+	if passwordErr != nil {
+		t.Fatal(passwordErr)
+	}
+	if password != "hunter2\n" {
+		t.Errorf("Expected exactly one trailing newline to be trimmed, got %q", password)
+	}
+
+	publicFile := filepath.Join(dir, "public")
+	if writeErr := os.WriteFile(publicFile, []byte("hunter2"), 0644); writeErr != nil {
+		t.Fatal(writeErr)
+	}
+	if chmodErr := os.Chmod(publicFile, 0644); chmodErr != nil {
+		t.Fatal(chmodErr)
+	}
+	if _, publicErr := ReadPasswordFile(publicFile); publicErr == nil {
+		t.Error("Expected an error for a world-readable password file")
+	}
+}
+
+func TestAddPasswordSourceFlags(t *testing.T) {
+	var password string
+	var preRunPassword string
+	root := &cobra.Command{Use: "root"}
+	child := &cobra.Command{
+		Use: "child",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			preRunPassword = password
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {},
+	}
+	child.Flags().StringVar(&password, "password", "", "")
+	root.AddCommand(child)
+	AddPasswordSourceFlags(root)
+
+	t.Setenv("TEST_SIGNER_PASSWORD", "hunter2\n")
+	root.SetArgs([]string{"child", "--password-env", "TEST_SIGNER_PASSWORD"})
+	if executeErr := root.Execute(); executeErr != nil {
+		t.Fatal(executeErr)
+	}
+	if preRunPassword != "hunter2" {
+		t.Errorf("Expected the password from the environment to be set before the command's PreRunE, got %q", preRunPassword)
+	}
+
+	root.SetArgs([]string{"child", "--password", "a", "--password-env", "TEST_SIGNER_PASSWORD"})
+	root.SilenceErrors, root.SilenceUsage = true, true
+	if conflictErr := root.Execute(); conflictErr == nil {
+		t.Error("Expected an error when both --password and --password-env are given")
+	}
+}
@@ -0,0 +1,156 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// RemoteSigner delegates signing to a JSON-RPC signer holding the key: either a node or signing service exposing
+// eth_signTransaction and eth_sign, or Clef (account_signTransaction and account_signData).
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+	clef    bool
+}
+
+type signTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// Connects to the remote signer at the given URL or IPC path. If address is the zero address, the signer must manage
+// exactly one account, which is used.
+func DialRemoteSigner(ctx context.Context, url string, address common.Address, clef bool) (*RemoteSigner, error) {
+	client, clientErr := rpc.DialContext(ctx, url)
+	if clientErr != nil {
+		return nil, clientErr
+	}
+
+	signer := &RemoteSigner{client: client, address: address, clef: clef}
+	if address == (common.Address{}) {
+		listMethod := "eth_accounts"
+		if clef {
+			listMethod = "account_list"
+		}
+
+		var addresses []common.Address
+		listErr := client.CallContext(ctx, &addresses, listMethod)
+		if listErr != nil {
+			return nil, fmt.Errorf("could not list the accounts of the remote signer: %w", listErr)
+		}
+		if len(addresses) != 1 {
+			return nil, fmt.Errorf("remote signer manages %d accounts, choose one with the from parameter", len(addresses))
+		}
+		signer.address = addresses[0]
+	}
+
+	return signer, nil
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *RemoteSigner) SignTx(ctx context.Context, transaction *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := map[string]interface{}{
+		"from":    s.address,
+		"gas":     hexutil.Uint64(transaction.Gas()),
+		"value":   (*hexutil.Big)(transaction.Value()),
+		"nonce":   hexutil.Uint64(transaction.Nonce()),
+		"data":    hexutil.Bytes(transaction.Data()),
+		"chainId": (*hexutil.Big)(chainID),
+	}
+	if transaction.To() != nil {
+		args["to"] = transaction.To()
+	}
+	if transaction.Type() == types.DynamicFeeTxType {
+		args["maxFeePerGas"] = (*hexutil.Big)(transaction.GasFeeCap())
+		args["maxPriorityFeePerGas"] = (*hexutil.Big)(transaction.GasTipCap())
+	} else {
+		args["gasPrice"] = (*hexutil.Big)(transaction.GasPrice())
+	}
+
+	method := "eth_signTransaction"
+	if s.clef {
+		method = "account_signTransaction"
+	}
+
+	var result signTransactionResult
+	callErr := s.client.CallContext(ctx, &result, method, args)
+	if callErr != nil {
+		return nil, callErr
+	}
+
+	signedTransaction := new(types.Transaction)
+	if decodeErr := signedTransaction.UnmarshalBinary(result.Raw); decodeErr != nil {
+		return nil, fmt.Errorf("could not decode the transaction returned by the remote signer: %w", decodeErr)
+	}
+
+	// The remote signer must sign exactly the transaction it was given, with the expected account.
+	chainSigner := types.LatestSignerForChainID(chainID)
+	if chainSigner.Hash(signedTransaction) != chainSigner.Hash(transaction) {
+		return nil, fmt.Errorf("remote signer returned a transaction that differs from the one it was asked to sign")
+	}
+	sender, senderErr := types.Sender(chainSigner, signedTransaction)
+	if senderErr != nil {
+		return nil, senderErr
+	}
+	if sender != s.address {
+		return nil, fmt.Errorf("remote signer signed with %s instead of %s", sender.Hex(), s.address.Hex())
+	}
+
+	return signedTransaction, nil
+}
+
+// Remote signers only sign prefixed messages, so this always returns ErrHashSigningUnsupported.
+func (s *RemoteSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return nil, ErrHashSigningUnsupported
+}
+
+func (s *RemoteSigner) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
+	var signature hexutil.Bytes
+	var callErr error
+	if s.clef {
+		callErr = s.client.CallContext(ctx, &signature, "account_signData", "text/plain", s.address, hexutil.Bytes(message))
+	} else {
+		callErr = s.client.CallContext(ctx, &signature, "eth_sign", s.address, hexutil.Bytes(message))
+	}
+	if callErr != nil {
+		return nil, callErr
+	}
+
+	return normalizeSignature(signature)
+}
+
+func (s *RemoteSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	method := "eth_signTypedData_v4"
+	if s.clef {
+		method = "account_signTypedData"
+	}
+
+	var signature hexutil.Bytes
+	callErr := s.client.CallContext(ctx, &signature, method, s.address, typedData)
+	if callErr != nil {
+		return nil, callErr
+	}
+
+	return normalizeSignature(signature)
+}
+
+// Checks the length of a signature returned by a remote signer and brings its V to 0 or 1.
+func normalizeSignature(signature []byte) ([]byte, error) {
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("remote signer returned a signature of %d bytes", len(signature))
+	}
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+
+	return signature, nil
+}
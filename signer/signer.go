@@ -0,0 +1,125 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"golang.org/x/term"
+)
+
+var ErrHashSigningUnsupported = errors.New("signer cannot sign raw hashes")
+
+// Signer signs transactions and messages on behalf of a single account. Signatures are returned in the
+// [R || S || V] format, with V being 0 or 1, as produced by crypto.Sign.
+type Signer interface {
+	// Address of the account that the signer signs for.
+	Address() common.Address
+	// Signs a transaction for the given chain.
+	SignTx(ctx context.Context, transaction *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	// Signs a 32 byte hash as is. Signers that only sign prefixed messages return ErrHashSigningUnsupported.
+	SignHash(ctx context.Context, hash common.Hash) ([]byte, error)
+	// Signs a message with the EIP-191 "\x19Ethereum Signed Message:\n" prefix, as personal_sign does.
+	SignMessage(ctx context.Context, message []byte) ([]byte, error)
+	// Signs EIP-712 typed data, as eth_signTypedData_v4 does.
+	SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error)
+}
+
+// LocalSigner signs with a private key held in memory, decrypted from a keystore file or read from the environment.
+type LocalSigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+func NewLocalSigner(privateKey *ecdsa.PrivateKey) *LocalSigner {
+	return &LocalSigner{privateKey: privateKey, address: crypto.PubkeyToAddress(privateKey.PublicKey)}
+}
+
+// Returns a signer for the key in the given keystore file. If password is empty, the user is prompted for it.
+func NewKeystoreSigner(keyFile string, password string) (*LocalSigner, error) {
+	keystoreContent, readErr := os.ReadFile(keyFile)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	if password == "" {
+		fmt.Printf("Please provide a password for keystore (%s): ", keyFile)
+		passwordRaw, inputErr := term.ReadPassword(int(os.Stdin.Fd()))
+		if inputErr != nil {
+			return nil, fmt.Errorf("error reading password: %s", inputErr.Error())
+		}
+		fmt.Print("\n")
+		password = string(passwordRaw)
+	}
+
+	key, keyErr := keystore.DecryptKey(keystoreContent, password)
+	if keyErr != nil {
+		return nil, keyErr
+	}
+
+	return NewLocalSigner(key.PrivateKey), nil
+}
+
+// Returns a signer for the hex encoded private key stored in the given environment variable.
+func NewEnvSigner(variable string) (*LocalSigner, error) {
+	privateKeyRaw, ok := os.LookupEnv(variable)
+	if !ok || privateKeyRaw == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", variable)
+	}
+
+	privateKey, privateKeyErr := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(privateKeyRaw), "0x"))
+	if privateKeyErr != nil {
+		return nil, fmt.Errorf("environment variable %s does not hold a valid private key: %w", variable, privateKeyErr)
+	}
+
+	return NewLocalSigner(privateKey), nil
+}
+
+func (s *LocalSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *LocalSigner) SignTx(ctx context.Context, transaction *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(transaction, types.NewLondonSigner(chainID), s.privateKey)
+}
+
+func (s *LocalSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return crypto.Sign(hash.Bytes(), s.privateKey)
+}
+
+func (s *LocalSigner) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
+	return crypto.Sign(accounts.TextHash(message), s.privateKey)
+}
+
+func (s *LocalSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	hash, hashErr := HashTypedData(typedData)
+	if hashErr != nil {
+		return nil, hashErr
+	}
+	return crypto.Sign(hash.Bytes(), s.privateKey)
+}
+
+// Returns transact options that sign the transactions of generated contract bindings with the given signer.
+func NewTransactOpts(ctx context.Context, signer Signer, chainID *big.Int) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:    signer.Address(),
+		Context: ctx,
+		Signer: func(address common.Address, transaction *types.Transaction) (*types.Transaction, error) {
+			if address != signer.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(ctx, transaction, chainID)
+		},
+	}
+}
@@ -0,0 +1,210 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func newTestTransaction(chainID *big.Int) *types.Transaction {
+	to := common.HexToAddress("0x1")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(42),
+	})
+}
+
+func newTestTypedData() apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"Attestation": []apitypes.Type{
+				{Name: "subject", Type: "address"},
+				{Name: "score", Type: "uint256"},
+			},
+		},
+		Domain:      apitypes.TypedDataDomain{Name: "Game7", ChainId: math.NewHexOrDecimal256(13746)},
+		PrimaryType: "Attestation",
+		Message:     apitypes.TypedDataMessage{"subject": "0x0000000000000000000000000000000000000001", "score": "42"},
+	}
+}
+
+// Serves eth_accounts, eth_signTransaction, eth_sign and eth_signTypedData_v4 for the given signer, the way a node holding its key would.
+func newRemoteSignerStub(t *testing.T, local *LocalSigner, chainID *big.Int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatal(err)
+		}
+
+		var result interface{}
+		switch request.Method {
+		case "eth_accounts":
+			result = []common.Address{local.Address()}
+		case "eth_signTransaction":
+			var args struct {
+				To    common.Address `json:"to"`
+				Nonce hexutil.Uint64 `json:"nonce"`
+				Value *hexutil.Big   `json:"value"`
+			}
+			if err := json.Unmarshal(request.Params[0], &args); err != nil {
+				t.Fatal(err)
+			}
+			transaction := newTestTransaction(chainID)
+			if args.To != *transaction.To() || uint64(args.Nonce) != transaction.Nonce() || args.Value.ToInt().Cmp(transaction.Value()) != 0 {
+				t.Errorf("Unexpected eth_signTransaction arguments: %s", request.Params[0])
+			}
+			signedTransaction, err := local.SignTx(context.Background(), transaction, chainID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			raw, err := signedTransaction.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			result = map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signedTransaction}
+		case "eth_sign":
+			var message hexutil.Bytes
+			if err := json.Unmarshal(request.Params[1], &message); err != nil {
+				t.Fatal(err)
+			}
+			signature, err := local.SignMessage(context.Background(), message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			signature[64] += 27
+			result = hexutil.Bytes(signature)
+		case "eth_signTypedData_v4":
+			var typedData apitypes.TypedData
+			if err := json.Unmarshal(request.Params[1], &typedData); err != nil {
+				t.Fatal(err)
+			}
+			signature, err := local.SignTypedData(context.Background(), typedData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			signature[64] += 27
+			result = hexutil.Bytes(signature)
+		default:
+			t.Errorf("Unexpected method %s", request.Method)
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRemoteSigner(t *testing.T) {
+	ctx := context.Background()
+	chainID := big.NewInt(13746)
+
+	privateKey, privateKeyErr := crypto.GenerateKey()
+	if privateKeyErr != nil {
+		t.Fatal(privateKeyErr)
+	}
+	local := NewLocalSigner(privateKey)
+	server := newRemoteSignerStub(t, local, chainID)
+
+	remote, remoteErr := FromURI(ctx, server.URL, "")
+	if remoteErr != nil {
+		t.Fatal(remoteErr)
+	}
+	if remote.Address() != local.Address() {
+		t.Fatalf("Expected the remote signer to sign for %s, got %s", local.Address().Hex(), remote.Address().Hex())
+	}
+
+	signedTransaction, signErr := remote.SignTx(ctx, newTestTransaction(chainID), chainID)
+	if signErr != nil {
+		t.Fatal(signErr)
+	}
+	sender, senderErr := types.Sender(types.LatestSignerForChainID(chainID), signedTransaction)
+	if senderErr != nil {
+		t.Fatal(senderErr)
+	}
+	if sender != local.Address() {
+		t.Errorf("Expected transaction signed by %s, got %s", local.Address().Hex(), sender.Hex())
+	}
+
+	message := []byte("hello")
+	signature, signatureErr := remote.SignMessage(ctx, message)
+	if signatureErr != nil {
+		t.Fatal(signatureErr)
+	}
+	messageSigner, recoverErr := RecoverMessageSigner(message, signature)
+	if recoverErr != nil {
+		t.Fatal(recoverErr)
+	}
+	if messageSigner != local.Address() {
+		t.Errorf("Expected message signed by %s, got %s", local.Address().Hex(), messageSigner.Hex())
+	}
+
+	typedData := newTestTypedData()
+	typedDataSignature, typedDataErr := remote.SignTypedData(ctx, typedData)
+	if typedDataErr != nil {
+		t.Fatal(typedDataErr)
+	}
+	typedDataSigner, recoverErr := RecoverTypedDataSigner(typedData, typedDataSignature)
+	if recoverErr != nil {
+		t.Fatal(recoverErr)
+	}
+	if typedDataSigner != local.Address() {
+		t.Errorf("Expected typed data signed by %s, got %s", local.Address().Hex(), typedDataSigner.Hex())
+	}
+
+	if _, hashErr := remote.SignHash(ctx, common.Hash{}); hashErr != ErrHashSigningUnsupported {
+		t.Errorf("Expected ErrHashSigningUnsupported, got %v", hashErr)
+	}
+}
+
+func TestFromURI(t *testing.T) {
+	ctx := context.Background()
+	privateKey, privateKeyErr := crypto.GenerateKey()
+	if privateKeyErr != nil {
+		t.Fatal(privateKeyErr)
+	}
+
+	t.Setenv("TEST_SIGNER_PRIVATE_KEY", "0x"+common.Bytes2Hex(crypto.FromECDSA(privateKey)))
+	envSigner, envSignerErr := FromURI(ctx, "env:TEST_SIGNER_PRIVATE_KEY", "")
+	if envSignerErr != nil {
+		t.Fatal(envSignerErr)
+	}
+	if envSigner.Address() != crypto.PubkeyToAddress(privateKey.PublicKey) {
+		t.Errorf("Unexpected address for env signer: %s", envSigner.Address().Hex())
+	}
+
+	if _, missingErr := FromURI(ctx, "env:TEST_SIGNER_MISSING", ""); missingErr == nil {
+		t.Error("Expected an error for an unset environment variable")
+	}
+
+	if _, schemeErr := FromURI(ctx, "ledger://0", ""); schemeErr == nil {
+		t.Error("Expected an error for an unsupported scheme")
+	}
+
+	if _, noSignerErr := FromFlags(ctx, "", "", ""); noSignerErr != ErrNoSigner {
+		t.Errorf("Expected ErrNoSigner, got %v", noSignerErr)
+	}
+}
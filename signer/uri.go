@@ -0,0 +1,71 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var ErrNoSigner = errors.New("no signer specified (use --signer or --keyfile)")
+
+// Returns the signer described by a signer URI:
+//   - keystore:<path> signs with a keystore file, decrypted with the given password.
+//   - env:<VARIABLE> signs with the hex encoded private key stored in an environment variable.
+//   - http(s)://, ws(s):// signs through a JSON-RPC signer exposing eth_signTransaction and eth_sign.
+//   - clef:<url or IPC path> signs through Clef.
+//
+// Remote signers take the account to sign with as a from query parameter, for example
+// http://localhost:8545?from=0x..., which can be left out when the signer manages a single account.
+func FromURI(ctx context.Context, uri string, password string) (Signer, error) {
+	if keyFile, ok := strings.CutPrefix(uri, "keystore:"); ok {
+		return NewKeystoreSigner(strings.TrimPrefix(keyFile, "//"), password)
+	}
+
+	if variable, ok := strings.CutPrefix(uri, "env:"); ok {
+		return NewEnvSigner(variable)
+	}
+
+	target, clef := strings.CutPrefix(uri, "clef:")
+	if !clef && !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") && !strings.HasPrefix(target, "ws://") && !strings.HasPrefix(target, "wss://") {
+		return nil, fmt.Errorf("unsupported signer URI %q (expected keystore:, env:, clef:, http(s):// or ws(s)://)", uri)
+	}
+
+	parsedTarget, parseErr := url.Parse(target)
+	if parseErr != nil {
+		return nil, fmt.Errorf("invalid signer URI %q: %w", uri, parseErr)
+	}
+
+	address := common.Address{}
+	query := parsedTarget.Query()
+	if from := query.Get("from"); from != "" {
+		if !common.IsHexAddress(from) {
+			return nil, fmt.Errorf("from parameter of signer URI is not a valid Ethereum address: %s", from)
+		}
+		address = common.HexToAddress(from)
+	}
+	query.Del("from")
+	parsedTarget.RawQuery = query.Encode()
+
+	return DialRemoteSigner(ctx, parsedTarget.String(), address, clef)
+}
+
+// Returns the signer selected by --signer, falling back to the keystore file given with --keyfile.
+func FromFlags(ctx context.Context, signerURI string, keyFile string, password string) (Signer, error) {
+	if signerURI != "" {
+		return FromURI(ctx, signerURI, password)
+	}
+	if keyFile != "" {
+		return NewKeystoreSigner(keyFile, password)
+	}
+	return nil, ErrNoSigner
+}
+
+// Adds the --signer flag to a command.
+func AddSignerFlag(cmd *cobra.Command, signerURI *string) {
+	cmd.Flags().StringVar(signerURI, "signer", "", "Signer URI, overriding --keyfile: keystore:<path>, env:<VARIABLE>, an http(s):// or ws(s):// JSON-RPC signer, or clef:<url or IPC path> (add ?from=<address> to pick the account of a remote signer)")
+}
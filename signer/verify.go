@@ -0,0 +1,54 @@
+package signer
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Returns the EIP-712 hash of typed data, which is what gets signed.
+func HashTypedData(typedData apitypes.TypedData) (common.Hash, error) {
+	hash, _, hashErr := apitypes.TypedDataAndHash(typedData)
+	if hashErr != nil {
+		return common.Hash{}, fmt.Errorf("failed to hash typed data: %w", hashErr)
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// Returns the address whose key produced the given signature of a hash. V may be 0 or 1, or 27 or 28.
+func RecoverHashSigner(hash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != 65 {
+		return common.Address{}, fmt.Errorf("signature must be 65 bytes long, not %d", len(signature))
+	}
+
+	normalizedSignature := common.CopyBytes(signature)
+	if normalizedSignature[64] >= 27 {
+		normalizedSignature[64] -= 27
+	}
+	if normalizedSignature[64] > 1 {
+		return common.Address{}, fmt.Errorf("invalid signature recovery id %d", signature[64])
+	}
+
+	publicKey, recoverErr := crypto.SigToPub(hash.Bytes(), normalizedSignature)
+	if recoverErr != nil {
+		return common.Address{}, recoverErr
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// Returns the address that signed a message with the EIP-191 "\x19Ethereum Signed Message:\n" prefix.
+func RecoverMessageSigner(message []byte, signature []byte) (common.Address, error) {
+	return RecoverHashSigner(common.BytesToHash(accounts.TextHash(message)), signature)
+}
+
+// Returns the address that signed EIP-712 typed data.
+func RecoverTypedDataSigner(typedData apitypes.TypedData, signature []byte) (common.Address, error) {
+	hash, hashErr := HashTypedData(typedData)
+	if hashErr != nil {
+		return common.Address{}, hashErr
+	}
+	return RecoverHashSigner(hash, signature)
+}